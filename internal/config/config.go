@@ -2,16 +2,429 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const DefaultGatewayURL = "http://127.0.0.1:18789"
 
+// DefaultWorkspaceCommands are offered for running in a session's
+// workspace unless commander.json lists others.
+var DefaultWorkspaceCommands = []string{"git status --short", "git log --oneline -5"}
+
+// DefaultFetchDepth is how many messages or log lines are fetched at once
+// unless commander.json says otherwise.
+const DefaultFetchDepth = 200
+
+// DefaultLogMemoryMB is how many megabytes of formatted log content are
+// kept in memory unless commander.json says otherwise.
+const DefaultLogMemoryMB = 8
+
+// DefaultProcessLogBuffer is how many lines of each followed process's
+// output are kept unless commander.json says otherwise.
+const DefaultProcessLogBuffer = 10000
+
 // Config holds the gateway connection settings.
 type Config struct {
 	GatewayURL string
 	Token      string
+	// Transport is the gateway protocol: TransportHTTP (OpenClaw's
+	// /tools/invoke, the default) or TransportMCP, where GatewayURL is the
+	// MCP server's endpoint.
+	Transport string
+	// Proxy is an explicit proxy URL (http://, https://, socks5://).
+	// When empty, HTTP_PROXY/HTTPS_PROXY/NO_PROXY from the environment apply.
+	Proxy string
+
+	// Retention controls automatic cleanup of archived transcripts.
+	Retention Retention
+
+	// AutoArchive moves idle sessions out of the Sessions tab.
+	AutoArchive AutoArchive
+
+	// LoopGuard flags sessions that repeat a tool call.
+	LoopGuard LoopGuard
+
+	// Exporters are the backends History runs are pushed to, beyond the
+	// local export directory.
+	Exporters []Exporter
+
+	// Operator names whoever runs this Commander on the session locks it
+	// takes, and LockDir is where the locks are kept; a shared directory
+	// lets operators on other machines see them.
+	Operator string
+	LockDir  string
+
+	// ModelColors maps model IDs, aliases, or substrings (e.g. "opus") to
+	// colors used to tell models apart; unlisted models get a stable color.
+	ModelColors map[string]string
+
+	// PrivacyTimeout hides transcript content after this long without
+	// input, until a key is pressed. Zero disables it.
+	PrivacyTimeout time.Duration
+
+	// PromptHistory enables remembering sent prompts and messages for
+	// recall with up/down and ctrl+r. On unless disabled in commander.json.
+	PromptHistory bool
+
+	// A11y selects screen-reader friendly output: words instead of emoji
+	// and glyphs, and panels stacked in reading order.
+	A11y bool
+
+	// NoColor disables all color output (NO_COLOR, --no-color).
+	NoColor bool
+
+	// NoEmoji replaces emoji and other non-ASCII glyphs with ASCII
+	// (--no-emoji). Both are implied by TERM=dumb.
+	NoEmoji bool
+
+	// Glyphs selects the status indicators: GlyphsEmoji, or GlyphsASCII
+	// for terminals and fonts that render emoji poorly, where they become
+	// colored ASCII and decorative emoji are dropped. ResolveGlyphs turns
+	// GlyphsAuto (or empty) into one of them.
+	Glyphs string
+
+	// StatusGlyphs overrides the indicator of a session status (running,
+	// completed, failed, idle); the status color still applies.
+	StatusGlyphs map[string]string
+
+	// StartSession, StartTab, and StartFilter come from the --session,
+	// --tab, and --filter flags: a session key, ID, or label to open at
+	// startup, one of StartTabs (or its number) to show, and a list filter.
+	// StartTab may also come from commander.json; empty or StartTabAuto
+	// lets the TUI pick it from what it finds at startup.
+	StartSession string
+	StartTab     string
+	StartFilter  string
+
+	// TranscriptDirs are extra directories scanned for transcripts to list
+	// in the History tab, e.g. ~/.claude/projects. The format of each file
+	// is detected when it is opened.
+	TranscriptDirs []string
+
+	// FetchDepth is how many messages are fetched and kept per session
+	// or transcript; scrolling past the top of the log loads this many more.
+	FetchDepth int
+
+	// ProcessLogLines is how many lines of a process log are fetched.
+	ProcessLogLines int
+
+	// ProcessLogBuffer is how many lines of each followed process's output
+	// are kept in memory, beyond what one fetch returns, for saving to a
+	// file after the gateway has dropped them.
+	ProcessLogBuffer int
+
+	// LogMemory caps the bytes of formatted log content held in memory;
+	// older content is spilled to a temp file and read back on scrolling up.
+	LogMemory int64
+
+	// KillSignals maps a process name or command substring to the signal
+	// preselected when killing it (TERM, INT, HUP, or KILL).
+	KillSignals map[string]string
+
+	// Tools overrides how tools are shown in logs, keyed by tool name or by
+	// a name prefix ending in "*" (see ToolStyleFor).
+	Tools map[string]ToolStyle
+
+	// ImagePathPattern is the regular expression that finds image paths
+	// in tool output for the open and copy-path actions. Empty means the
+	// built-in pattern.
+	ImagePathPattern string
+
+	// Confirm maps destructive actions to how they are confirmed (see
+	// ConfirmLevel); ConfirmEnvironments overrides it for gateways whose URL
+	// contains the key.
+	Confirm             map[string]string
+	ConfirmEnvironments map[string]map[string]string
+
+	// Environments maps gateway URL substrings to how the Commander marks
+	// that gateway, e.g. a red accent for production.
+	Environments map[string]Environment
+
+	// WorkspaceCommands are the shell commands offered for running in a
+	// session's workspace, the first preselected.
+	WorkspaceCommands []string
+}
+
+// Environment marks the gateway the Commander is attached to.
+type Environment struct {
+	Label  string `json:"label"`  // shown in the status bar; the URL match if empty
+	Accent string `json:"accent"` // a name in AccentNames, #rrggbb, or an ANSI color number
+}
+
+// AccentNames are the named environment accent colors.
+var AccentNames = []string{"red", "orange", "yellow", "green", "blue", "purple"}
+
+// Environment returns the environment whose key is the longest match in the
+// gateway URL.
+func (c Config) Environment() (Environment, bool) {
+	var best Environment
+	bestMatch := ""
+	for match, env := range c.Environments {
+		if len(match) > len(bestMatch) && strings.Contains(c.GatewayURL, match) {
+			best, bestMatch = env, match
+		}
+	}
+	if best.Label == "" {
+		best.Label = bestMatch
+	}
+	return best, bestMatch != ""
+}
+
+// Gateway transports.
+const (
+	TransportHTTP = "http" // POST /tools/invoke, GET /health
+	TransportMCP  = "mcp"  // MCP JSON-RPC: tools/call, tools/list, ping
+)
+
+// Status indicator sets.
+const (
+	GlyphsAuto  = "auto"  // ASCII when the terminal looks unable to show emoji
+	GlyphsEmoji = "emoji" // 🟡 ✅ ❌ ⚪
+	GlyphsASCII = "ascii" // colored * + x -
+)
+
+// StartTabs are the tabs --tab accepts, in order; 1-4 also select them.
+var StartTabs = []string{"sessions", "processes", "history", "activity"}
+
+// StartTabAuto picks the start tab from the first health check and process
+// list: History when the gateway is down, Processes when one has failed,
+// Sessions otherwise.
+const StartTabAuto = "auto"
+
+// DetectGlyphs guesses whether the terminal shows emoji well. The Linux
+// console, non-UTF-8 locales, and the classic Windows console don't.
+func DetectGlyphs() string {
+	if os.Getenv("TERM") == "linux" {
+		return GlyphsASCII
+	}
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			v = strings.ToLower(v)
+			if !strings.Contains(v, "utf-8") && !strings.Contains(v, "utf8") {
+				return GlyphsASCII
+			}
+			break
+		}
+	}
+	if runtime.GOOS == "windows" && os.Getenv("WT_SESSION") == "" && os.Getenv("TERM_PROGRAM") == "" {
+		return GlyphsASCII
+	}
+	return GlyphsEmoji
+}
+
+// ResolveGlyphs picks the indicator set when Glyphs is GlyphsAuto or unset.
+func (c *Config) ResolveGlyphs() {
+	if c.Glyphs == "" || c.Glyphs == GlyphsAuto {
+		c.Glyphs = DetectGlyphs()
+	}
+}
+
+// StartTabIndex returns the position in StartTabs of a --tab value, a tab
+// name or its number from 1, or -1 when it names no tab.
+func StartTabIndex(tab string) int {
+	tab = strings.ToLower(strings.TrimSpace(tab))
+	for i, name := range StartTabs {
+		if tab == name || tab == fmt.Sprint(i+1) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Destructive actions whose confirmation can be configured.
+const (
+	ActionKill  = "kill"  // signal a process
+	ActionAbort = "abort" // abort a session's run
+	ActionPurge = "purge" // delete archived transcripts
+	ActionSend  = "send"  // deliver a message to an external channel
+)
+
+// Confirmation levels: run immediately, ask y/n, or require typing the
+// target's name.
+const (
+	ConfirmNone = "none"
+	ConfirmAsk  = "ask"
+	ConfirmType = "type"
+)
+
+// defaultConfirm is the confirmation of each action unless configured.
+var defaultConfirm = map[string]string{
+	ActionKill:  ConfirmAsk,
+	ActionAbort: ConfirmNone,
+	ActionPurge: ConfirmAsk,
+	ActionSend:  ConfirmAsk,
+}
+
+// ConfirmLevel returns how action must be confirmed against this gateway:
+// the built-in default, overridden by the confirm setting, overridden by the
+// environment whose key is the longest match in the gateway URL.
+func (c Config) ConfirmLevel(action string) string {
+	level := defaultConfirm[action]
+	if v, ok := c.Confirm[action]; ok {
+		level = v
+	}
+	bestLen := 0
+	for match, env := range c.ConfirmEnvironments {
+		if v, ok := env[action]; ok && len(match) > bestLen && strings.Contains(c.GatewayURL, match) {
+			level, bestLen = v, len(match)
+		}
+	}
+	return level
+}
+
+// Retention describes which archived runs may be purged. Zero values
+// disable the corresponding rule.
+type Retention struct {
+	MaxAge        time.Duration // purge runs older than this
+	MaxTotalBytes int64         // purge oldest runs until the archive fits
+	KeepLabeled   bool          // never purge runs the operator labeled
+	OnStartup     bool          // evaluate the policy when the TUI starts
+}
+
+// Enabled reports whether any purge rule is configured.
+func (r Retention) Enabled() bool {
+	return r.MaxAge > 0 || r.MaxTotalBytes > 0
+}
+
+// AutoArchive describes which sessions are archived for being idle. A zero
+// Idle disables it.
+type AutoArchive struct {
+	Idle time.Duration // archive sessions idle for longer than this
+	Ask  bool          // offer to archive them instead of doing it at once
+}
+
+// DefaultLoopMessage is sent to a looping session when LoopGuard.Stop is
+// set and no message is configured.
+const DefaultLoopMessage = "You have made the same tool call with the same arguments several times in a row. Stop, explain what you are trying to do, and try a different approach."
+
+// LoopGuard describes when repeated tool calls count as a possible loop
+// and whether the session is told to stop.
+type LoopGuard struct {
+	Repeats int           // identical calls that make a loop
+	Window  time.Duration // how close together they must be
+	Stop    bool          // message the session when a loop is found
+	Message string        // what to send; DefaultLoopMessage when empty
+}
+
+// commanderJSON mirrors ~/.openclaw/commander.json, which holds settings
+// specific to the Commander rather than the gateway.
+type commanderJSON struct {
+	Retention struct {
+		MaxAgeDays  float64 `json:"maxAgeDays"`
+		MaxTotalMB  float64 `json:"maxTotalMB"`
+		KeepLabeled bool    `json:"keepLabeled"`
+		OnStartup   bool    `json:"onStartup"`
+	} `json:"retention"`
+	AutoArchive struct {
+		IdleHours float64 `json:"idleHours"`
+		Ask       bool    `json:"ask"`
+	} `json:"autoArchive"`
+	LoopGuard struct {
+		Repeats       int     `json:"repeats"`
+		WindowMinutes float64 `json:"windowMinutes"`
+		Stop          bool    `json:"stop"`
+		Message       string  `json:"message"`
+	} `json:"loopGuard"`
+	Transport        string               `json:"transport"`
+	ModelColors      map[string]string    `json:"modelColors"`
+	A11y             bool                 `json:"a11y"`
+	NoEmoji          bool                 `json:"noEmoji"`
+	Glyphs           string               `json:"glyphs"`
+	StartTab         string               `json:"startTab"`
+	StatusGlyphs     map[string]string    `json:"statusGlyphs"`
+	TranscriptDirs   []string             `json:"transcriptDirs"`
+	KillSignals      map[string]string    `json:"killSignals"`
+	Tools            map[string]ToolStyle `json:"tools"`
+	Exporters        []Exporter           `json:"exporters"`
+	Operator         string               `json:"operator"`
+	LockDir          string               `json:"lockDir"`
+	FetchDepth       int                  `json:"fetchDepth"`
+	ProcessLogLines  int                  `json:"processLogLines"`
+	ProcessLogBuffer int                  `json:"processLogBufferLines"`
+	LogMemoryMB      int                  `json:"logMemoryMB"`
+	PrivacyMinutes   float64              `json:"privacyMinutes"`
+	ImagePathPattern string               `json:"imagePathPattern"`
+	WorkspaceCommands []string            `json:"workspaceCommands"`
+	// Pointer so a missing key keeps the default (enabled)
+	PromptHistory *bool             `json:"promptHistory"`
+	Confirm       map[string]string `json:"confirm"`
+	Environments  map[string]struct {
+		Confirm map[string]string `json:"confirm"`
+		Environment
+	} `json:"environments"`
+}
+
+// OpenclawPath returns the path of the OpenClaw gateway config file.
+func OpenclawPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".openclaw", "openclaw.json")
+}
+
+// CommanderPath returns the path of the Commander settings file.
+func CommanderPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".openclaw", "commander.json")
+}
+
+// ExportDir returns the directory Commander writes exported logs to.
+func ExportDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".openclaw", "exports")
+}
+
+// LockDir returns the default directory session locks are kept in.
+func LockDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".openclaw", "commander-locks")
+}
+
+// DefaultOperator names the operator as user@host.
+func DefaultOperator() string {
+	name := os.Getenv("USER")
+	if name == "" {
+		name = os.Getenv("USERNAME")
+	}
+	if name == "" {
+		name = "operator"
+	}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		name += "@" + host
+	}
+	return name
+}
+
+// ExpandHome replaces a leading ~ in path with the user's home directory.
+// Both ~/ and, for Windows users, ~\ are recognized.
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") && !strings.HasPrefix(path, `~\`) {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
 }
 
 // openclawJSON mirrors the relevant fields of ~/.openclaw/openclaw.json.
@@ -27,16 +440,107 @@ type openclawJSON struct {
 //  1. ~/.openclaw/openclaw.json  gateway.auth.token
 //  2. OPENCLAW_GATEWAY_TOKEN env var
 //  3. Explicit flag values (passed as arguments)
-func Load(flagURL, flagToken string) Config {
-	cfg := Config{GatewayURL: DefaultGatewayURL}
+//
+// Commander-only settings are read from ~/.openclaw/commander.json.
+func Load(flagURL, flagToken, flagProxy string) Config {
+	cfg := Config{
+		GatewayURL:      DefaultGatewayURL,
+		PromptHistory:   true,
+		FetchDepth:      DefaultFetchDepth,
+		ProcessLogLines: DefaultFetchDepth,
+		ProcessLogBuffer: DefaultProcessLogBuffer,
+		Operator:        DefaultOperator(),
+		LockDir:         LockDir(),
+		LogMemory:       DefaultLogMemoryMB << 20,
+		LoopGuard:       LoopGuard{Repeats: 4, Window: 5 * time.Minute, Message: DefaultLoopMessage},
+		WorkspaceCommands: DefaultWorkspaceCommands,
+	}
 
 	// 1. Config file
-	if home, err := os.UserHomeDir(); err == nil {
-		p := filepath.Join(home, ".openclaw", "openclaw.json")
-		if data, err := os.ReadFile(p); err == nil {
-			var f openclawJSON
-			if json.Unmarshal(data, &f) == nil && f.Gateway.Auth.Token != "" {
-				cfg.Token = f.Gateway.Auth.Token
+	if data, err := os.ReadFile(OpenclawPath()); err == nil {
+		var f openclawJSON
+		if json.Unmarshal(data, &f) == nil && f.Gateway.Auth.Token != "" {
+			cfg.Token = f.Gateway.Auth.Token
+		}
+	}
+
+	// Commander settings
+	if data, err := os.ReadFile(CommanderPath()); err == nil {
+		var f commanderJSON
+		if json.Unmarshal(data, &f) == nil {
+			r := f.Retention
+			cfg.Retention = Retention{
+				MaxAge:        time.Duration(r.MaxAgeDays * float64(24*time.Hour)),
+				MaxTotalBytes: int64(r.MaxTotalMB * 1024 * 1024),
+				KeepLabeled:   r.KeepLabeled,
+				OnStartup:     r.OnStartup,
+			}
+			cfg.AutoArchive = AutoArchive{
+				Idle: time.Duration(f.AutoArchive.IdleHours * float64(time.Hour)),
+				Ask:  f.AutoArchive.Ask,
+			}
+			lg := f.LoopGuard
+			if lg.Repeats > 0 {
+				cfg.LoopGuard.Repeats = lg.Repeats
+			}
+			if lg.WindowMinutes > 0 {
+				cfg.LoopGuard.Window = time.Duration(lg.WindowMinutes * float64(time.Minute))
+			}
+			cfg.LoopGuard.Stop = lg.Stop
+			if lg.Message != "" {
+				cfg.LoopGuard.Message = lg.Message
+			}
+			cfg.Transport = f.Transport
+			cfg.ModelColors = f.ModelColors
+			cfg.A11y = f.A11y
+			cfg.NoEmoji = f.NoEmoji
+			cfg.Glyphs = f.Glyphs
+			cfg.StartTab = f.StartTab
+			cfg.StatusGlyphs = f.StatusGlyphs
+			cfg.KillSignals = f.KillSignals
+			cfg.Tools = f.Tools
+			cfg.Exporters = f.Exporters
+			if f.Operator != "" {
+				cfg.Operator = f.Operator
+			}
+			if f.LockDir != "" {
+				cfg.LockDir = ExpandHome(f.LockDir)
+			}
+			cfg.ImagePathPattern = f.ImagePathPattern
+			if f.FetchDepth > 0 {
+				cfg.FetchDepth = f.FetchDepth
+			}
+			if f.ProcessLogLines > 0 {
+				cfg.ProcessLogLines = f.ProcessLogLines
+			}
+			if f.ProcessLogBuffer > 0 {
+				cfg.ProcessLogBuffer = f.ProcessLogBuffer
+			}
+			if f.LogMemoryMB > 0 {
+				cfg.LogMemory = int64(f.LogMemoryMB) << 20
+			}
+			cfg.PrivacyTimeout = time.Duration(f.PrivacyMinutes * float64(time.Minute))
+			if len(f.WorkspaceCommands) > 0 {
+				cfg.WorkspaceCommands = f.WorkspaceCommands
+			}
+			for _, dir := range f.TranscriptDirs {
+				cfg.TranscriptDirs = append(cfg.TranscriptDirs, ExpandHome(dir))
+			}
+			if f.PromptHistory != nil {
+				cfg.PromptHistory = *f.PromptHistory
+			}
+			cfg.Confirm = f.Confirm
+			for match, env := range f.Environments {
+				if cfg.ConfirmEnvironments == nil {
+					cfg.ConfirmEnvironments = make(map[string]map[string]string)
+				}
+				cfg.ConfirmEnvironments[match] = env.Confirm
+				if env.Label != "" || env.Accent != "" {
+					if cfg.Environments == nil {
+						cfg.Environments = make(map[string]Environment)
+					}
+					cfg.Environments[match] = env.Environment
+				}
 			}
 		}
 	}
@@ -45,6 +549,22 @@ func Load(flagURL, flagToken string) Config {
 	if v := os.Getenv("OPENCLAW_GATEWAY_TOKEN"); v != "" {
 		cfg.Token = v
 	}
+	if v := os.Getenv("OPENCLAW_COMMANDER_A11Y"); v != "" {
+		cfg.A11y = v != "0" && v != "false"
+	}
+	// https://no-color.org: any non-empty value disables color
+	if os.Getenv("NO_COLOR") != "" {
+		cfg.NoColor = true
+	}
+	if v := os.Getenv("OPENCLAW_COMMANDER_NO_EMOJI"); v != "" {
+		cfg.NoEmoji = v != "0" && v != "false"
+	}
+	if os.Getenv("TERM") == "dumb" {
+		cfg.NoColor, cfg.NoEmoji = true, true
+	}
+	if v := os.Getenv("OPENCLAW_COMMANDER_GLYPHS"); v != "" {
+		cfg.Glyphs = v
+	}
 
 	// 3. CLI flags override everything
 	if flagToken != "" {
@@ -53,6 +573,168 @@ func Load(flagURL, flagToken string) Config {
 	if flagURL != "" {
 		cfg.GatewayURL = flagURL
 	}
+	if flagProxy != "" {
+		cfg.Proxy = flagProxy
+	}
 
 	return cfg
 }
+
+// Validate reports settings that cannot work, such as a malformed proxy URL.
+func (c Config) Validate() error {
+	policies := map[string]map[string]string{"confirm": c.Confirm}
+	for match, env := range c.ConfirmEnvironments {
+		policies["environments."+match+".confirm"] = env
+	}
+	for where, policy := range policies {
+		for action, level := range policy {
+			if _, ok := defaultConfirm[action]; !ok {
+				return fmt.Errorf("%s: unknown action %q (want kill, abort, purge, or send)", where, action)
+			}
+			switch level {
+			case ConfirmNone, ConfirmAsk, ConfirmType:
+			default:
+				return fmt.Errorf("%s.%s: unknown level %q (want none, ask, or type)", where, action, level)
+			}
+		}
+	}
+	for match, env := range c.Environments {
+		if env.Accent != "" && !validAccent(env.Accent) {
+			return fmt.Errorf("environments.%s.accent: unknown color %q (want %s, #rrggbb, or 0-255)", match, env.Accent, strings.Join(AccentNames, ", "))
+		}
+	}
+	switch c.Glyphs {
+	case "", GlyphsAuto, GlyphsEmoji, GlyphsASCII:
+	default:
+		return fmt.Errorf("glyphs: unknown set %q (want auto, emoji, or ascii)", c.Glyphs)
+	}
+	if c.StartTab != "" && !strings.EqualFold(c.StartTab, StartTabAuto) && StartTabIndex(c.StartTab) < 0 {
+		return fmt.Errorf("--tab: unknown tab %q (want %s, %s, or 1-%d)", c.StartTab, StartTabAuto, strings.Join(StartTabs, ", "), len(StartTabs))
+	}
+	switch c.Transport {
+	case "", TransportHTTP, TransportMCP:
+	default:
+		return fmt.Errorf("transport: unknown transport %q (want http or mcp)", c.Transport)
+	}
+	for i, e := range c.Exporters {
+		switch {
+		case e.Type != ExportS3 && e.Type != ExportGit && e.Type != ExportOpenAI && e.Type != ExportAnthropic:
+			return fmt.Errorf("exporters[%d]: unknown type %q (want s3, git, openai, or anthropic)", i, e.Type)
+		case e.Type == ExportS3 && e.Bucket == "":
+			return fmt.Errorf("exporters[%d]: s3 needs a bucket", i)
+		case e.Type == ExportGit && e.Repo == "":
+			return fmt.Errorf("exporters[%d]: git needs a repo", i)
+		case (e.Type == ExportOpenAI || e.Type == ExportAnthropic) && e.Path == "":
+			return fmt.Errorf("exporters[%d]: %s needs a path", i, e.Type)
+		}
+		if _, err := path.Match(e.Match, ""); err != nil {
+			return fmt.Errorf("exporters[%d].match: %w", i, err)
+		}
+	}
+	for match, style := range c.Tools {
+		if strings.TrimSuffix(match, "*") == "" {
+			return fmt.Errorf("tools: empty tool name %q", match)
+		}
+		if style.Emoji == "" && style.Label == "" {
+			return fmt.Errorf("tools.%s: set emoji, label, or both", match)
+		}
+	}
+	if c.ImagePathPattern != "" {
+		if _, err := regexp.Compile(c.ImagePathPattern); err != nil {
+			return fmt.Errorf("invalid imagePathPattern in %s: %w", CommanderPath(), err)
+		}
+	}
+	if c.Proxy == "" {
+		return nil
+	}
+	u, err := url.Parse(c.Proxy)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return fmt.Errorf("unsupported proxy scheme %q (want http, https, or socks5)", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q: missing host", c.Proxy)
+	}
+	return nil
+}
+
+// Export backends.
+const (
+	ExportS3        = "s3"        // upload with the aws CLI
+	ExportGit       = "git"       // commit to a local clone, optionally pushing
+	ExportOpenAI    = "openai"    // append to an OpenAI chat fine-tuning JSONL file
+	ExportAnthropic = "anthropic" // append to an Anthropic Messages JSONL file
+)
+
+// Exporter is a backend completed runs are pushed to: an S3 bucket, a git
+// repository, or a fine-tuning dataset, for the runs whose label matches
+// Match.
+type Exporter struct {
+	Match      string `json:"match"`      // label glob such as "nightly-*"; empty for all runs
+	Type       string `json:"type"`       // ExportS3, ExportGit, ExportOpenAI, or ExportAnthropic
+	Bucket     string `json:"bucket"`     // s3: bucket name
+	Prefix     string `json:"prefix"`     // s3: key prefix; git: directory in the repo
+	Profile    string `json:"profile"`    // s3: aws CLI profile; empty for the default
+	Repo       string `json:"repo"`       // git: path of a local clone
+	Push       bool   `json:"push"`       // git: push after committing
+	Path       string `json:"path"`       // openai, anthropic: JSONL file examples are appended to
+	StripTools bool   `json:"stripTools"` // openai, anthropic: leave tool calls and results out
+}
+
+// Matches reports whether the exporter takes runs labelled label.
+func (e Exporter) Matches(label string) bool {
+	if e.Match == "" || e.Match == "*" {
+		return true
+	}
+	ok, _ := path.Match(e.Match, label)
+	return ok
+}
+
+// ToolStyle is how a tool is shown in logs. Empty fields keep the default.
+type ToolStyle struct {
+	Emoji string `json:"emoji"`
+	Label string `json:"label"`
+}
+
+// ToolStyleFor returns the configured style for the tool name: an exact key
+// wins, then the longest matching prefix key ("jira_*"). Matching ignores
+// case.
+func ToolStyleFor(tools map[string]ToolStyle, name string) (ToolStyle, bool) {
+	name = strings.ToLower(name)
+	var best ToolStyle
+	bestLen := -1
+	for match, style := range tools {
+		key := strings.ToLower(match)
+		if key == name {
+			return style, true
+		}
+		prefix, ok := strings.CutSuffix(key, "*")
+		if ok && strings.HasPrefix(name, prefix) && len(prefix) > bestLen {
+			best, bestLen = style, len(prefix)
+		}
+	}
+	return best, bestLen >= 0
+}
+
+// validAccent reports whether accent is a named accent, a #rgb or #rrggbb
+// hex color, or an ANSI 256-color number.
+func validAccent(accent string) bool {
+	for _, name := range AccentNames {
+		if accent == name {
+			return true
+		}
+	}
+	if hex, ok := strings.CutPrefix(accent, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(accent)
+	return err == nil && n >= 0 && n <= 255
+}
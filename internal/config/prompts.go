@@ -0,0 +1,47 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// MaxPromptHistory caps how many prompts and messages are remembered.
+const MaxPromptHistory = 500
+
+// PromptHistoryPath returns the path of the prompt history file.
+func PromptHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".openclaw", "commander-prompts.json")
+}
+
+// LoadPromptHistory returns remembered prompts, oldest first.
+func LoadPromptHistory() []string {
+	var prompts []string
+	if data, err := os.ReadFile(PromptHistoryPath()); err == nil {
+		json.Unmarshal(data, &prompts)
+	}
+	return prompts
+}
+
+// SavePromptHistory writes prompts, keeping the newest MaxPromptHistory.
+func SavePromptHistory(prompts []string) error {
+	path := PromptHistoryPath()
+	if path == "" {
+		return os.ErrNotExist
+	}
+	if len(prompts) > MaxPromptHistory {
+		prompts = prompts[len(prompts)-MaxPromptHistory:]
+	}
+	data, err := json.Marshal(prompts)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
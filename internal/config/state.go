@@ -0,0 +1,168 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State holds UI preferences the Commander remembers between runs. Unlike
+// commander.json it is written by the Commander itself.
+type State struct {
+	HistorySort string `json:"historySort,omitempty"`
+
+	// AbsoluteDates shows when History runs were archived as dates and
+	// times instead of ages.
+	AbsoluteDates bool `json:"absoluteDates,omitempty"`
+
+	// ListPercent is the list panel's share of the screen width; 0 means
+	// the default 40%.
+	ListPercent int `json:"listPercent,omitempty"`
+
+	// TokenSplit shows input and output tokens separately in the
+	// sessions list instead of the total.
+	TokenSplit bool `json:"tokenSplit,omitempty"`
+
+	// FollowLatest keeps the log panel on whichever session most recently
+	// produced output.
+	FollowLatest bool `json:"followLatest,omitempty"`
+
+	// Project is the Sessions project view: "" for all, "*" for grouped
+	// by project, otherwise the path of the project to show.
+	Project string `json:"project,omitempty"`
+
+	// Favorites are the keys of the sessions on the favorites bar, in
+	// alt+1..alt+9 order.
+	Favorites []string `json:"favorites,omitempty"`
+
+	// MainWidget pins the main session's latest reply above the status bar.
+	MainWidget bool `json:"mainWidget,omitempty"`
+
+	// Deadlines maps spawned task labels to their due time (Unix ms).
+	Deadlines map[string]int64 `json:"deadlines,omitempty"`
+
+	// Notes maps session IDs to free-form operator notes.
+	Notes map[string]string `json:"notes,omitempty"`
+
+	// RestartPolicies maps the commands of gateway-managed processes to
+	// their restart policy, "on-failure" or "always"; others never restart.
+	RestartPolicies map[string]string `json:"restartPolicies,omitempty"`
+
+	// Archived maps the keys of archived sessions to their last activity
+	// (Unix ms) when archived; activity after that brings them back.
+	Archived map[string]int64 `json:"archived,omitempty"`
+
+	// Scheduled holds messages waiting to be sent, soonest first.
+	Scheduled []ScheduledSend `json:"scheduled,omitempty"`
+
+	// IgnoredProcesses are fingerprints of scanned processes marked as not
+	// agents; matching processes are left out of the Processes tab.
+	IgnoredProcesses []string `json:"ignoredProcesses,omitempty"`
+}
+
+// ScheduledSend is a message to deliver to a session at a later time.
+type ScheduledSend struct {
+	ID        string `json:"id,omitempty"` // see ScheduledID
+	SessionID string `json:"sessionId"`
+	Target    string `json:"target"` // session display name when scheduled
+	Text      string `json:"text"`
+	At        int64  `json:"at"` // Unix ms; pushed back after a failed send
+
+	// Sending is when a Commander started delivering it (Unix ms), 0 when
+	// it waits; Attempts and LastError record failed deliveries.
+	Sending   int64  `json:"sending,omitempty"`
+	Attempts  int    `json:"attempts,omitempty"`
+	LastError string `json:"lastError,omitempty"`
+}
+
+// ScheduledID identifies a scheduled send across Commanders sharing the
+// state file, for claiming it. Sends stored before IDs existed get the same
+// ID wherever it is derived.
+func ScheduledID(s ScheduledSend) string {
+	if s.ID != "" {
+		return s.ID
+	}
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%d\x00%s", s.SessionID, s.At, s.Text)))
+	return fmt.Sprintf("%x", sum[:8])
+}
+
+// sendClaimPath is the claim file of scheduled send id.
+func sendClaimPath(id string) string {
+	return filepath.Join(filepath.Dir(StatePath()), "commander-sends", id+".claim")
+}
+
+// ClaimScheduled takes the claim to deliver scheduled send id, so that of
+// several Commanders sharing the state file only one sends it: the claim is
+// a file created exclusively. A claim older than ttl was left by a
+// Commander that stopped mid-send and is taken over.
+func ClaimScheduled(id string, ttl time.Duration) bool {
+	if StatePath() == "" {
+		return true // no shared state to race over
+	}
+	path := sendClaimPath(id)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return false
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		info, serr := os.Stat(path)
+		if !errors.Is(err, fs.ErrExist) || serr != nil || time.Since(info.ModTime()) < ttl {
+			return false
+		}
+		os.Remove(path)
+		if f, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600); err != nil {
+			return false
+		}
+	}
+	fmt.Fprintln(f, os.Getpid())
+	f.Close()
+	return true
+}
+
+// ReleaseScheduled gives up the claim on scheduled send id once it is
+// delivered or put back in the queue.
+func ReleaseScheduled(id string) {
+	if StatePath() != "" {
+		os.Remove(sendClaimPath(id))
+	}
+}
+
+// StatePath returns the path of the Commander state file.
+func StatePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".openclaw", "commander-state.json")
+}
+
+// LoadState reads the state file, returning zero values when it is missing
+// or unreadable.
+func LoadState() State {
+	var s State
+	if data, err := os.ReadFile(StatePath()); err == nil {
+		json.Unmarshal(data, &s)
+	}
+	return s
+}
+
+// Save writes the state file.
+func (s State) Save() error {
+	path := StatePath()
+	if path == "" {
+		return os.ErrNotExist
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o600)
+}
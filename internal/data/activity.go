@@ -0,0 +1,175 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// ActivityWindow is how far back session activity is sampled.
+	ActivityWindow = 30 * time.Minute
+	// ActivityBuckets is the number of sparkline buckets across ActivityWindow.
+	ActivityBuckets = 10
+
+	// activityTailBytes bounds how much of a transcript is read; only the
+	// recent tail can fall inside ActivityWindow.
+	activityTailBytes = 512 * 1024
+	// timelineTailBytes bounds how much of a transcript the timeline reads,
+	// which looks back hours rather than minutes.
+	timelineTailBytes = 16 * 1024 * 1024
+)
+
+// activityEntry caches the timestamps parsed from a transcript tail,
+// keyed by the file's size and mtime so unchanged files aren't re-read.
+type activityEntry struct {
+	size    int64
+	modTime time.Time
+	tail    int64 // bytes read from the end of the file
+	stamps  []int64
+}
+
+// TranscriptPath returns the on-disk transcript for a session.
+func TranscriptPath(s Session) string {
+	if s.TranscriptPath != "" {
+		return s.TranscriptPath
+	}
+	if s.SessionID == "" {
+		return ""
+	}
+	return filepath.Join(homeDir(), ".openclaw", "agents", "main", "sessions", s.SessionID+".jsonl")
+}
+
+// SessionActivity returns per-bucket message/tool counts for the last
+// ActivityWindow, oldest bucket first. Missing transcripts yield all zeros.
+func (c *Client) SessionActivity(s Session, now time.Time) []int {
+	return c.activityCounts(TranscriptPath(s), now.Add(-ActivityWindow), now, ActivityBuckets, activityTailBytes)
+}
+
+// TimelineActivity returns the message/tool counts of the transcript at
+// path in buckets equal slices of the time from start to end, oldest
+// first. Only the last 16 MB of a transcript are read.
+func (c *Client) TimelineActivity(path string, start, end time.Time, buckets int) []int {
+	return c.activityCounts(path, start, end, buckets, timelineTailBytes)
+}
+
+// activityCounts counts the messages in the last tail bytes of the
+// transcript at path into buckets from start to end. Missing transcripts
+// yield all zeros.
+func (c *Client) activityCounts(path string, start, end time.Time, buckets int, tail int64) []int {
+	counts := make([]int, buckets)
+	if path == "" || buckets <= 0 || !end.After(start) {
+		return counts
+	}
+	stamps, err := c.transcriptTimestamps(path, tail)
+	if err != nil {
+		return counts
+	}
+
+	from, to := start.UnixMilli(), end.UnixMilli()
+	span := to - from
+	for _, ts := range stamps {
+		if ts < from || ts > to {
+			continue
+		}
+		i := int((ts - from) * int64(buckets) / span)
+		if i >= buckets {
+			i = buckets - 1
+		}
+		counts[i]++
+	}
+	return counts
+}
+
+// transcriptTimestamps returns the message timestamps (unix ms) found in
+// the last tail bytes of a transcript, using the cache when the file is
+// unchanged and at least that much of it was read.
+func (c *Client) transcriptTimestamps(path string, tail int64) ([]int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.activityMu.Lock()
+	cached, ok := c.activity[path]
+	c.activityMu.Unlock()
+	if ok && cached.size == info.Size() && cached.modTime.Equal(info.ModTime()) &&
+		(cached.tail >= tail || cached.tail >= info.Size()) {
+		return cached.stamps, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	skipPartial := false
+	if info.Size() > tail {
+		if _, err := f.Seek(info.Size()-tail, io.SeekStart); err != nil {
+			return nil, err
+		}
+		skipPartial = true
+	}
+
+	var stamps []int64
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 256*1024), 1024*1024)
+	for scanner.Scan() {
+		if skipPartial {
+			skipPartial = false
+			continue
+		}
+		var entry struct {
+			Type      string          `json:"type"`
+			Timestamp json.RawMessage `json:"timestamp"`
+			Message   struct {
+				Timestamp json.RawMessage `json:"timestamp"`
+			} `json:"message"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+			continue
+		}
+		if entry.Type != "" && entry.Type != "message" {
+			continue
+		}
+		ts := parseTimestamp(entry.Message.Timestamp)
+		if ts == 0 {
+			ts = parseTimestamp(entry.Timestamp)
+		}
+		if ts > 0 {
+			stamps = append(stamps, ts)
+		}
+	}
+
+	c.activityMu.Lock()
+	c.activity[path] = activityEntry{size: info.Size(), modTime: info.ModTime(), tail: tail, stamps: stamps}
+	c.activityMu.Unlock()
+	return stamps, nil
+}
+
+// parseTimestamp accepts unix milliseconds or an RFC 3339 string.
+func parseTimestamp(raw json.RawMessage) int64 {
+	if len(raw) == 0 {
+		return 0
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return t.UnixMilli()
+		}
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+		return 0
+	}
+	var n float64
+	if json.Unmarshal(raw, &n) == nil {
+		return int64(n)
+	}
+	return 0
+}
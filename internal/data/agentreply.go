@@ -0,0 +1,66 @@
+package data
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// agentReplyJSON is what `openclaw agent --json` prints: the reply payloads
+// and run metadata, either at the top level or under "result".
+type agentReplyJSON struct {
+	Payloads []agentPayload `json:"payloads"`
+	Meta     agentMeta      `json:"meta"`
+	Result   *struct {
+		Payloads []agentPayload `json:"payloads"`
+		Meta     agentMeta      `json:"meta"`
+	} `json:"result"`
+}
+
+type agentPayload struct {
+	Text     string `json:"text"`
+	MediaURL string `json:"mediaUrl"`
+}
+
+type agentMeta struct {
+	AgentMeta struct {
+		Model string `json:"model"`
+		Usage struct {
+			Total int `json:"total"`
+		} `json:"usage"`
+	} `json:"agentMeta"`
+}
+
+// SentExchange returns a message sent at sentAt and the agent's reply, as
+// printed by SendMessage, as history messages that render like the rest of
+// the session. Output that isn't the CLI's JSON becomes the reply text.
+func SentExchange(text string, sentAt time.Time, out string) []HistoryMessage {
+	msgs := []HistoryMessage{{Role: "user", Text: text, Timestamp: sentAt.UnixMilli()}}
+	reply := HistoryMessage{Role: "assistant", Timestamp: time.Now().UnixMilli()}
+
+	var parsed agentReplyJSON
+	if raw := cliJSON([]byte(out)); raw == nil || json.Unmarshal(raw, &parsed) != nil {
+		reply.Text = strings.TrimSpace(StripANSI(out))
+	} else {
+		payloads, meta := parsed.Payloads, parsed.Meta
+		if parsed.Result != nil {
+			payloads, meta = parsed.Result.Payloads, parsed.Result.Meta
+		}
+		var parts []string
+		for _, p := range payloads {
+			if p.Text != "" {
+				parts = append(parts, p.Text)
+			}
+			if p.MediaURL != "" {
+				parts = append(parts, p.MediaURL)
+			}
+		}
+		reply.Text = strings.Join(parts, "\n\n")
+		reply.Model = meta.AgentMeta.Model
+		reply.Tokens = meta.AgentMeta.Usage.Total
+	}
+	if reply.Text != "" {
+		msgs = append(msgs, reply)
+	}
+	return msgs
+}
@@ -0,0 +1,182 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// RunStats is the indexed summary of one archived transcript.
+type RunStats struct {
+	Size    int64          `json:"size"`
+	ModTime int64          `json:"modTime"` // Unix ms; with Size, detects changed files
+	Start   int64          `json:"start"`   // first message timestamp, Unix ms (0 if none)
+	End     int64          `json:"end"`     // last message timestamp, Unix ms
+	Failed  bool           `json:"failed"`
+	Tokens  int            `json:"tokens"`
+	Tools   map[string]int `json:"tools,omitempty"`
+}
+
+// Duration is the time between the first and last message.
+func (r RunStats) Duration() time.Duration {
+	if r.Start == 0 || r.End < r.Start {
+		return 0
+	}
+	return time.Duration(r.End-r.Start) * time.Millisecond
+}
+
+// DayStats aggregates the runs that ended on one day.
+type DayStats struct {
+	Day    string // YYYY-MM-DD, local time
+	Runs   int
+	Failed int
+	Tokens int
+}
+
+// ToolCount is one row of the most-used tools ranking.
+type ToolCount struct {
+	Name  string
+	Calls int
+}
+
+// ArchiveStats aggregates the indexed archive.
+type ArchiveStats struct {
+	Runs           int
+	Failed         int
+	MedianDuration time.Duration
+	TotalTokens    int
+	Days           []DayStats // oldest first
+	Tools          []ToolCount
+	IndexedAt      time.Time
+}
+
+// archiveIndexPath is where per-run summaries are cached between runs.
+func archiveIndexPath() string {
+	return filepath.Join(homeDir(), ".openclaw", "commander-index.json")
+}
+
+// IndexArchive summarizes every run, re-reading only transcripts that are
+// new or changed since they were last indexed, and returns the aggregates.
+// The cache is kept in memory and in ~/.openclaw/commander-index.json.
+func (c *Client) IndexArchive(runs []ArchivedRun) ArchiveStats {
+	c.indexMu.Lock()
+	defer c.indexMu.Unlock()
+
+	if c.index == nil {
+		c.index = make(map[string]RunStats)
+		if data, err := os.ReadFile(archiveIndexPath()); err == nil {
+			json.Unmarshal(data, &c.index)
+		}
+	}
+
+	changed := false
+	live := make(map[string]bool, len(runs))
+	for _, run := range runs {
+		live[run.Path] = true
+		if st, ok := c.index[run.Path]; ok && st.Size == run.Size && st.ModTime == run.ModifiedAt {
+			continue
+		}
+		if run.Sealed {
+			continue // not worth a gateway call per run
+		}
+		msgs, err := c.ReadTranscriptMessages(run.Path)
+		if err != nil {
+			continue
+		}
+		st := summarizeRun(msgs)
+		st.Size, st.ModTime = run.Size, run.ModifiedAt
+		if st.End == 0 {
+			st.End = run.ModifiedAt
+		}
+		c.index[run.Path] = st
+		changed = true
+	}
+	for path := range c.index {
+		if !live[path] {
+			delete(c.index, path)
+			changed = true
+		}
+	}
+	if changed {
+		if data, err := json.Marshal(c.index); err == nil {
+			os.WriteFile(archiveIndexPath(), data, 0o600)
+		}
+	}
+
+	return aggregateRuns(c.index)
+}
+
+// summarizeRun derives a run's stats from its messages. A run failed when
+// it ended in an error or was aborted (see runOutcome).
+func summarizeRun(msgs []HistoryMessage) RunStats {
+	var st RunStats
+	st.Tools = make(map[string]int)
+	for _, m := range msgs {
+		if m.Timestamp > 0 {
+			if st.Start == 0 || m.Timestamp < st.Start {
+				st.Start = m.Timestamp
+			}
+			st.End = max(st.End, m.Timestamp)
+		}
+		st.Tokens += m.Tokens
+		if (m.Role == "toolResult" || m.Role == "tool") && m.ToolName != "" {
+			st.Tools[m.ToolName]++
+		}
+	}
+	outcome := runOutcome(msgs)
+	st.Failed = outcome == RunFailed || outcome == RunAborted
+	return st
+}
+
+func aggregateRuns(index map[string]RunStats) ArchiveStats {
+	stats := ArchiveStats{IndexedAt: time.Now()}
+	days := make(map[string]*DayStats)
+	tools := make(map[string]int)
+	var durations []time.Duration
+
+	for _, st := range index {
+		stats.Runs++
+		stats.TotalTokens += st.Tokens
+		if st.Failed {
+			stats.Failed++
+		}
+		if d := st.Duration(); d > 0 {
+			durations = append(durations, d)
+		}
+		day := time.UnixMilli(st.End).Format("2006-01-02")
+		ds := days[day]
+		if ds == nil {
+			ds = &DayStats{Day: day}
+			days[day] = ds
+		}
+		ds.Runs++
+		ds.Tokens += st.Tokens
+		if st.Failed {
+			ds.Failed++
+		}
+		for name, n := range st.Tools {
+			tools[name] += n
+		}
+	}
+
+	if len(durations) > 0 {
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		stats.MedianDuration = durations[len(durations)/2]
+	}
+	for _, ds := range days {
+		stats.Days = append(stats.Days, *ds)
+	}
+	sort.Slice(stats.Days, func(i, j int) bool { return stats.Days[i].Day < stats.Days[j].Day })
+	for name, n := range tools {
+		stats.Tools = append(stats.Tools, ToolCount{Name: name, Calls: n})
+	}
+	sort.Slice(stats.Tools, func(i, j int) bool {
+		if stats.Tools[i].Calls != stats.Tools[j].Calls {
+			return stats.Tools[i].Calls > stats.Tools[j].Calls
+		}
+		return stats.Tools[i].Name < stats.Tools[j].Name
+	})
+	return stats
+}
@@ -0,0 +1,135 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// archiveProgressInterval is the minimum time between progress reports
+// from a background archive scan.
+const archiveProgressInterval = 200 * time.Millisecond
+
+// ArchiveProgress is a snapshot of a background archive scan.
+type ArchiveProgress struct {
+	Done     int           // transcripts labelled so far
+	Total    int           // transcripts found
+	Runs     []ArchivedRun // the first Done runs, newest first
+	Finished bool
+}
+
+// labelEntry caches the label read from a transcript head and the outcome
+// read from its tail, valid while the file's size and modification time
+// are unchanged.
+type labelEntry struct {
+	size    int64
+	modTime int64
+	label   string
+	format  string
+	outcome string
+
+	encrypted, sealed bool
+}
+
+// listArchivedRuns stats the transcripts that aren't in the active sessions
+// list, newest first, without reading them; Label, Format, and Outcome are
+// left empty.
+func (c *Client) listArchivedRuns(activeSessions []Session) []ArchivedRun {
+	sessDir := filepath.Join(homeDir(), ".openclaw", "agents", "main", "sessions")
+
+	// Build set of active session IDs
+	activeIDs := make(map[string]bool)
+	for _, s := range activeSessions {
+		activeIDs[s.SessionID] = true
+	}
+
+	var runs []ArchivedRun
+	add := func(path string, info os.FileInfo) {
+		sessionID := strings.TrimSuffix(info.Name(), ".jsonl")
+		if activeIDs[sessionID] {
+			return // skip active sessions
+		}
+		runs = append(runs, ArchivedRun{
+			SessionID:  sessionID,
+			Size:       info.Size(),
+			ModifiedAt: info.ModTime().UnixMilli(),
+			Path:       path,
+		})
+	}
+
+	// graceful if dir doesn't exist
+	if entries, err := os.ReadDir(sessDir); err == nil {
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".jsonl") {
+				continue
+			}
+			if info, err := e.Info(); err == nil {
+				add(filepath.Join(sessDir, e.Name()), info)
+			}
+		}
+	}
+	for _, dir := range c.cfg.TranscriptDirs {
+		walkTranscripts(dir, transcriptDirDepth, add)
+	}
+
+	// Sort by modified time, newest first
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].ModifiedAt > runs[j].ModifiedAt
+	})
+	return runs
+}
+
+// labelRun fills in run's label, format, and outcome, reading the
+// transcript's head and tail only when it changed since it was last
+// labelled. A label and note the operator gave the run in its sidecar are
+// read every time; they are tiny, and editing them doesn't touch the
+// transcript.
+func (c *Client) labelRun(run *ArchivedRun) {
+	c.labelsMu.Lock()
+	cached, ok := c.labels[run.Path]
+	c.labelsMu.Unlock()
+	if ok && cached.size == run.Size && cached.modTime == run.ModifiedAt {
+		run.Label, run.Format, run.Outcome = cached.label, cached.format, cached.outcome
+		run.Encrypted, run.Sealed = cached.encrypted, cached.sealed
+		applyRunMeta(run)
+		return
+	}
+
+	run.Encrypted, run.Sealed = transcriptEncryption(run.Path)
+	run.Label, run.Format = readTranscriptLabel(run.Path)
+	if !run.Encrypted {
+		run.Outcome = readTranscriptOutcome(run.Path, run.Format)
+	}
+	c.labelsMu.Lock()
+	if c.labels == nil {
+		c.labels = make(map[string]labelEntry)
+	}
+	c.labels[run.Path] = labelEntry{size: run.Size, modTime: run.ModifiedAt, label: run.Label, format: run.Format, outcome: run.Outcome,
+		encrypted: run.Encrypted, sealed: run.Sealed}
+	c.labelsMu.Unlock()
+	applyRunMeta(run)
+}
+
+// ScanArchivedRuns lists archived runs in a background goroutine. The
+// channel receives a progress snapshot at most every
+// archiveProgressInterval while transcripts are being labelled, then a
+// final snapshot with Finished set, and is closed.
+func (c *Client) ScanArchivedRuns(activeSessions []Session) <-chan ArchiveProgress {
+	ch := make(chan ArchiveProgress)
+	go func() {
+		defer close(ch)
+		runs := c.listArchivedRuns(activeSessions)
+		last := time.Now()
+		for i := range runs {
+			c.labelRun(&runs[i])
+			if time.Since(last) >= archiveProgressInterval && i+1 < len(runs) {
+				ch <- ArchiveProgress{Done: i + 1, Total: len(runs), Runs: append([]ArchivedRun(nil), runs[:i+1]...)}
+				last = time.Now()
+			}
+		}
+		ch <- ArchiveProgress{Done: len(runs), Total: len(runs), Runs: runs, Finished: true}
+	}()
+	return ch
+}
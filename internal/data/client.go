@@ -1,14 +1,18 @@
 package data
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/jaigner-hub/openclaw-commander/internal/config"
@@ -25,13 +29,51 @@ type SpawnResult struct {
 type Client struct {
 	cfg    config.Config
 	http   *http.Client
+	tr     transport
+
+	// activity caches transcript timestamps for sparklines, keyed by path.
+	activityMu sync.Mutex
+	activity   map[string]activityEntry
+
+	// resources holds CPU/memory history per process name.
+	resourcesMu sync.Mutex
+	resources   map[string]*resourceRing
+
+	// calls is the rolling window of gateway call outcomes for HealthStats.
+	callsMu sync.Mutex
+	calls   []callSample
+
+	// trace is the ring of recent gateway requests for the trace view.
+	traceMu sync.Mutex
+	trace   []TraceEntry
+
+	// index caches per-run archive summaries, keyed by transcript path.
+	indexMu sync.Mutex
+	index   map[string]RunStats
+
+	// labels caches archived run labels read from transcript heads, keyed by path.
+	labelsMu sync.Mutex
+	labels   map[string]labelEntry
 }
 
 // NewClient creates an API client from the given config.
+// Requests go through cfg.Proxy when set, otherwise through the proxy
+// named by HTTP_PROXY/HTTPS_PROXY (honoring NO_PROXY).
 func NewClient(cfg config.Config) *Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if cfg.Proxy != "" {
+		if u, err := url.Parse(cfg.Proxy); err == nil {
+			transport.Proxy = http.ProxyURL(u)
+		}
+	}
+	httpClient := &http.Client{Timeout: 10 * time.Second, Transport: transport}
 	return &Client{
-		cfg: cfg,
-		http: &http.Client{Timeout: 10 * time.Second},
+		cfg:       cfg,
+		http:      httpClient,
+		tr:        newTransport(cfg, httpClient),
+		activity:  make(map[string]activityEntry),
+		resources: make(map[string]*resourceRing),
 	}
 }
 
@@ -41,36 +83,60 @@ type toolRequest struct {
 	Args interface{} `json:"args"`
 }
 
-// invoke calls POST /tools/invoke and returns the raw response body.
+// invoke calls a gateway tool through the configured transport and returns
+// the raw response body.
 func (c *Client) invoke(req toolRequest) ([]byte, error) {
-	body, err := json.Marshal(req)
+	args, err := json.Marshal(req.Args)
 	if err != nil {
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequest("POST", c.cfg.GatewayURL+"/tools/invoke", bytes.NewReader(body))
+	argsBytes := len(args)
+	start := time.Now()
+	status, data, err := c.tr.call(req.Tool, args)
 	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
+		// Errors the transport already classified, such as unparseable
+		// replies, got an answer; the rest never reached the gateway.
+		var kinded *Error
+		answered := errors.As(err, &kinded)
+		c.recordCall(time.Since(start), !answered)
+		c.recordTrace(TraceEntry{At: start, Tool: req.Tool, ArgsBytes: argsBytes, Duration: time.Since(start), Status: traceError(err), Failed: true})
+		if answered {
+			return nil, err
+		}
+		return nil, classified(ErrKindNetwork, err)
 	}
-	httpReq.Header.Set("Content-Type", "application/json")
-	if c.cfg.Token != "" {
-		httpReq.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	c.recordCall(time.Since(start), status >= 500)
+	c.recordTrace(TraceEntry{At: start, Tool: req.Tool, ArgsBytes: argsBytes, Duration: time.Since(start),
+		Status: strconv.Itoa(status), Failed: status != http.StatusOK})
+	if status != http.StatusOK {
+		return nil, parseAPIError(req.Tool, status, data)
 	}
+	return data, nil
+}
 
-	resp, err := c.http.Do(httpReq)
-	if err != nil {
-		return nil, fmt.Errorf("gateway request: %w", err)
+// ListTools returns the names of the tools the gateway offers. Only MCP
+// gateways can list them.
+func (c *Client) ListTools() ([]string, error) {
+	tools, err := c.tr.listTools()
+	names := make([]string, len(tools))
+	for i, t := range tools {
+		names[i] = t.Name
 	}
-	defer resp.Body.Close()
+	return names, err
+}
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("read response: %w", err)
+// decodeResponse unmarshals the gateway envelope for tool, turning a
+// response with ok=false into an *APIError.
+func decodeResponse(tool string, body []byte) (APIResponse, error) {
+	var resp APIResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return resp, classified(ErrKindParse, fmt.Errorf("parse %s response: %w", tool, err))
 	}
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("gateway %d: %s", resp.StatusCode, string(data))
+	if !resp.OK {
+		return resp, parseAPIError(tool, 0, body)
 	}
-	return data, nil
+	return resp, nil
 }
 
 // ModelOption represents a configured model with optional alias.
@@ -143,9 +209,21 @@ func (c *Client) FetchConfiguredModels() ([]ModelOption, error) {
 	return opts, nil
 }
 
-// SpawnSession sends a message to the main agent session asking it to
-// spawn a sub-agent with the given prompt, model, and label.
-func (c *Client) SpawnSession(mainSessionID, prompt, model, label string) (*SpawnResult, error) {
+// SpawnSession sends a message to the parent agent session asking it to
+// spawn a sub-agent with the given prompt, model, and label. The sub-agent
+// is attached to the parent's session tree. env, when given, is handed to
+// the gateway for the sub-agent's commands first; the instruction names the
+// variables but never holds their values.
+func (c *Client) SpawnSession(parentSessionID, prompt, model, label string, env map[string]string) (*SpawnResult, error) {
+	if len(env) > 0 {
+		if label == "" {
+			return nil, errors.New("environment variables need a label to reach the sub-agent")
+		}
+		if err := c.SetSpawnEnv(label, env); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build the instruction for the main agent
 	var msg strings.Builder
 	msg.WriteString("Spawn a sub-agent to work on this task")
@@ -156,9 +234,13 @@ func (c *Client) SpawnSession(mainSessionID, prompt, model, label string) (*Spaw
 		msg.WriteString(" (label: " + label + ")")
 	}
 	msg.WriteString(":\n\n")
+	if len(env) > 0 {
+		msg.WriteString("The gateway sets these environment variables for its commands: " +
+			strings.Join(EnvNames(env), ", ") + ".\n\n")
+	}
 	msg.WriteString(prompt)
 
-	reply, err := c.SendMessage(mainSessionID, msg.String())
+	reply, err := c.SendMessage(parentSessionID, msg.String())
 	if err != nil {
 		return nil, err
 	}
@@ -169,3 +251,90 @@ func (c *Client) SpawnSession(mainSessionID, prompt, model, label string) (*Spaw
 		Model: model,
 	}, nil
 }
+
+// AbortSession asks the gateway to abort the current run of a session.
+func (c *Client) AbortSession(sessionKey string) error {
+	body, err := c.invoke(toolRequest{
+		Tool: "sessions_abort",
+		Args: map[string]interface{}{"sessionKey": sessionKey},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = decodeResponse("sessions_abort", body)
+	return err
+}
+
+// StopProcess terminates a process. Gateway-managed processes are killed
+// through the process tool; OS-scanned entries ("pid:<n>") are sent SIGTERM.
+func (c *Client) StopProcess(name string) error {
+	if pid, ok := strings.CutPrefix(name, "pid:"); ok {
+		n, err := strconv.Atoi(pid)
+		if err != nil {
+			return fmt.Errorf("invalid pid %q", pid)
+		}
+		return signalPID(n, "TERM", syscall.SIGTERM, false)
+	}
+
+	body, err := c.invoke(toolRequest{
+		Tool: "process",
+		Args: map[string]interface{}{"action": "kill", "sessionId": name},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = decodeResponse("process", body)
+	return err
+}
+
+// SessionStatus calls the session_status tool and returns its status card.
+// A non-empty model sets the session's model override first ("default"
+// clears it).
+func (c *Client) SessionStatus(sessionKey, model string) (string, error) {
+	args := map[string]interface{}{"sessionKey": sessionKey}
+	if model != "" {
+		args["model"] = model
+	}
+	body, err := c.invoke(toolRequest{Tool: "session_status", Args: args})
+	if err != nil {
+		return "", err
+	}
+	resp, err := decodeResponse("session_status", body)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		TextResult
+		Details json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return "", classified(ErrKindParse, fmt.Errorf("parse session_status result: %w", err))
+	}
+	if apiErr := resultError("session_status", result.Details); apiErr != nil {
+		return "", apiErr
+	}
+	var sb strings.Builder
+	for _, item := range result.Content {
+		if item.Type == "text" {
+			sb.WriteString(item.Text)
+		}
+	}
+	return sb.String(), nil
+}
+
+// CompactSession asks the gateway to compact a session's context via the
+// sessions.compact gateway method.
+func (c *Client) CompactSession(sessionKey string) error {
+	params, err := json.Marshal(map[string]string{"key": sessionKey})
+	if err != nil {
+		return err
+	}
+	start := time.Now()
+	out, err := exec.Command("openclaw", "gateway", "call", "sessions.compact",
+		"--params", string(params), "--json").CombinedOutput()
+	c.traceCLI("gateway call sessions.compact", len(params), start, err)
+	if err != nil {
+		return classified(ErrKindTool, fmt.Errorf("sessions.compact: %s", strings.TrimSpace(string(out))))
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"regexp"
+	"strings"
+)
+
+// externalChannels are channels whose sessions are bridged to real people.
+var externalChannels = map[string]bool{
+	"signal":   true,
+	"matrix":   true,
+	"discord":  true,
+	"telegram": true,
+	"whatsapp": true,
+	"slack":    true,
+	"imessage": true,
+}
+
+// IsExternalChannel reports whether messages on channel reach humans outside
+// OpenClaw, so sends should be previewed and confirmed.
+func IsExternalChannel(channel string) bool {
+	return externalChannels[strings.ToLower(channel)]
+}
+
+var (
+	mdLinkRe   = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+	mdBoldRe   = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+	mdItalicRe = regexp.MustCompile(`\*([^*\s][^*]*)\*|\b_([^_]+)_\b`)
+	mdCodeRe   = regexp.MustCompile("`([^`]+)`")
+	mdHeadRe   = regexp.MustCompile(`(?m)^#{1,6}\s+`)
+)
+
+// PreviewDelivery returns the text as the channel will render it. Signal,
+// WhatsApp, and iMessage have no markdown, so formatting markers are
+// stripped and links become "text (url)"; other channels render markdown
+// and receive the text unchanged apart from trimming.
+func PreviewDelivery(channel, text string) string {
+	text = strings.TrimSpace(text)
+	switch strings.ToLower(channel) {
+	case "signal", "whatsapp", "imessage":
+		text = mdLinkRe.ReplaceAllString(text, "$1 ($2)")
+		text = mdBoldRe.ReplaceAllString(text, "$1$2")
+		text = mdItalicRe.ReplaceAllString(text, "$1$2")
+		text = mdCodeRe.ReplaceAllString(text, "$1")
+		text = mdHeadRe.ReplaceAllString(text, "")
+	}
+	return text
+}
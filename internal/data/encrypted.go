@@ -0,0 +1,172 @@
+package data
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// TranscriptKeyEnv names the environment variable holding the key of
+// transcripts the gateway encrypts at rest: 32 bytes, base64-encoded.
+const TranscriptKeyEnv = "OPENCLAW_TRANSCRIPT_KEY"
+
+// TranscriptReadTool is the gateway tool that returns a transcript it
+// encrypted at rest as plain JSONL. Gateways without it answer with a
+// not-found error.
+const TranscriptReadTool = "transcripts_read"
+
+// encryptedMagic starts a transcript encrypted with the transcript key: it
+// is followed by a 12-byte nonce and the JSONL sealed with AES-256-GCM.
+var encryptedMagic = []byte("OCENC1")
+
+// ErrTranscriptEncrypted is returned for an encrypted transcript that
+// neither the transcript key nor the gateway could open.
+var ErrTranscriptEncrypted = errors.New("transcript is encrypted at rest; set " + TranscriptKeyEnv +
+	" or use a gateway with the " + TranscriptReadTool + " tool to open it")
+
+// isEncryptedTranscript reports whether head, the start of a transcript
+// file, carries the encryption header. Other files are left to the parser,
+// even when they aren't valid UTF-8: a transcript with a stray Latin-1 byte
+// in its first prompt still reads.
+func isEncryptedTranscript(head []byte) bool {
+	return bytes.HasPrefix(head, encryptedMagic)
+}
+
+// canDecryptTranscript reports whether the transcript starting with head
+// can be opened here with the transcript key.
+func canDecryptTranscript(head []byte) bool {
+	return bytes.HasPrefix(head, encryptedMagic) && os.Getenv(TranscriptKeyEnv) != ""
+}
+
+// transcriptEncryption reports whether the transcript at path is encrypted
+// at rest, and whether it is sealed: the transcript key can't open it here.
+func transcriptEncryption(path string) (encrypted, sealed bool) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, false
+	}
+	defer f.Close()
+	head, _ := io.ReadAll(io.LimitReader(f, 512))
+	if !isEncryptedTranscript(head) {
+		return false, false
+	}
+	return true, !canDecryptTranscript(head)
+}
+
+// transcriptKey reads the transcript key from the environment.
+func transcriptKey() ([]byte, error) {
+	encoded := strings.TrimSpace(os.Getenv(TranscriptKeyEnv))
+	if encoded == "" {
+		return nil, ErrTranscriptEncrypted
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		key, err = base64.RawStdEncoding.DecodeString(encoded)
+	}
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("%s must be 32 bytes, base64-encoded", TranscriptKeyEnv)
+	}
+	return key, nil
+}
+
+// decryptTranscript opens a transcript sealed with the transcript key.
+// Other encryption schemes are ErrTranscriptEncrypted.
+func decryptTranscript(raw []byte) ([]byte, error) {
+	if !bytes.HasPrefix(raw, encryptedMagic) {
+		return nil, ErrTranscriptEncrypted
+	}
+	key, err := transcriptKey()
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	sealed := raw[len(encryptedMagic):]
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("decrypt transcript: file is truncated")
+	}
+	plain, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt transcript: wrong %s or damaged file", TranscriptKeyEnv)
+	}
+	return plain, nil
+}
+
+// readPlainTranscript returns the contents of the transcript at path,
+// decrypted when it is encrypted at rest.
+func readPlainTranscript(path string) ([]byte, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !isEncryptedTranscript(raw) {
+		return raw, nil
+	}
+	return decryptTranscript(raw)
+}
+
+// readTranscriptFromGateway asks the gateway for an encrypted transcript
+// in plain text.
+func (c *Client) readTranscriptFromGateway(path string) ([]byte, error) {
+	body, err := c.invoke(toolRequest{Tool: TranscriptReadTool, Args: map[string]interface{}{"path": path}})
+	if err != nil {
+		return nil, transcriptReadError(err)
+	}
+	resp, err := decodeResponse(TranscriptReadTool, body)
+	if err != nil {
+		return nil, transcriptReadError(err)
+	}
+	if apiErr := resultError(TranscriptReadTool, resp.Result); apiErr != nil {
+		return nil, transcriptReadError(apiErr)
+	}
+	var result TextResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return nil, classified(ErrKindParse, fmt.Errorf("parse %s result: %w", TranscriptReadTool, err))
+	}
+	var b bytes.Buffer
+	for _, item := range result.Content {
+		if item.Type == "text" {
+			b.WriteString(item.Text)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// transcriptReadError turns a not-found answer about the transcript tool
+// itself into ErrTranscriptEncrypted.
+func transcriptReadError(err error) error {
+	if missingTool(err) {
+		return ErrTranscriptEncrypted
+	}
+	return err
+}
+
+// parseTranscript detects the format of a transcript held in memory and
+// parses it.
+func parseTranscript(content []byte) ([]HistoryMessage, TranscriptFormat, error) {
+	return parseTranscriptReader(content[:min(len(content), 64*1024)], bytes.NewReader(content))
+}
+
+// parseTranscriptReader parses the transcript read from r, whose start is
+// head.
+func parseTranscriptReader(head []byte, r io.Reader) ([]HistoryMessage, TranscriptFormat, error) {
+	format := DetectTranscriptFormat(head)
+	msgs, err := format.Parse(r)
+	for i := range msgs {
+		msgs[i].Text = strings.ReplaceAll(msgs[i].Text, "\r\n", "\n")
+	}
+	return msgs, format, err
+}
@@ -0,0 +1,229 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// ErrorKind classifies failures so the UI can present and retry them.
+type ErrorKind int
+
+const (
+	ErrKindOther   ErrorKind = iota
+	ErrKindNetwork           // gateway unreachable, timeouts, DNS
+	ErrKindAuth              // gateway rejected the token (401/403)
+	ErrKindTool              // gateway reached, but the tool call failed
+	ErrKindParse             // unexpected response shape
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrKindNetwork:
+		return "network"
+	case ErrKindAuth:
+		return "auth"
+	case ErrKindTool:
+		return "tool"
+	case ErrKindParse:
+		return "parse"
+	default:
+		return "error"
+	}
+}
+
+// Error attaches an ErrorKind to an underlying error.
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+func (e *Error) Unwrap() error { return e.Err }
+
+// classified wraps err with kind, leaving nil untouched.
+func classified(kind ErrorKind, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// APIError is a failure reported by the gateway, either as a non-200
+// response or as an error payload inside a tool result.
+type APIError struct {
+	Tool    string
+	Status  int // HTTP status; 0 when the error came inside a 200 response
+	Code    string
+	Message string
+	Details json.RawMessage
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	b.WriteString(e.Tool)
+	switch {
+	case e.Code != "":
+		b.WriteString(" " + e.Code)
+	case e.Status != 0:
+		fmt.Fprintf(&b, " %d", e.Status)
+	default:
+		b.WriteString(" failed")
+	}
+	if e.Message != "" {
+		b.WriteString(": " + e.Message)
+	}
+	return b.String()
+}
+
+// Kind reports auth for rejected credentials or denied access, and tool for
+// everything else.
+func (e *APIError) Kind() ErrorKind {
+	if e.Status == http.StatusUnauthorized || e.Status == http.StatusForbidden {
+		return ErrKindAuth
+	}
+	switch strings.ToLower(e.Code) {
+	case "forbidden", "denied", "unauthorized":
+		return ErrKindAuth
+	}
+	return ErrKindTool
+}
+
+// parseAPIError extracts code, message, and details from a gateway error
+// payload. Both {"error": "msg"} and {"error": {"code", "message",
+// "details"}} are understood, as is the {"status": "forbidden", "error":
+// "visibility"} shape some tools return inside their result.
+func parseAPIError(tool string, status int, body []byte) *APIError {
+	e := &APIError{Tool: tool, Status: status}
+	var env struct {
+		Error   json.RawMessage `json:"error"`
+		Status  string          `json:"status"`
+		Code    string          `json:"code"`
+		Message string          `json:"message"`
+		Details json.RawMessage `json:"details"`
+	}
+	if json.Unmarshal(body, &env) != nil {
+		e.Message = truncateError(string(body))
+		return e
+	}
+	e.Code, e.Message, e.Details = env.Code, env.Message, env.Details
+	if e.Code == "" && env.Status != "error" {
+		e.Code = env.Status
+	}
+	if len(env.Error) > 0 {
+		var msg string
+		var obj struct {
+			Code    string          `json:"code"`
+			Type    string          `json:"type"`
+			Message string          `json:"message"`
+			Details json.RawMessage `json:"details"`
+		}
+		if json.Unmarshal(env.Error, &msg) == nil {
+			e.Message = msg
+		} else if json.Unmarshal(env.Error, &obj) == nil {
+			if obj.Code == "" {
+				obj.Code = obj.Type
+			}
+			if obj.Code != "" {
+				e.Code = obj.Code
+			}
+			if obj.Message != "" {
+				e.Message = obj.Message
+			}
+			if len(obj.Details) > 0 {
+				e.Details = obj.Details
+			}
+		}
+	}
+	if e.Code == "" && e.Message == "" {
+		e.Message = truncateError(string(body))
+	}
+	return e
+}
+
+// resultError returns an *APIError when a tool result payload reports a
+// failure ({"status": "forbidden"} or a non-empty "error"), otherwise nil.
+func resultError(tool string, payload []byte) *APIError {
+	if len(payload) == 0 {
+		return nil
+	}
+	var check struct {
+		Status string          `json:"status"`
+		Error  json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(payload, &check) != nil {
+		return nil
+	}
+	hasError := len(check.Error) > 0 && string(check.Error) != "null" && string(check.Error) != `""`
+	if check.Status != "forbidden" && check.Status != "error" && !hasError {
+		return nil
+	}
+	return parseAPIError(tool, 0, payload)
+}
+
+// agentError turns the output of a failed openclaw agent run into an error.
+// With --json the CLI reports failures such as rate limits and unknown
+// models as an error payload, which becomes an *APIError carrying the code;
+// other output is returned as text.
+func agentError(out []byte) error {
+	if apiErr := resultError("openclaw agent", cliJSON(out)); apiErr != nil {
+		return apiErr
+	}
+	return fmt.Errorf("openclaw agent: %s", string(out))
+}
+
+// cliJSON returns the JSON document in CLI output, skipping any log lines
+// printed before it, or nil when there is none.
+func cliJSON(out []byte) []byte {
+	text := string(out)
+	for start := 0; start < len(text); {
+		if rest := strings.TrimSpace(text[start:]); strings.HasPrefix(rest, "{") && json.Valid([]byte(rest)) {
+			return []byte(rest)
+		}
+		next := strings.IndexByte(text[start:], '\n')
+		if next < 0 {
+			break
+		}
+		start += next + 1
+	}
+	return nil
+}
+
+func truncateError(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) > 200 {
+		s = s[:197] + "..."
+	}
+	return s
+}
+
+// KindOf returns the category of err, looking through wrapped errors and
+// falling back to the standard library's network and JSON error types.
+func KindOf(err error) ErrorKind {
+	if err == nil {
+		return ErrKindOther
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Kind()
+	}
+	var netErr net.Error
+	var urlErr *url.Error
+	if errors.As(err, &netErr) || errors.As(err, &urlErr) {
+		return ErrKindNetwork
+	}
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+		return ErrKindParse
+	}
+	return ErrKindOther
+}
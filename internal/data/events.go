@@ -0,0 +1,80 @@
+package data
+
+import (
+	"strings"
+	"time"
+)
+
+// EventKind is what happened to a session.
+type EventKind string
+
+const (
+	EventCreated   EventKind = "created"
+	EventCompleted EventKind = "completed"
+	EventFailed    EventKind = "failed"
+	EventDenied    EventKind = "denied" // failed because a tool call was denied
+	EventMessage   EventKind = "message"
+)
+
+// Event is a change in a session seen between two polls of the gateway's
+// session list.
+type Event struct {
+	At      time.Time
+	Kind    EventKind
+	Session Session
+	Detail  string
+}
+
+// SessionEvents compares two successive session lists and returns what
+// happened in between: new sessions, runs that completed or failed, and
+// new messages on sessions bridged to Signal, Matrix, and other external
+// channels. Events come in the order of next.
+func SessionEvents(prev, next []Session, now time.Time) []Event {
+	before := make(map[string]Session, len(prev))
+	for _, s := range prev {
+		before[s.Key] = s
+	}
+	var events []Event
+	for _, s := range next {
+		at := now
+		if s.UpdatedAt > 0 {
+			at = time.UnixMilli(s.UpdatedAt)
+		}
+		p, seen := before[s.Key]
+		if !seen {
+			detail := ModelAlias(s.Model)
+			if s.Channel != "" {
+				detail += " on " + s.Channel
+			}
+			events = append(events, Event{At: at, Kind: EventCreated, Session: s, Detail: detail})
+			continue
+		}
+		if status := s.EffectiveStatus(); status != p.EffectiveStatus() {
+			switch status {
+			case "completed":
+				events = append(events, Event{At: at, Kind: EventCompleted, Session: s})
+			case "failed":
+				kind := EventFailed
+				if isDenial(s.ErrorMessage) {
+					kind = EventDenied
+				}
+				events = append(events, Event{At: at, Kind: kind, Session: s, Detail: s.ErrorMessage})
+			}
+		}
+		if IsExternalChannel(s.Channel) && s.UpdatedAt > p.UpdatedAt {
+			events = append(events, Event{At: at, Kind: EventMessage, Session: s, Detail: "new message on " + s.Channel})
+		}
+	}
+	return events
+}
+
+// isDenial reports whether a session error says a tool call was refused.
+func isDenial(msg string) bool {
+	msg = strings.ToLower(msg)
+	for _, word := range []string{"denied", "not allowed", "forbidden", "rejected"} {
+		if strings.Contains(msg, word) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// execYieldMs is how long the exec tool waits for a command to finish
+// before backgrounding it and returning, so long commands can be polled
+// for output as they run.
+const execYieldMs = 1000
+
+// ExecResult is output from a command run through the gateway's exec tool.
+type ExecResult struct {
+	Output    string // output since the last result
+	Running   bool   // the command is still running; poll SessionID for more
+	SessionID string // process session of a backgrounded command
+	ExitCode  int
+	Failed    bool
+}
+
+// execDetails is the details payload of exec and process poll results.
+type execDetails struct {
+	Status    string `json:"status"`
+	SessionID string `json:"sessionId"`
+	ExitCode  *int   `json:"exitCode"`
+}
+
+// ExecCommand runs command in workdir on the gateway host via the exec
+// tool. Commands that outlast execYieldMs come back Running, with the
+// output so far; PollCommand fetches the rest.
+func (c *Client) ExecCommand(command, workdir string) (ExecResult, error) {
+	args := map[string]interface{}{"command": command, "yieldMs": execYieldMs}
+	if workdir != "" {
+		args["workdir"] = workdir
+	}
+	return c.execResult("exec", args)
+}
+
+// PollCommand returns the new output of a backgrounded command and whether
+// it is still running.
+func (c *Client) PollCommand(sessionID string) (ExecResult, error) {
+	res, err := c.execResult("process", map[string]interface{}{"action": "poll", "sessionId": sessionID})
+	if res.SessionID == "" {
+		res.SessionID = sessionID
+	}
+	return res, err
+}
+
+func (c *Client) execResult(tool string, args map[string]interface{}) (ExecResult, error) {
+	body, err := c.invoke(toolRequest{Tool: tool, Args: args})
+	if err != nil {
+		return ExecResult{}, err
+	}
+	resp, err := decodeResponse(tool, body)
+	if err != nil {
+		return ExecResult{}, err
+	}
+	var result struct {
+		TextResult
+		Details json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return ExecResult{}, classified(ErrKindParse, fmt.Errorf("parse %s result: %w", tool, err))
+	}
+	var details execDetails
+	if len(result.Details) > 0 {
+		json.Unmarshal(result.Details, &details)
+	}
+
+	var sb strings.Builder
+	for _, item := range result.Content {
+		if item.Type == "text" {
+			sb.WriteString(item.Text)
+		}
+	}
+	res := ExecResult{
+		Output:    StripANSI(sb.String()),
+		Running:   details.Status == "running",
+		SessionID: details.SessionID,
+		Failed:    details.Status == "failed" || details.Status == "error",
+	}
+	if details.ExitCode != nil {
+		res.ExitCode = *details.ExitCode
+		res.Failed = res.Failed || res.ExitCode != 0
+	}
+	return res, nil
+}
+
+// StartProcess starts command in the background through the exec tool and
+// returns the process session it runs under.
+func (c *Client) StartProcess(command string) (string, error) {
+	res, err := c.execResult("exec", map[string]interface{}{"command": command, "background": true})
+	if err != nil {
+		return "", err
+	}
+	if res.Failed {
+		return res.SessionID, fmt.Errorf("exec %s: exited with status %d", command, res.ExitCode)
+	}
+	return res.SessionID, nil
+}
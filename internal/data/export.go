@@ -0,0 +1,156 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// RunExport is an archived run as pushed to an export backend: its
+// transcript and a summary, stored under Name.
+type RunExport struct {
+	Name       string // file-safe name, e.g. "fix-login-20250301-1412"
+	Transcript string // path of the transcript file
+	Summary    string
+	Messages   []HistoryMessage // the parsed transcript, for training formats
+}
+
+// RunSummary describes run for an export: what it was and what it
+// produced.
+func RunSummary(run ArchivedRun, msgs []HistoryMessage) string {
+	var b strings.Builder
+	title := run.Label
+	if title == "" {
+		title = run.SessionID
+	}
+	outcome := run.Outcome
+	if outcome == "" {
+		outcome = "unknown"
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "Session: %s\n", run.SessionID)
+	fmt.Fprintf(&b, "Outcome: %s\n", outcome)
+	if run.Format != "" {
+		fmt.Fprintf(&b, "Format: %s\n", run.Format)
+	}
+	fmt.Fprintf(&b, "Last written: %s\n", time.UnixMilli(run.ModifiedAt).Format(time.RFC3339))
+	fmt.Fprintf(&b, "Messages: %d\n\n", len(msgs))
+	b.WriteString(FormatOutputs(CollectOutputs(msgs)))
+	return b.String()
+}
+
+// ExportRun pushes run to the backend e and returns where it went.
+func ExportRun(e config.Exporter, run RunExport) (string, error) {
+	switch e.Type {
+	case config.ExportS3:
+		return exportS3(e, run)
+	case config.ExportGit:
+		return exportGit(e, run)
+	case config.ExportOpenAI, config.ExportAnthropic:
+		return exportTraining(e, run)
+	}
+	return "", fmt.Errorf("unknown exporter type %q", e.Type)
+}
+
+// transcriptName is the name the transcript is stored under, keeping its
+// extension.
+func transcriptName(run RunExport) string {
+	ext := filepath.Ext(run.Transcript)
+	if ext == "" {
+		ext = ".jsonl"
+	}
+	return "transcript" + ext
+}
+
+// exportS3 uploads the transcript and summary with the aws CLI, which
+// brings its own credentials and configuration.
+func exportS3(e config.Exporter, run RunExport) (string, error) {
+	summary, err := os.CreateTemp("", "openclaw-commander-summary-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(summary.Name())
+	_, err = summary.WriteString(run.Summary)
+	if cerr := summary.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", err
+	}
+
+	prefix := strings.Trim(e.Prefix, "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	dest := fmt.Sprintf("s3://%s/%s%s/", e.Bucket, prefix, run.Name)
+	uploads := [][2]string{
+		{run.Transcript, dest + transcriptName(run)},
+		{summary.Name(), dest + "summary.md"},
+	}
+	for _, u := range uploads {
+		args := []string{"s3", "cp", u[0], u[1], "--only-show-errors"}
+		if e.Profile != "" {
+			args = append(args, "--profile", e.Profile)
+		}
+		if out, err := exec.Command("aws", args...).CombinedOutput(); err != nil {
+			return "", commandError("aws s3 cp", out, err)
+		}
+	}
+	return dest, nil
+}
+
+// exportGit copies the transcript and summary into a local clone, commits
+// just those files, and pushes when configured to.
+func exportGit(e config.Exporter, run RunExport) (string, error) {
+	repo := config.ExpandHome(e.Repo)
+	rel := filepath.Join(e.Prefix, run.Name)
+	dir := filepath.Join(repo, rel)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	transcript, err := os.ReadFile(run.Transcript)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, transcriptName(run)), transcript, 0o644); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "summary.md"), []byte(run.Summary), 0o644); err != nil {
+		return "", err
+	}
+
+	git := func(args ...string) error {
+		out, err := exec.Command("git", append([]string{"-C", repo}, args...)...).CombinedOutput()
+		if err != nil {
+			return commandError("git "+args[0], out, err)
+		}
+		return nil
+	}
+	if err := git("add", "--", rel); err != nil {
+		return "", err
+	}
+	// Commit only the run's files, leaving anything else staged alone
+	if err := git("commit", "-m", "Export run "+run.Name, "--", rel); err != nil {
+		return "", err
+	}
+	if e.Push {
+		if err := git("push"); err != nil {
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+// commandError adds the last line a failed command printed to its error.
+func commandError(name string, out []byte, err error) error {
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return fmt.Errorf("%s: %s", name, last)
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
@@ -1,32 +1,39 @@
 package data
 
 import (
-	"bufio"
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"sort"
+	"strconv"
 	"strings"
 	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
 )
 
 // FetchSessions uses `openclaw sessions --json` to list all sessions.
 // The CLI reads the session store directly and is not subject to the
 // per-session tool visibility scoping that limits the sessions_list tool.
 func (c *Client) FetchSessions() ([]Session, error) {
+	start := time.Now()
 	out, err := exec.Command("openclaw", "sessions", "--json").Output()
+	c.traceCLI("sessions", 0, start, err)
 	if err != nil {
 		return nil, fmt.Errorf("openclaw sessions: %w", err)
 	}
 
 	var resp SessionsResponse
 	if err := json.Unmarshal(out, &resp); err != nil {
-		return nil, fmt.Errorf("parse sessions response: %w", err)
+		return nil, classified(ErrKindParse, fmt.Errorf("parse sessions response: %w", err))
 	}
 
+	assignProjects(resp.Sessions)
 	return resp.Sessions, nil
 }
 
@@ -42,70 +49,58 @@ func (c *Client) FetchProcesses() ([]Process, error) {
 				Status  string `json:"status"`
 				Runtime string `json:"runtime"`
 				Command string `json:"command"`
+				PID     int    `json:"pid"`
 			} `json:"processes"`
 			UpdatedAt int64 `json:"updatedAt"`
 		}
 		if json.Unmarshal(data, &pf) == nil && len(pf.Processes) > 0 {
 			// Check staleness — if older than 2 minutes, also scan ps
 			var procs []Process
+			var pids []int
 			for _, p := range pf.Processes {
 				procs = append(procs, Process{
 					SessionName: p.Name,
 					Status:      p.Status,
 					Runtime:     p.Runtime,
 					Command:     p.Command,
+					PID:         p.PID,
 				})
+				if p.PID > 0 {
+					pids = append(pids, p.PID)
+				}
 			}
+			c.recordResources(procs, sampleUsage(pids))
 			return procs, nil
 		}
 	}
 
 	// Fallback: scan OS processes
-	out, err := exec.Command("ps", "axo", "pid,etime,command").Output()
+	procs, usage, err := scanProcesses()
 	if err != nil {
 		return nil, nil
 	}
+	c.recordResources(procs, usage)
+	return procs, nil
+}
 
-	var procs []Process
-	for _, line := range strings.Split(string(out), "\n") {
-		line = strings.TrimSpace(line)
-		lower := strings.ToLower(line)
-
-		isRelevant := strings.Contains(lower, "claude") ||
-			strings.Contains(lower, "openclaw") ||
-			strings.Contains(lower, "oclaw-tui")
-
-		if !isRelevant {
-			continue
-		}
-
-		if strings.Contains(lower, "chrome") || strings.Contains(lower, "chromium") ||
-			strings.Contains(lower, "firefox") || strings.Contains(lower, "electron") ||
-			strings.HasPrefix(line, "PID") || strings.Contains(line, "ps axo") {
-			continue
-		}
-
-		fields := strings.Fields(line)
-		if len(fields) < 3 {
-			continue
-		}
-
-		pid := fields[0]
-		etime := fields[1]
-		cmd := strings.Join(fields[2:], " ")
-		if len(cmd) > 150 {
-			cmd = cmd[:147] + "..."
-		}
-
-		procs = append(procs, Process{
-			SessionName: "pid:" + pid,
-			Status:      "running",
-			Runtime:     etime,
-			Command:     cmd,
-		})
+// relevantProcess reports whether an OS process command line belongs to an
+// agent rather than a browser or the scan itself.
+func relevantProcess(cmdline string) bool {
+	lower := strings.ToLower(cmdline)
+	if !strings.Contains(lower, "claude") && !strings.Contains(lower, "openclaw") &&
+		!strings.Contains(lower, "oclaw-tui") {
+		return false
 	}
+	return !strings.Contains(lower, "chrome") && !strings.Contains(lower, "chromium") &&
+		!strings.Contains(lower, "firefox") && !strings.Contains(lower, "electron")
+}
 
-	return procs, nil
+// truncateCommand shortens a command line for the process list.
+func truncateCommand(cmd string) string {
+	if len(cmd) > 150 {
+		return cmd[:147] + "..."
+	}
+	return cmd
 }
 
 // parseProcessList parses the text table from the process list API.
@@ -168,17 +163,20 @@ func (c *Client) FetchProcessLog(sessionID string, limit int) (string, error) {
 		return "", fmt.Errorf("process log unavailable: %w", err)
 	}
 
-	var resp APIResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return "", nil
-	}
-	if !resp.OK {
-		return "", nil
+	resp, err := decodeResponse("process", body)
+	if err != nil {
+		return "", err
 	}
 
-	var result TextResult
+	var result struct {
+		TextResult
+		Details json.RawMessage `json:"details"`
+	}
 	if err := json.Unmarshal(resp.Result, &result); err != nil {
-		return "", nil
+		return "", classified(ErrKindParse, fmt.Errorf("parse process log result: %w", err))
+	}
+	if apiErr := resultError("process", result.Details); apiErr != nil {
+		return "", apiErr
 	}
 
 	var sb strings.Builder
@@ -196,7 +194,7 @@ func (c *Client) FetchSessionHistory(sessionKey string, limit int) (string, erro
 	if err != nil {
 		return "", err
 	}
-	return FormatHistory(msgs, VerboseSummary), nil
+	return FormatHistory(msgs, VerboseSummary, false), nil
 }
 
 // FetchSessionMessages returns parsed history messages.
@@ -218,12 +216,9 @@ func (c *Client) FetchSessionMessages(sessionKey string, limit int, sessionID ..
 		return nil, err
 	}
 
-	var resp APIResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
-		return nil, fmt.Errorf("parse history response: %w", err)
-	}
-	if !resp.OK {
-		return nil, fmt.Errorf("sessions_history: API error")
+	resp, err := decodeResponse("sessions_history", body)
+	if err != nil {
+		return nil, err
 	}
 
 	// The tool returns its result in result.content[0].text as a JSON string
@@ -251,12 +246,7 @@ func (c *Client) FetchSessionMessages(sessionKey string, limit int, sessionID ..
 	}
 	
 	// Check if the history response contains an error (forbidden/visibility)
-	var checkErr struct {
-		Status string `json:"status"`
-		Error  string `json:"error"`
-	}
-	json.Unmarshal(historyJSON, &checkErr)
-	if checkErr.Status == "forbidden" || checkErr.Error != "" {
+	if apiErr := resultError("sessions_history", historyJSON); apiErr != nil {
 		// Fall back to transcript file
 		sid := ""
 		if len(sessionID) > 0 {
@@ -274,7 +264,7 @@ func (c *Client) FetchSessionMessages(sessionKey string, limit int, sessionID ..
 				return msgs, nil
 			}
 		}
-		return nil, fmt.Errorf("sessions_history: %s", checkErr.Error)
+		return nil, apiErr
 	}
 
 	// Parse the actual history response
@@ -283,79 +273,64 @@ func (c *Client) FetchSessionMessages(sessionKey string, limit int, sessionID ..
 		Messages   []json.RawMessage `json:"messages"`
 	}
 	if err := json.Unmarshal(historyJSON, &result); err != nil {
-		return nil, fmt.Errorf("parse history result: %w", err)
+		return nil, classified(ErrKindParse, fmt.Errorf("parse history result: %w", err))
 	}
 
 	var msgs []HistoryMessage
 	for _, raw := range result.Messages {
-		var base struct {
-			Role     string `json:"role"`
-			Model    string `json:"model,omitempty"`
-			Content  []struct {
-				Type      string          `json:"type"`
-				Text      string          `json:"text"`
-				Name      string          `json:"name,omitempty"`
-				ID        string          `json:"id,omitempty"`
-				Arguments json.RawMessage `json:"arguments,omitempty"`
-			} `json:"content"`
-			ToolName   string `json:"toolName,omitempty"`
-			ToolCallId string `json:"toolCallId,omitempty"`
-			IsError    bool   `json:"isError,omitempty"`
-			Timestamp  int64  `json:"timestamp,omitempty"`
-		}
-		if json.Unmarshal(raw, &base) != nil {
-			continue
-		}
+		msgs = append(msgs, parseGatewayMessage(raw)...)
+	}
+	return msgs, nil
+}
 
-		// Check if this assistant message contains toolCall content blocks
-		// If so, emit them as separate toolUse messages
-		if base.Role == "assistant" {
-			hasToolCalls := false
-			for _, c := range base.Content {
-				if c.Type == "toolCall" || c.Type == "tool_use" {
-					hasToolCalls = true
-					msg := HistoryMessage{
-						Role:      "toolUse",
-						Model:     base.Model,
-						ToolName:  c.Name,
-						Timestamp: base.Timestamp,
-					}
-					// Extract args summary from the arguments
-					if len(c.Arguments) > 0 {
-						msg.ToolArgs = extractToolArgsFromJSON(c.Arguments)
-					}
-					msgs = append(msgs, msg)
-				}
-			}
-			// Also emit any text content as an assistant message
-			var text strings.Builder
-			for _, c := range base.Content {
-				if c.Type == "text" && c.Text != "" {
-					if text.Len() > 0 {
-						text.WriteString("\n")
-					}
-					text.WriteString(c.Text)
-				}
-			}
-			if text.Len() > 0 {
-				msgs = append(msgs, HistoryMessage{
-					Role:      "assistant",
-					Model:     base.Model,
-					Text:      text.String(),
-					Timestamp: base.Timestamp,
-				})
-			} else if !hasToolCalls {
-				// Empty assistant message with no tools
-				msgs = append(msgs, HistoryMessage{
-					Role:      "assistant",
+// parseGatewayMessage turns one message of a sessions_history result into
+// history messages: an assistant message becomes one toolUse message per
+// tool call and an assistant message for its text. It returns nil for a
+// message it can't read.
+func parseGatewayMessage(raw json.RawMessage) []HistoryMessage {
+	var msgs []HistoryMessage
+	var base struct {
+		Role     string `json:"role"`
+		Model    string `json:"model,omitempty"`
+		Content  []struct {
+			Type      string          `json:"type"`
+			Text      string          `json:"text"`
+			Name      string          `json:"name,omitempty"`
+			ID        string          `json:"id,omitempty"`
+			Arguments json.RawMessage `json:"arguments,omitempty"`
+			Thinking  string          `json:"thinking,omitempty"`
+		} `json:"content"`
+		ToolName   string `json:"toolName,omitempty"`
+		ToolCallId string `json:"toolCallId,omitempty"`
+		IsError    bool   `json:"isError,omitempty"`
+		Timestamp  int64  `json:"timestamp,omitempty"`
+	}
+	if json.Unmarshal(raw, &base) != nil {
+		return nil
+	}
+
+	// Check if this assistant message contains toolCall content blocks
+	// If so, emit them as separate toolUse messages
+	if base.Role == "assistant" {
+		hasToolCalls := false
+		for _, c := range base.Content {
+			if c.Type == "toolCall" || c.Type == "tool_use" {
+				hasToolCalls = true
+				msg := HistoryMessage{
+					Role:      "toolUse",
 					Model:     base.Model,
+					ToolName:  c.Name,
 					Timestamp: base.Timestamp,
-				})
+				}
+				// Extract args summary from the arguments
+				if len(c.Arguments) > 0 {
+					msg.ToolArgs = extractToolArgsFromJSON(c.Arguments)
+				}
+				msgs = append(msgs, msg)
 			}
-			continue
 		}
-
-		var text strings.Builder
+		// Also emit any text content as an assistant message
+		var text, thinking strings.Builder
 		for _, c := range base.Content {
 			if c.Type == "text" && c.Text != "" {
 				if text.Len() > 0 {
@@ -363,24 +338,56 @@ func (c *Client) FetchSessionMessages(sessionKey string, limit int, sessionID ..
 				}
 				text.WriteString(c.Text)
 			}
+			if c.Type == "thinking" && c.Thinking != "" {
+				if thinking.Len() > 0 {
+					thinking.WriteString("\n")
+				}
+				thinking.WriteString(c.Thinking)
+			}
+		}
+		if text.Len() > 0 || thinking.Len() > 0 {
+			msgs = append(msgs, HistoryMessage{
+				Role:      "assistant",
+				Model:     base.Model,
+				Text:      text.String(),
+				Thinking:  thinking.String(),
+				Timestamp: base.Timestamp,
+			})
+		} else if !hasToolCalls {
+			// Empty assistant message with no tools
+			msgs = append(msgs, HistoryMessage{
+				Role:      "assistant",
+				Model:     base.Model,
+				Timestamp: base.Timestamp,
+			})
+		}
+		return msgs
+	}
+
+	var text strings.Builder
+	for _, c := range base.Content {
+		if c.Type == "text" && c.Text != "" {
+			if text.Len() > 0 {
+				text.WriteString("\n")
+			}
+			text.WriteString(c.Text)
 		}
+	}
 
-		msg := HistoryMessage{
-			Role:      base.Role,
-			Model:     base.Model,
-			Text:      text.String(),
-			Timestamp: base.Timestamp,
-		}
-
-		if base.Role == "toolResult" || base.Role == "toolUse" || base.Role == "tool" {
-			msg.ToolName = base.ToolName
-			msg.ToolError = base.IsError
-			msg.ToolArgs = extractToolArgs(raw)
-		}
+	msg := HistoryMessage{
+		Role:      base.Role,
+		Model:     base.Model,
+		Text:      text.String(),
+		Timestamp: base.Timestamp,
+	}
 
-		msgs = append(msgs, msg)
+	if base.Role == "toolResult" || base.Role == "toolUse" || base.Role == "tool" {
+		msg.ToolName = base.ToolName
+		msg.ToolError = base.IsError
+		msg.ToolArgs = extractToolArgs(raw)
 	}
-	return msgs, nil
+
+	return []HistoryMessage{msg}
 }
 
 // extractToolArgsFromJSON extracts a short summary from tool call arguments JSON.
@@ -468,8 +475,29 @@ func extractToolArgs(raw json.RawMessage) string {
 	return strings.Join(parts, " ")
 }
 
-// toolEmoji returns an emoji for a tool name.
+// toolStyles holds the configured tool emoji and labels; see SetToolStyles.
+var toolStyles map[string]config.ToolStyle
+
+// SetToolStyles installs the tool emoji and labels from config.Tools for
+// FormatHistory. Call it once at startup.
+func SetToolStyles(tools map[string]config.ToolStyle) {
+	toolStyles = tools
+}
+
+// toolLabel returns the name to show for a tool: its configured label, or
+// the name itself.
+func toolLabel(name string) string {
+	if style, ok := config.ToolStyleFor(toolStyles, name); ok && style.Label != "" {
+		return style.Label
+	}
+	return name
+}
+
+// toolEmoji returns an emoji for a tool name, preferring a configured one.
 func toolEmoji(name string) string {
+	if style, ok := config.ToolStyleFor(toolStyles, name); ok && style.Emoji != "" {
+		return style.Emoji
+	}
 	switch strings.ToLower(name) {
 	case "read", "file_read":
 		return "📖"
@@ -497,13 +525,16 @@ func toolEmoji(name string) string {
 		return "📱"
 	case "canvas":
 		return "🎨"
+	case "sessions_spawn":
+		return "🤖"
 	default:
 		return "🔧"
 	}
 }
 
-// FormatHistory renders messages according to the verbose level.
-func FormatHistory(msgs []HistoryMessage, verbose VerboseLevel) string {
+// FormatHistory renders messages according to the verbose level. Reasoning
+// is collapsed to a one-line summary unless showThinking is set.
+func FormatHistory(msgs []HistoryMessage, verbose VerboseLevel, showThinking bool) string {
 	var sb strings.Builder
 	// Track consecutive tool calls for collapsing in summary mode
 	var toolBatch []HistoryMessage
@@ -544,7 +575,11 @@ func FormatHistory(msgs []HistoryMessage, verbose VerboseLevel) string {
 			line := fmt.Sprintf(" %s %s %s", status, emoji, summary)
 			sb.WriteString(line + "\n")
 			if msg.ToolError && msg.Text != "" {
-				errLines := strings.Split(msg.Text, "\n")
+				text := msg.Text
+				if pretty, ok := PrettyJSON(text, JSONMaxDepth); ok {
+					text = pretty
+				}
+				errLines := strings.Split(text, "\n")
 				limit := 6
 				if len(errLines) < limit {
 					limit = len(errLines)
@@ -574,12 +609,19 @@ func FormatHistory(msgs []HistoryMessage, verbose VerboseLevel) string {
 				role := strings.ToUpper(msg.Role)
 				name := msg.ToolName
 				if name != "" {
-					role = role + " (" + name + ")"
+					role = role + " (" + toolLabel(name) + ")"
 				}
 				sb.WriteString(fmt.Sprintf("─── %s ───\n", role))
-				if msg.Text != "" {
+				if pretty, ok := PrettyJSON(msg.Text, JSONMaxDepth); ok {
+					sb.WriteString(pretty + "\n")
+				} else if msg.Text != "" {
 					sb.WriteString(msg.Text + "\n")
 				}
+				if msg.Role != "toolUse" && isSpawnTool(name) {
+					if child := SpawnedSession(msg.Text); child != "" {
+						sb.WriteString("↳ " + SpawnLinkPrefix + child + "\n")
+					}
+				}
 				sb.WriteString("\n")
 			}
 		default:
@@ -593,6 +635,9 @@ func FormatHistory(msgs []HistoryMessage, verbose VerboseLevel) string {
 				sb.WriteString(fmt.Sprintf("(%s) ", msg.Model))
 			}
 			sb.WriteString("───\n")
+			if msg.Thinking != "" {
+				sb.WriteString(formatThinking(msg.Thinking, showThinking))
+			}
 			if msg.Text != "" {
 				sb.WriteString(msg.Text + "\n")
 			}
@@ -606,6 +651,23 @@ func FormatHistory(msgs []HistoryMessage, verbose VerboseLevel) string {
 	return sb.String()
 }
 
+// ThinkingPrefix starts every line of reasoning in formatted history, so the
+// UI can set it apart from the reply.
+const ThinkingPrefix = "💭 "
+
+// formatThinking renders an assistant turn's reasoning: a one-line summary
+// when collapsed, otherwise every line under ThinkingPrefix.
+func formatThinking(text string, expand bool) string {
+	if !expand {
+		return fmt.Sprintf("%sthinking… (%d words)\n", ThinkingPrefix, len(strings.Fields(text)))
+	}
+	var sb strings.Builder
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		sb.WriteString(ThinkingPrefix + line + "\n")
+	}
+	return sb.String()
+}
+
 // formatToolSummary produces a Claude Code-style one-liner for a tool call.
 func formatToolSummary(toolName, args, resultText string, isError bool) string {
 	lower := strings.ToLower(toolName)
@@ -648,8 +710,13 @@ func formatToolSummary(toolName, args, resultText string, isError bool) string {
 		return fmt.Sprintf("searched %s", args)
 	case "web_fetch", "fetch":
 		return fmt.Sprintf("fetched %s", args)
+	case "sessions_spawn":
+		if child := SpawnedSession(resultText); child != "" && !isError {
+			return SpawnLinkPrefix + child
+		}
+		return toolLabel(toolName) + " " + args
 	default:
-		summary := toolName
+		summary := toolLabel(toolName)
 		if args != "" {
 			summary += " " + args
 		}
@@ -683,21 +750,24 @@ func extractArgValue(args string, keys ...string) string {
 // shortenPath returns a shorter version of a file path for display.
 func shortenPath(path string) string {
 	// Remove common prefixes
-	prefixes := []string{
-		"/home/enum/Projects/",
-		"/home/enum/.openclaw/workspace/",
-		"/home/enum/",
-	}
-	for _, p := range prefixes {
-		if strings.HasPrefix(path, p) {
-			return path[len(p):]
+	if home := homeDir(); home != "" {
+		prefixes := []string{
+			filepath.Join(home, "Projects"),
+			filepath.Join(home, ".openclaw", "workspace"),
+			home,
+		}
+		for _, p := range prefixes {
+			if rest, ok := strings.CutPrefix(path, p+string(filepath.Separator)); ok {
+				return rest
+			}
 		}
 	}
 	// If still long, show last 2-3 path components
 	if len(path) > 120 {
-		parts := strings.Split(path, "/")
+		sep := string(filepath.Separator)
+		parts := strings.Split(filepath.FromSlash(path), sep)
 		if len(parts) > 3 {
-			return "…/" + strings.Join(parts[len(parts)-3:], "/")
+			return "…" + sep + strings.Join(parts[len(parts)-3:], sep)
 		}
 	}
 	return path
@@ -710,119 +780,98 @@ func dimStyleGlobal(s string) string {
 
 // SendMessage sends a message to a session via `openclaw agent`.
 func (c *Client) SendMessage(sessionID, message string) (string, error) {
+	start := time.Now()
 	out, err := exec.Command("openclaw", "agent",
 		"--session-id", sessionID,
 		"--message", message,
 		"--json").CombinedOutput()
+	c.traceCLI("agent", len(message), start, err)
 	if err != nil {
-		return "", fmt.Errorf("openclaw agent: %s", string(out))
+		return "", agentError(out)
+	}
+	// Some failures, such as rate limits, exit cleanly with an error payload
+	if apiErr := resultError("openclaw agent", cliJSON(out)); apiErr != nil {
+		return "", apiErr
 	}
 	return string(out), nil
 }
 
 // FetchArchivedRuns finds transcript files that aren't in the active sessions list.
-// These are typically completed/cleaned-up sub-agent runs.
+// These are typically completed/cleaned-up sub-agent runs. Transcripts in the
+// configured extra directories (e.g. Claude Code's) are listed alongside.
+// Labels are cached, so only new or changed transcripts are read.
 func (c *Client) FetchArchivedRuns(activeSessions []Session) ([]ArchivedRun, error) {
-	sessDir := filepath.Join(homeDir(), ".openclaw", "agents", "main", "sessions")
-	entries, err := os.ReadDir(sessDir)
-	if err != nil {
-		return nil, nil // graceful if dir doesn't exist
+	runs := c.listArchivedRuns(activeSessions)
+	for i := range runs {
+		c.labelRun(&runs[i])
 	}
+	return runs, nil
+}
 
-	// Build set of active session IDs
-	activeIDs := make(map[string]bool)
-	for _, s := range activeSessions {
-		activeIDs[s.SessionID] = true
-	}
+// transcriptDirDepth limits how deep extra transcript directories are
+// searched; Claude Code keeps transcripts one level down, per project.
+const transcriptDirDepth = 3
 
-	var runs []ArchivedRun
+// walkTranscripts calls fn for each .jsonl file under dir, descending at most
+// depth levels. Unreadable directories are skipped.
+func walkTranscripts(dir string, depth int, fn func(path string, info os.FileInfo)) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
 	for _, e := range entries {
-		if e.IsDir() || !strings.HasSuffix(e.Name(), ".jsonl") {
+		path := filepath.Join(dir, e.Name())
+		if e.IsDir() {
+			if depth > 0 && !strings.HasPrefix(e.Name(), ".") {
+				walkTranscripts(path, depth-1, fn)
+			}
 			continue
 		}
-		sessionID := strings.TrimSuffix(e.Name(), ".jsonl")
-		if activeIDs[sessionID] {
-			continue // skip active sessions
-		}
-
-		info, err := e.Info()
-		if err != nil {
+		if !strings.HasSuffix(e.Name(), ".jsonl") {
 			continue
 		}
-
-		// Try to read first line to get a label
-		label := readTranscriptLabel(filepath.Join(sessDir, e.Name()))
-
-		runs = append(runs, ArchivedRun{
-			SessionID:  sessionID,
-			Label:      label,
-			Size:       info.Size(),
-			ModifiedAt: info.ModTime().UnixMilli(),
-			Path:       filepath.Join(sessDir, e.Name()),
-		})
+		if info, err := e.Info(); err == nil {
+			fn(path, info)
+		}
 	}
-
-	// Sort by modified time, newest first
-	sort.Slice(runs, func(i, j int) bool {
-		return runs[i].ModifiedAt > runs[j].ModifiedAt
-	})
-
-	return runs, nil
 }
 
-// readTranscriptLabel reads the first user message from a transcript to use as a label.
-func readTranscriptLabel(path string) string {
+// readTranscriptLabel reads the first user message from a transcript to use
+// as a label, returning it with the detected format's name. Only the start
+// of the file is read.
+func readTranscriptLabel(path string) (string, string) {
 	f, err := os.Open(path)
 	if err != nil {
-		return ""
+		return "", ""
 	}
 	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 64*1024), 64*1024)
-	for scanner.Scan() {
-		var entry struct {
-			Type    string `json:"type"`
-			Message struct {
-				Role    string `json:"role"`
-				Content []struct {
-					Type string `json:"type"`
-					Text string `json:"text"`
-				} `json:"content"`
-			} `json:"message"`
-			Role    string `json:"role"`
-			Content []struct {
-				Type string `json:"type"`
-				Text string `json:"text"`
-			} `json:"content"`
+	head, _ := io.ReadAll(io.LimitReader(f, 64*1024))
+	if isEncryptedTranscript(head) {
+		// Only the transcript key opens it here; the gateway isn't asked
+		// while scanning
+		plain, err := readPlainTranscript(path)
+		if err != nil {
+			return "", ""
 		}
-		if json.Unmarshal(scanner.Bytes(), &entry) != nil {
+		head = plain[:min(len(plain), 64*1024)]
+	}
+	format := DetectTranscriptFormat(head)
+	msgs, _ := format.Parse(bytes.NewReader(head))
+	for _, msg := range msgs {
+		if msg.Role != "user" || msg.Text == "" {
 			continue
 		}
-
-		role := entry.Message.Role
-		content := entry.Message.Content
-		if role == "" {
-			role = entry.Role
-			content = entry.Content
+		text := msg.Text
+		if idx := strings.IndexByte(text, '\n'); idx > 0 {
+			text = text[:idx]
 		}
-
-		if role == "user" {
-			for _, c := range content {
-				if c.Type == "text" && c.Text != "" {
-					text := c.Text
-					if idx := strings.IndexByte(text, '\n'); idx > 0 {
-						text = text[:idx]
-					}
-					if len(text) > 200 {
-						text = text[:197] + "..."
-					}
-					return text
-				}
-			}
+		if len(text) > 200 {
+			text = text[:197] + "..."
 		}
+		return text, format.Name()
 	}
-	return ""
+	return "", format.Name()
 }
 
 // ReadTranscript reads a full transcript file and formats it for display.
@@ -832,306 +881,45 @@ func (c *Client) ReadTranscript(path string) (string, error) {
 
 // ReadTranscriptVerbose reads a transcript with the given verbose level.
 func (c *Client) ReadTranscriptVerbose(path string, verbose VerboseLevel) (string, error) {
-	f, err := os.Open(path)
+	msgs, err := c.ReadTranscriptMessages(path)
 	if err != nil {
 		return "", err
 	}
-	defer f.Close()
-
-	var msgs []HistoryMessage
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 256*1024), 256*1024)
-	
-	// Track pending tool calls from assistant messages to pair with toolResults
-	var pendingToolCalls []struct {
-		Name string
-		Args string
-	}
-	
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		var entry struct {
-			Type    string `json:"type"`
-			Message struct {
-				Role    string `json:"role"`
-				Content []struct {
-					Type      string          `json:"type"`
-					Text      string          `json:"text"`
-					Name      string          `json:"name,omitempty"`
-					Arguments json.RawMessage `json:"arguments,omitempty"`
-				} `json:"content"`
-				ToolName string `json:"toolName,omitempty"`
-				IsError  bool   `json:"isError,omitempty"`
-			} `json:"message"`
-			Role    string `json:"role"`
-			Content []struct {
-				Type      string          `json:"type"`
-				Text      string          `json:"text"`
-				Name      string          `json:"name,omitempty"`
-				Arguments json.RawMessage `json:"arguments,omitempty"`
-			} `json:"content"`
-			Model    string `json:"model,omitempty"`
-			ToolName string `json:"toolName,omitempty"`
-			IsError  bool   `json:"isError,omitempty"`
-		}
-		if json.Unmarshal(line, &entry) != nil {
-			continue
-		}
-
-		role := entry.Message.Role
-		content := entry.Message.Content
-		toolName := entry.Message.ToolName
-		isError := entry.Message.IsError
-		if role == "" {
-			role = entry.Role
-			content = entry.Content
-			toolName = entry.ToolName
-			isError = entry.IsError
-		}
-
-		if role == "" || (entry.Type != "" && entry.Type != "message") {
-			continue
-		}
-
-		// Handle assistant messages - extract tool calls from content
-		if role == "assistant" {
-			var text strings.Builder
-			for _, c := range content {
-				switch c.Type {
-				case "text":
-					if c.Text != "" {
-						if text.Len() > 0 {
-							text.WriteString("\n")
-						}
-						text.WriteString(c.Text)
-					}
-				case "toolCall", "tool_use":
-					// Store tool call for pairing with toolResult
-					args := extractToolArgsFromJSON(c.Arguments)
-					pendingToolCalls = append(pendingToolCalls, struct {
-						Name string
-						Args string
-					}{Name: c.Name, Args: args})
-				}
-			}
-			
-			msg := HistoryMessage{
-				Role:  role,
-				Model: entry.Model,
-				Text:  text.String(),
-			}
-			msgs = append(msgs, msg)
-			continue
-		}
-
-		// Handle toolResult - pair with pending tool call args
-		if role == "toolResult" || role == "tool" {
-			msg := HistoryMessage{
-				Role:      role,
-				Model:     entry.Model,
-				ToolName:  toolName,
-				ToolError: isError,
-			}
-			
-			// Extract result text from content
-			var text strings.Builder
-			for _, c := range content {
-				if c.Type == "text" && c.Text != "" {
-					if text.Len() > 0 {
-						text.WriteString("\n")
-					}
-					text.WriteString(c.Text)
-				}
-			}
-			msg.Text = text.String()
-			
-			// Pair with pending tool call args if available
-			if len(pendingToolCalls) > 0 {
-				msg.ToolArgs = pendingToolCalls[0].Args
-				// If toolName is empty, use the pending call's name
-				if msg.ToolName == "" {
-					msg.ToolName = pendingToolCalls[0].Name
-				}
-				pendingToolCalls = pendingToolCalls[1:]
-			} else {
-				msg.ToolArgs = extractToolArgs(line)
-			}
-			
-			msgs = append(msgs, msg)
-			continue
-		}
-
-		// Default handling for user and other roles
-		var text strings.Builder
-		for _, c := range content {
-			if c.Type == "text" && c.Text != "" {
-				if text.Len() > 0 {
-					text.WriteString("\n")
-				}
-				text.WriteString(c.Text)
-			}
-		}
+	return FormatHistory(msgs, verbose, false), nil
+}
 
-		msg := HistoryMessage{
-			Role:  role,
-			Model: entry.Model,
-			Text:  text.String(),
+// ReadTranscriptMessages parses a transcript file into HistoryMessage slices,
+// detecting its format.
+// Transcripts encrypted at rest that the transcript key can't open are
+// read through the gateway.
+func (c *Client) ReadTranscriptMessages(path string) ([]HistoryMessage, error) {
+	msgs, _, err := ParseTranscriptFile(path)
+	if errors.Is(err, ErrTranscriptEncrypted) {
+		plain, gerr := c.readTranscriptFromGateway(path)
+		if gerr != nil {
+			return nil, gerr
 		}
-		msgs = append(msgs, msg)
+		msgs, _, err = parseTranscript(plain)
 	}
-	return FormatHistory(msgs, verbose), nil
+	return msgs, err
 }
 
-// ReadTranscriptMessages parses a transcript file into HistoryMessage slices.
-func (c *Client) ReadTranscriptMessages(path string) ([]HistoryMessage, error) {
-	f, err := os.Open(path)
-	if err != nil {
-		return nil, err
+// ReadTranscriptTail returns the last n lines of a transcript file exactly
+// as written, and whether that is the whole file.
+func (c *Client) ReadTranscriptTail(path string, n int) (string, bool, error) {
+	raw, err := readPlainTranscript(path)
+	if errors.Is(err, ErrTranscriptEncrypted) {
+		raw, err = c.readTranscriptFromGateway(path)
 	}
-	defer f.Close()
-
-	var msgs []HistoryMessage
-	scanner := bufio.NewScanner(f)
-	scanner.Buffer(make([]byte, 256*1024), 256*1024)
-	
-	// Track pending tool calls from assistant messages to pair with toolResults
-	var pendingToolCalls []struct {
-		Name string
-		Args string
+	if err != nil {
+		return "", false, err
 	}
-	
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		var entry struct {
-			Type    string `json:"type"`
-			Message struct {
-				Role    string `json:"role"`
-				Content []struct {
-					Type      string          `json:"type"`
-					Text      string          `json:"text"`
-					Name      string          `json:"name,omitempty"`
-					Arguments json.RawMessage `json:"arguments,omitempty"`
-				} `json:"content"`
-				ToolName string `json:"toolName,omitempty"`
-				IsError  bool   `json:"isError,omitempty"`
-			} `json:"message"`
-			Role    string `json:"role"`
-			Content []struct {
-				Type      string          `json:"type"`
-				Text      string          `json:"text"`
-				Name      string          `json:"name,omitempty"`
-				Arguments json.RawMessage `json:"arguments,omitempty"`
-			} `json:"content"`
-			Model    string `json:"model,omitempty"`
-			ToolName string `json:"toolName,omitempty"`
-			IsError  bool   `json:"isError,omitempty"`
-		}
-		if json.Unmarshal(line, &entry) != nil {
-			continue
-		}
-
-		role := entry.Message.Role
-		content := entry.Message.Content
-		toolName := entry.Message.ToolName
-		isError := entry.Message.IsError
-		if role == "" {
-			role = entry.Role
-			content = entry.Content
-			toolName = entry.ToolName
-			isError = entry.IsError
-		}
-
-		if role == "" || (entry.Type != "" && entry.Type != "message") {
-			continue
-		}
-
-		// Handle assistant messages - extract tool calls from content
-		if role == "assistant" {
-			var text strings.Builder
-			for _, c := range content {
-				switch c.Type {
-				case "text":
-					if c.Text != "" {
-						if text.Len() > 0 {
-							text.WriteString("\n")
-						}
-						text.WriteString(c.Text)
-					}
-				case "toolCall", "tool_use":
-					// Store tool call for pairing with toolResult
-					args := extractToolArgsFromJSON(c.Arguments)
-					pendingToolCalls = append(pendingToolCalls, struct {
-						Name string
-						Args string
-					}{Name: c.Name, Args: args})
-				}
-			}
-			
-			msg := HistoryMessage{
-				Role:  role,
-				Model: entry.Model,
-				Text:  text.String(),
-			}
-			msgs = append(msgs, msg)
-			continue
-		}
-
-		// Handle toolResult - pair with pending tool call args
-		if role == "toolResult" || role == "tool" {
-			msg := HistoryMessage{
-				Role:      role,
-				Model:     entry.Model,
-				ToolName:  toolName,
-				ToolError: isError,
-			}
-			
-			// Extract result text from content
-			var text strings.Builder
-			for _, c := range content {
-				if c.Type == "text" && c.Text != "" {
-					if text.Len() > 0 {
-						text.WriteString("\n")
-					}
-					text.WriteString(c.Text)
-				}
-			}
-			msg.Text = text.String()
-			
-			// Pair with pending tool call args if available
-			if len(pendingToolCalls) > 0 {
-				msg.ToolArgs = pendingToolCalls[0].Args
-				// If toolName is empty, use the pending call's name
-				if msg.ToolName == "" {
-					msg.ToolName = pendingToolCalls[0].Name
-				}
-				pendingToolCalls = pendingToolCalls[1:]
-			} else {
-				msg.ToolArgs = extractToolArgs(line)
-			}
-			
-			msgs = append(msgs, msg)
-			continue
-		}
-
-		// Default handling for user and other roles
-		var text strings.Builder
-		for _, c := range content {
-			if c.Type == "text" && c.Text != "" {
-				if text.Len() > 0 {
-					text.WriteString("\n")
-				}
-				text.WriteString(c.Text)
-			}
-		}
-
-		msg := HistoryMessage{
-			Role:  role,
-			Model: entry.Model,
-			Text:  text.String(),
-		}
-		msgs = append(msgs, msg)
+	lines := strings.Split(strings.TrimRight(string(raw), "\n"), "\n")
+	complete := len(lines) <= n
+	if !complete {
+		lines = lines[len(lines)-n:]
 	}
-	return msgs, nil
+	return strings.Join(lines, "\n"), complete, nil
 }
 
 func homeDir() string {
@@ -1140,17 +928,21 @@ func homeDir() string {
 }
 
 // FetchGatewayHealth does a simple GET to the gateway root to check connectivity.
+// It is the heartbeat that keeps HealthStats current between other calls.
 func (c *Client) FetchGatewayHealth() (*GatewayHealth, error) {
 	start := time.Now()
-	resp, err := c.http.Get(c.cfg.GatewayURL + "/health")
+	status, err := c.tr.ping()
 	dur := time.Since(start)
 	if err != nil {
-		return nil, err
+		c.recordCall(dur, true)
+		c.recordTrace(TraceEntry{At: start, Tool: c.tr.pingName(), Duration: dur, Status: traceError(err), Failed: true})
+		return nil, classified(ErrKindNetwork, err)
 	}
-	defer resp.Body.Close()
+	c.recordCall(dur, status >= 500)
+	c.recordTrace(TraceEntry{At: start, Tool: c.tr.pingName(), Duration: dur, Status: strconv.Itoa(status), Failed: status != http.StatusOK})
 
 	h := &GatewayHealth{
-		OK:         resp.StatusCode == http.StatusOK,
+		OK:         status == http.StatusOK,
 		DurationMs: int(dur.Milliseconds()),
 		Ts:         time.Now().UnixMilli(),
 	}
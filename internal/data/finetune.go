@@ -0,0 +1,225 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// openAIExample is a chat fine-tuning example: {"messages": [...]}.
+type openAIExample struct {
+	Messages []openAIChat `json:"messages"`
+}
+
+type openAIChat struct {
+	Role       string           `json:"role"`
+	Content    *string          `json:"content"` // null on tool-call-only turns
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+// anthropicExample is a Messages API conversation: alternating user and
+// assistant turns, the system prompt apart.
+type anthropicExample struct {
+	System   string          `json:"system,omitempty"`
+	Messages []anthropicTurn `json:"messages"`
+}
+
+type anthropicTurn struct {
+	Role    string           `json:"role"`
+	Content []anthropicBlock `json:"content"`
+}
+
+type anthropicBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   string          `json:"content,omitempty"`
+	IsError   bool            `json:"is_error,omitempty"`
+}
+
+// toolInput returns a tool call's arguments as a JSON object: as called
+// when the transcript has them, otherwise the summary under "args".
+func toolInput(msg HistoryMessage) json.RawMessage {
+	var obj map[string]json.RawMessage
+	if json.Unmarshal(msg.ToolInput, &obj) == nil && obj != nil {
+		return msg.ToolInput
+	}
+	if msg.ToolArgs == "" {
+		return json.RawMessage("{}")
+	}
+	raw, _ := json.Marshal(map[string]string{"args": msg.ToolArgs})
+	return raw
+}
+
+// trainingTurns keeps the messages that make a training conversation: the
+// system prompt, user and assistant text (reasoning left out), and tool
+// results unless stripTools is set. Turns before the first user message
+// and after the last assistant one are dropped, so examples open with a
+// request and end on the answer being trained.
+func trainingTurns(msgs []HistoryMessage, stripTools bool) []HistoryMessage {
+	var kept []HistoryMessage
+	for _, msg := range msgs {
+		switch msg.Role {
+		case "system", "user":
+			if strings.TrimSpace(msg.Text) != "" {
+				kept = append(kept, msg)
+			}
+		case "assistant":
+			if strings.TrimSpace(msg.Text) != "" {
+				kept = append(kept, HistoryMessage{Role: "assistant", Text: msg.Text})
+			}
+		case "toolResult", "tool":
+			if !stripTools {
+				kept = append(kept, msg)
+			}
+		}
+	}
+	first := -1
+	last := -1
+	for i, msg := range kept {
+		if msg.Role == "user" && first < 0 {
+			first = i
+		}
+		if msg.Role == "assistant" {
+			last = i
+		}
+	}
+	if first < 0 || last < first {
+		return nil
+	}
+	var system []HistoryMessage
+	for _, msg := range kept[:first] {
+		if msg.Role == "system" {
+			system = append(system, msg)
+		}
+	}
+	return append(system, kept[first:last+1]...)
+}
+
+// OpenAIExample converts a transcript to an OpenAI chat fine-tuning
+// example. Tool calls become assistant tool_calls answered by tool
+// messages unless stripTools is set.
+func OpenAIExample(msgs []HistoryMessage, stripTools bool) ([]byte, error) {
+	var ex openAIExample
+	calls := 0
+	for _, msg := range trainingTurns(msgs, stripTools) {
+		if msg.Role != "toolResult" && msg.Role != "tool" {
+			// Text split only by stripped tool calls reads as one turn
+			if n := len(ex.Messages); n > 0 && ex.Messages[n-1].Role == msg.Role &&
+				ex.Messages[n-1].Content != nil && len(ex.Messages[n-1].ToolCalls) == 0 {
+				*ex.Messages[n-1].Content += "\n\n" + msg.Text
+				continue
+			}
+			text := msg.Text
+			ex.Messages = append(ex.Messages, openAIChat{Role: msg.Role, Content: &text})
+			continue
+		}
+		// A tool result belongs to a call made by the preceding assistant
+		// turn, or by one that said nothing but made calls
+		n := len(ex.Messages)
+		if n == 0 || ex.Messages[n-1].Role != "assistant" && ex.Messages[n-1].Role != "tool" {
+			ex.Messages = append(ex.Messages, openAIChat{Role: "assistant"})
+			n++
+		}
+		caller := n - 1
+		for caller > 0 && ex.Messages[caller].Role == "tool" {
+			caller--
+		}
+		calls++
+		call := openAIToolCall{ID: fmt.Sprintf("call_%d", calls), Type: "function"}
+		call.Function.Name = msg.ToolName
+		call.Function.Arguments = string(toolInput(msg))
+		ex.Messages[caller].ToolCalls = append(ex.Messages[caller].ToolCalls, call)
+		text := msg.Text
+		ex.Messages = append(ex.Messages, openAIChat{Role: "tool", Content: &text, ToolCallID: call.ID})
+	}
+	if len(ex.Messages) == 0 {
+		return nil, errNoTrainingTurns
+	}
+	return json.Marshal(ex)
+}
+
+// AnthropicExample converts a transcript to an Anthropic Messages API
+// conversation. Tool calls become tool_use blocks answered by tool_result
+// blocks unless stripTools is set; consecutive turns of one role are
+// merged, as the API requires them to alternate.
+func AnthropicExample(msgs []HistoryMessage, stripTools bool) ([]byte, error) {
+	var ex anthropicExample
+	add := func(role string, block anthropicBlock) {
+		n := len(ex.Messages)
+		if n > 0 && ex.Messages[n-1].Role == role {
+			ex.Messages[n-1].Content = append(ex.Messages[n-1].Content, block)
+			return
+		}
+		ex.Messages = append(ex.Messages, anthropicTurn{Role: role, Content: []anthropicBlock{block}})
+	}
+	calls := 0
+	for _, msg := range trainingTurns(msgs, stripTools) {
+		switch msg.Role {
+		case "system":
+			if ex.System != "" {
+				ex.System += "\n\n"
+			}
+			ex.System += msg.Text
+		case "user", "assistant":
+			add(msg.Role, anthropicBlock{Type: "text", Text: msg.Text})
+		default:
+			calls++
+			id := fmt.Sprintf("toolu_%d", calls)
+			add("assistant", anthropicBlock{Type: "tool_use", ID: id, Name: msg.ToolName, Input: toolInput(msg)})
+			add("user", anthropicBlock{Type: "tool_result", ToolUseID: id, Content: msg.Text, IsError: msg.ToolError})
+		}
+	}
+	if len(ex.Messages) == 0 {
+		return nil, errNoTrainingTurns
+	}
+	return json.Marshal(ex)
+}
+
+var errNoTrainingTurns = errors.New("no user request answered by the assistant to train on")
+
+// exportTraining appends run's transcript to a fine-tuning JSONL file as
+// one example.
+func exportTraining(e config.Exporter, run RunExport) (string, error) {
+	var line []byte
+	var err error
+	if e.Type == config.ExportOpenAI {
+		line, err = OpenAIExample(run.Messages, e.StripTools)
+	} else {
+		line, err = AnthropicExample(run.Messages, e.StripTools)
+	}
+	if err != nil {
+		return "", err
+	}
+	path := config.ExpandHome(e.Path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(append(line, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return path, err
+}
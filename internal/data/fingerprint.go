@@ -0,0 +1,25 @@
+package data
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// fingerprintNoise matches the parts of a command line that change from
+// one run of a program to the next: numbers such as ports and PIDs, and
+// hex IDs.
+var fingerprintNoise = regexp.MustCompile(`\b[0-9a-f]{8,}\b|\d+`)
+
+// ProcessFingerprint reduces a scanned command line to what stays the same
+// across runs of the program, so a process once marked as not an agent can
+// be recognized when it starts again: the executable's name without its
+// directory, and the arguments with numbers and IDs replaced by "#".
+func ProcessFingerprint(cmd string) string {
+	fields := strings.Fields(strings.ToLower(strings.TrimSuffix(cmd, "...")))
+	if len(fields) == 0 {
+		return ""
+	}
+	fields[0] = path.Base(strings.ReplaceAll(fields[0], `\`, "/"))
+	return fingerprintNoise.ReplaceAllString(strings.Join(fields, " "), "#")
+}
@@ -0,0 +1,119 @@
+package data
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/fakegateway"
+)
+
+// fixtureClient returns a client of a fake gateway replaying
+// testdata/gateway.jsonl.
+func fixtureClient(t *testing.T) *Client {
+	t.Helper()
+	exchanges, err := fakegateway.LoadFixtures(filepath.Join("testdata", "gateway.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(fakegateway.NewServer(exchanges))
+	t.Cleanup(srv.Close)
+	return NewClient(config.Config{GatewayURL: srv.URL, Transport: config.TransportHTTP})
+}
+
+// TestFetchSessionsFixture lists sessions through a stand-in for the
+// openclaw CLI, which FetchSessions reads instead of the gateway, printing
+// testdata/sessions.json.
+func TestFetchSessionsFixture(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the openclaw stand-in is a shell script")
+	}
+	fixture, err := filepath.Abs(filepath.Join("testdata", "sessions.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := t.TempDir()
+	script := "#!/bin/sh\ncat '" + fixture + "'\n"
+	if err := os.WriteFile(filepath.Join(bin, "openclaw"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	c := fixtureClient(t)
+	sessions, err := c.FetchSessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("got %d sessions, want 2", len(sessions))
+	}
+	s := sessions[1]
+	if s.Key != "agent:main:subagent:fix-flaky-test" || s.Label != "fix-flaky-test" || s.TotalTokens != 18000 {
+		t.Errorf("unexpected session %+v", s)
+	}
+
+	// The session's history comes from the gateway
+	msgs, err := c.FetchSessionMessages(s.Key, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	roles := make([]string, len(msgs))
+	for i, m := range msgs {
+		roles[i] = m.Role
+	}
+	if got := strings.Join(roles, " "); !strings.Contains(got, "user") || !strings.Contains(got, "toolResult") {
+		t.Errorf("roles %q, want a user message and a tool result", got)
+	}
+	if text := FormatHistory(msgs, VerboseFull, false); !strings.Contains(text, "Sorted the keys before comparing") {
+		t.Errorf("formatted history misses the last reply:\n%s", text)
+	}
+}
+
+func TestReadTranscriptMessagesFixtures(t *testing.T) {
+	c := fixtureClient(t)
+	for _, tc := range []struct {
+		file  string
+		first string // text of the first user message
+		last  string // text of the last assistant message
+	}{
+		{"openclaw.jsonl", "Summarize yesterday's deploy failures", "One failure: the api deploy timed out after 300s. Web deployed fine."},
+		{"claude-code.jsonl", "Rename Foo to Bar across the repo", "Renamed Foo to Bar in 2 files."},
+		{"openai.jsonl", "What changed in v1.4?", "v1.4 brings faster startup and Windows support."},
+	} {
+		t.Run(tc.file, func(t *testing.T) {
+			msgs, err := c.ReadTranscriptMessages(filepath.Join("testdata", "transcripts", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var first, last string
+			var tools int
+			for _, m := range msgs {
+				switch m.Role {
+				case "user":
+					if first == "" {
+						first = m.Text
+					}
+				case "assistant":
+					if m.Text != "" {
+						last = m.Text
+					}
+				case "toolResult", "tool":
+					tools++
+				}
+			}
+			if first != tc.first {
+				t.Errorf("first user message %q, want %q", first, tc.first)
+			}
+			if last != tc.last {
+				t.Errorf("last assistant message %q, want %q", last, tc.last)
+			}
+			if tools == 0 {
+				t.Error("no tool result parsed")
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// HealthLevel grades the gateway connection from recent call outcomes.
+type HealthLevel int
+
+const (
+	HealthUnknown  HealthLevel = iota // no calls recorded yet
+	HealthHealthy                     // fast and error-free
+	HealthDegraded                    // some errors or elevated latency
+	HealthSlow                        // high latency or frequent errors
+	HealthDown                        // calls are failing outright
+)
+
+func (l HealthLevel) String() string {
+	switch l {
+	case HealthHealthy:
+		return "healthy"
+	case HealthDegraded:
+		return "degraded"
+	case HealthSlow:
+		return "slow"
+	case HealthDown:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+// Health grading thresholds, applied to the calls in the rolling window.
+const (
+	healthWindowSize   = 50              // most recent calls considered
+	healthWindowAge    = 5 * time.Minute // older calls are ignored
+	degradedP95        = 500 * time.Millisecond
+	degradedErrorRate  = 0.05
+	slowP95            = 2 * time.Second
+	slowErrorRate      = 0.25
+	downConsecutiveErr = 3
+)
+
+// callSample is the outcome of one gateway call.
+type callSample struct {
+	At       time.Time
+	Duration time.Duration
+	Failed   bool
+}
+
+// HealthStats summarizes recent gateway calls.
+type HealthStats struct {
+	Level       HealthLevel
+	Calls       int
+	Errors      int
+	ErrorRate   float64
+	P50         time.Duration
+	P95         time.Duration
+	P99         time.Duration
+	Consecutive int       // failures since the last successful call
+	LastOK      time.Time // zero if no call has succeeded in the window
+}
+
+// recordCall adds a call outcome to the rolling window. failed should only
+// be set for failures that say something about the gateway itself
+// (unreachable, timeouts, 5xx), not for rejected tool arguments.
+func (c *Client) recordCall(d time.Duration, failed bool) {
+	c.callsMu.Lock()
+	defer c.callsMu.Unlock()
+	c.calls = append(c.calls, callSample{At: time.Now(), Duration: d, Failed: failed})
+	if len(c.calls) > healthWindowSize {
+		c.calls = c.calls[len(c.calls)-healthWindowSize:]
+	}
+}
+
+// HealthStats grades the gateway from the calls in the rolling window.
+func (c *Client) HealthStats() HealthStats {
+	c.callsMu.Lock()
+	defer c.callsMu.Unlock()
+
+	cutoff := time.Now().Add(-healthWindowAge)
+	var s HealthStats
+	var durations []time.Duration
+	for _, call := range c.calls {
+		if call.At.Before(cutoff) {
+			continue
+		}
+		s.Calls++
+		if call.Failed {
+			s.Errors++
+			s.Consecutive++
+			continue
+		}
+		s.Consecutive = 0
+		s.LastOK = call.At
+		durations = append(durations, call.Duration)
+	}
+	if s.Calls == 0 {
+		return s
+	}
+	s.ErrorRate = float64(s.Errors) / float64(s.Calls)
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	s.P50 = percentile(durations, 0.50)
+	s.P95 = percentile(durations, 0.95)
+	s.P99 = percentile(durations, 0.99)
+
+	switch {
+	case s.Consecutive >= downConsecutiveErr || len(durations) == 0:
+		s.Level = HealthDown
+	case s.P95 >= slowP95 || s.ErrorRate >= slowErrorRate:
+		s.Level = HealthSlow
+	case s.P95 >= degradedP95 || s.ErrorRate >= degradedErrorRate:
+		s.Level = HealthDegraded
+	default:
+		s.Level = HealthHealthy
+	}
+	return s
+}
+
+// percentile returns the nearest-rank percentile of sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(float64(len(sorted))*p+0.5) - 1
+	return sorted[min(max(i, 0), len(sorted)-1)]
+}
+
+// Report describes the stats and the thresholds behind the level.
+func (s HealthStats) Report() string {
+	if s.Calls == 0 {
+		return "No gateway calls recorded yet."
+	}
+	lastOK := "never"
+	if !s.LastOK.IsZero() {
+		lastOK = fmt.Sprintf("%s ago", time.Since(s.LastOK).Round(time.Second))
+	}
+	return fmt.Sprintf(`Gateway health: %s
+
+Calls (last %d, up to %s):  %d
+Errors:                      %d (%.0f%%)
+Consecutive failures:        %d
+Last success:                %s
+
+Latency p50:  %s
+Latency p95:  %s
+Latency p99:  %s
+
+Levels:
+  degraded  p95 ≥ %s or errors ≥ %.0f%%
+  slow      p95 ≥ %s or errors ≥ %.0f%%
+  down      %d failures in a row, or no successful call
+`,
+		s.Level,
+		healthWindowSize, healthWindowAge, s.Calls,
+		s.Errors, s.ErrorRate*100,
+		s.Consecutive,
+		lastOK,
+		s.P50.Round(time.Millisecond), s.P95.Round(time.Millisecond), s.P99.Round(time.Millisecond),
+		degradedP95, degradedErrorRate*100,
+		slowP95, slowErrorRate*100,
+		downConsecutiveErr)
+}
@@ -0,0 +1,65 @@
+package data
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// labelWords is how many meaningful prompt words make up a derived label.
+const labelWords = 4
+
+// maxLabelLen caps the length of a derived label.
+const maxLabelLen = 40
+
+// labelStopWords are words too common to say anything about a task.
+var labelStopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "can": true, "could": true, "do": true, "for": true,
+	"from": true, "i": true, "in": true, "into": true, "is": true, "it": true,
+	"its": true, "me": true, "my": true, "of": true, "on": true, "or": true,
+	"our": true, "please": true, "so": true, "that": true, "the": true,
+	"then": true, "this": true, "to": true, "us": true, "we": true,
+	"with": true, "would": true, "you": true, "your": true,
+}
+
+// LabelFromPrompt derives a short slug label from the first meaningful
+// words of a prompt, e.g. "Please fix the flaky test in pkg/foo" becomes
+// "fix-flaky-test-pkg". It returns "" when the prompt has no usable words.
+func LabelFromPrompt(prompt string) string {
+	var words []string
+	for _, field := range strings.FieldsFunc(strings.ToLower(prompt), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if labelStopWords[field] {
+			continue
+		}
+		words = append(words, field)
+		if len(words) == labelWords {
+			break
+		}
+	}
+	label := strings.Join(words, "-")
+	for len(label) > maxLabelLen {
+		cut := strings.LastIndexByte(label[:maxLabelLen], '-')
+		if cut <= 0 {
+			label = label[:maxLabelLen]
+			break
+		}
+		label = label[:cut]
+	}
+	return label
+}
+
+// UniqueLabel returns label, or label with the lowest "-2", "-3", ...
+// suffix that no name in taken uses.
+func UniqueLabel(label string, taken map[string]bool) string {
+	if !taken[label] {
+		return label
+	}
+	for n := 2; ; n++ {
+		if candidate := label + "-" + strconv.Itoa(n); !taken[candidate] {
+			return candidate
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package data
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LockTTL is how long a session lock lasts unless its holder renews it, so
+// a Commander that exits without releasing its locks doesn't hold them
+// forever.
+const LockTTL = 30 * time.Minute
+
+// SessionLock is an advisory claim by an operator on a session. Locks are
+// files in a directory, which operators on several machines can share.
+type SessionLock struct {
+	Key      string `json:"key"`
+	Operator string `json:"operator"`
+	PID      int    `json:"pid"`
+	At       int64  `json:"at"` // Unix ms, renewed while held
+}
+
+// Expired reports whether the lock is past LockTTL at now.
+func (l SessionLock) Expired(now time.Time) bool {
+	return now.Sub(time.UnixMilli(l.At)) > LockTTL
+}
+
+// LockedError is returned by ClaimLock when another operator holds the
+// session.
+type LockedError struct{ Lock SessionLock }
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("%s is being handled by %s", e.Lock.Key, e.Lock.Operator)
+}
+
+// lockPath is the file of the lock on session key. Keys contain colons,
+// which not every filesystem allows, so the file is named by a hash.
+func lockPath(dir, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(dir, fmt.Sprintf("%x.json", sum[:8]))
+}
+
+// ReadLocks returns the unexpired locks in dir by session key. A missing
+// directory holds no locks.
+func ReadLocks(dir string) (map[string]SessionLock, error) {
+	locks := map[string]SessionLock{}
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return locks, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			continue // released while listing
+		}
+		var l SessionLock
+		if json.Unmarshal(raw, &l) != nil || l.Key == "" || l.Expired(now) {
+			continue
+		}
+		locks[l.Key] = l
+	}
+	return locks, nil
+}
+
+// ClaimLock locks session key for operator, or renews the operator's lock.
+// A lock another operator holds is a *LockedError unless force is set. A
+// free session is claimed by creating the lock file exclusively, so of two
+// operators claiming it at once only one gets it; an existing lock is only
+// replaced when it is the operator's own, has expired, or force is set.
+func ClaimLock(dir, key, operator string, force bool) (SessionLock, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return SessionLock{}, err
+	}
+	path := lockPath(dir, key)
+	l := SessionLock{Key: key, Operator: operator, PID: os.Getpid(), At: time.Now().UnixMilli()}
+	raw, err := json.Marshal(l)
+	if err != nil {
+		return SessionLock{}, err
+	}
+	for {
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if err == nil {
+			_, err = f.Write(raw)
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return SessionLock{}, err
+			}
+			return l, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return SessionLock{}, err
+		}
+		held, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue // released meanwhile; try again
+		}
+		if err != nil {
+			return SessionLock{}, err
+		}
+		var h SessionLock
+		if json.Unmarshal(held, &h) != nil {
+			// Another claim may be writing it; an old unreadable lock is stale
+			if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) < 5*time.Second && !force {
+				return SessionLock{}, fmt.Errorf("%s is being claimed; try again", key)
+			}
+		} else if h.Operator != operator && !h.Expired(time.Now()) && !force {
+			return SessionLock{}, &LockedError{h}
+		}
+		break
+	}
+	// Replacing a lock: write then rename, so readers never see half a lock
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmp, raw, 0o644); err != nil {
+		return SessionLock{}, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return SessionLock{}, err
+	}
+	return l, nil
+}
+
+// ReleaseLock removes operator's lock on session key. Another operator's
+// lock is left alone.
+func ReleaseLock(dir, key, operator string) error {
+	path := lockPath(dir, key)
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var held SessionLock
+	if json.Unmarshal(raw, &held) == nil && held.Operator != operator {
+		return nil
+	}
+	return os.Remove(path)
+}
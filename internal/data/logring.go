@@ -0,0 +1,98 @@
+package data
+
+import "strings"
+
+// LogGap marks where a LogRing missed output: more was written between two
+// fetches than one fetch returns.
+const LogGap = "⋯ (output missed between fetches)"
+
+// LogRing keeps the last lines of a log that is only ever fetched as its
+// most recent window, so lines that scroll out of the window are kept until
+// the ring is full.
+type LogRing struct {
+	buf     []string
+	head    int // index of the oldest line
+	n       int
+	dropped int // lines pushed out of a full ring
+}
+
+// NewLogRing returns a ring holding at most capacity lines.
+func NewLogRing(capacity int) *LogRing {
+	return &LogRing{buf: make([]string, max(1, capacity))}
+}
+
+// Len is the number of lines held.
+func (r *LogRing) Len() int { return r.n }
+
+// Dropped is the number of lines pushed out because the ring was full.
+func (r *LogRing) Dropped() int { return r.dropped }
+
+// Lines returns the lines held, oldest first.
+func (r *LogRing) Lines() []string {
+	lines := make([]string, r.n)
+	for i := range lines {
+		lines[i] = r.at(i)
+	}
+	return lines
+}
+
+func (r *LogRing) at(i int) string {
+	return r.buf[(r.head+i)%len(r.buf)]
+}
+
+func (r *LogRing) push(line string) {
+	if r.n < len(r.buf) {
+		r.buf[(r.head+r.n)%len(r.buf)] = line
+		r.n++
+		return
+	}
+	r.buf[r.head] = line
+	r.head = (r.head + 1) % len(r.buf)
+	r.dropped++
+}
+
+// Merge adds the lines of a fetched window that the ring doesn't have yet.
+// The window is lined up with the ring by finding where the lines the ring
+// ends with end in the window; when they can't be found, more was written
+// between fetches than the window holds, and the gap is marked.
+func (r *LogRing) Merge(window string) {
+	if window == "" {
+		return
+	}
+	lines := strings.Split(strings.TrimRight(window, "\n"), "\n")
+	if r.n == 0 {
+		for _, l := range lines {
+			r.push(l)
+		}
+		return
+	}
+	for end := len(lines); end > 0; end-- {
+		if r.endsWith(lines[:end]) {
+			for _, l := range lines[end:] {
+				r.push(l)
+			}
+			return
+		}
+	}
+	r.push(LogGap)
+	for _, l := range lines {
+		r.push(l)
+	}
+}
+
+// endsWith reports whether the ring's last lines match the end of lines,
+// comparing as many lines as both have back to the last gap, since the
+// window holds the output the ring missed there.
+func (r *LogRing) endsWith(lines []string) bool {
+	k := min(len(lines), r.n)
+	for i := 1; i <= k; i++ {
+		line := r.at(r.n - i)
+		if line == LogGap {
+			return true
+		}
+		if line != lines[len(lines)-i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package data
+
+import "time"
+
+// ToolLoop is a tool call an agent repeated with identical arguments.
+type ToolLoop struct {
+	Tool  string
+	Args  string
+	Count int
+}
+
+// DetectLoop finds the tool call repeated most often with identical
+// arguments among the calls in msgs made within window of the last one;
+// calls without a timestamp count as within it. ok is false when no call
+// was made at least repeats times.
+func DetectLoop(msgs []HistoryMessage, window time.Duration, repeats int) (loop ToolLoop, ok bool) {
+	// Gateway history has a toolUse per call; transcripts only results
+	role := "toolResult"
+	for _, msg := range msgs {
+		if msg.Role == "toolUse" {
+			role = "toolUse"
+			break
+		}
+	}
+	var last int64
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role == role && msgs[i].Timestamp > 0 {
+			last = msgs[i].Timestamp
+			break
+		}
+	}
+	type call struct{ tool, args string }
+	counts := make(map[call]int)
+	for _, msg := range msgs {
+		if msg.Role != role || msg.ToolName == "" {
+			continue
+		}
+		if msg.Timestamp > 0 && last-msg.Timestamp > window.Milliseconds() {
+			continue
+		}
+		c := call{msg.ToolName, msg.ToolArgs}
+		counts[c]++
+		if n := counts[c]; n > loop.Count {
+			loop = ToolLoop{Tool: c.tool, Args: c.args, Count: n}
+		}
+	}
+	return loop, loop.Count >= repeats
+}
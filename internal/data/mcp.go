@@ -0,0 +1,327 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// mcpProtocolVersion is the MCP revision requested in the handshake.
+const mcpProtocolVersion = "2025-03-26"
+
+// mcpTransport talks to an MCP server over streamable HTTP: JSON-RPC 2.0
+// requests POSTed to the gateway URL, answered with JSON or an SSE stream.
+// The initialize handshake runs before the first request and again when
+// the server forgets the session.
+type mcpTransport struct {
+	cfg  config.Config
+	http *http.Client
+
+	mu      sync.Mutex
+	ready   bool
+	session string // Mcp-Session-Id assigned by the server, if any
+	nextID  int
+}
+
+// rpcError is a JSON-RPC error object.
+type rpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC response; Method is set on server requests and
+// notifications interleaved in an SSE stream.
+type rpcResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// mcpToolResult is the result of tools/call.
+type mcpToolResult struct {
+	Content           []ContentItem   `json:"content"`
+	StructuredContent json.RawMessage `json:"structuredContent"`
+	IsError           bool            `json:"isError"`
+}
+
+func (t *mcpTransport) call(tool string, args []byte) (int, []byte, error) {
+	if len(args) == 0 || string(args) == "null" {
+		args = []byte("{}")
+	}
+	params, err := json.Marshal(struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}{tool, args})
+	if err != nil {
+		return 0, nil, fmt.Errorf("marshal request: %w", err)
+	}
+	status, resp, body, err := t.request("tools/call", params)
+	if err != nil || resp == nil {
+		return status, body, err
+	}
+	if resp.Error != nil {
+		return rpcStatus(resp.Error.Code), rpcErrorBody(resp.Error), nil
+	}
+
+	// Rewrap the result in the /tools/invoke envelope; structured content
+	// takes the place of the gateway's details.
+	var result mcpToolResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return status, nil, classified(ErrKindParse, fmt.Errorf("parse tools/call result: %w", err))
+	}
+	if result.IsError {
+		var msg []string
+		for _, c := range result.Content {
+			msg = append(msg, c.Text)
+		}
+		body, _ := json.Marshal(map[string]interface{}{"ok": false, "error": strings.Join(msg, "\n")})
+		return status, body, nil
+	}
+	env := map[string]interface{}{"content": result.Content}
+	if len(result.StructuredContent) > 0 {
+		env["details"] = result.StructuredContent
+	}
+	body, err = json.Marshal(map[string]interface{}{"ok": true, "result": env})
+	return status, body, err
+}
+
+func (t *mcpTransport) ping() (int, error) {
+	status, resp, _, err := t.request("ping", nil)
+	if err == nil && resp != nil && resp.Error != nil {
+		return rpcStatus(resp.Error.Code), nil
+	}
+	return status, err
+}
+
+func (t *mcpTransport) pingName() string { return "MCP ping" }
+
+func (t *mcpTransport) listTools() ([]ToolInfo, error) {
+	var tools []ToolInfo
+	var cursor string
+	for {
+		var params []byte
+		if cursor != "" {
+			params, _ = json.Marshal(map[string]string{"cursor": cursor})
+		}
+		status, resp, body, err := t.request("tools/list", params)
+		switch {
+		case err != nil:
+			return nil, err
+		case resp == nil:
+			return nil, parseAPIError("tools/list", status, body)
+		case resp.Error != nil:
+			return nil, fmt.Errorf("tools/list: %s", resp.Error.Message)
+		}
+		var page struct {
+			Tools []struct {
+				Name        string          `json:"name"`
+				Description string          `json:"description"`
+				InputSchema json.RawMessage `json:"inputSchema"`
+			} `json:"tools"`
+			NextCursor string `json:"nextCursor"`
+		}
+		if err := json.Unmarshal(resp.Result, &page); err != nil {
+			return nil, classified(ErrKindParse, fmt.Errorf("parse tools/list result: %w", err))
+		}
+		for _, tool := range page.Tools {
+			tools = append(tools, ToolInfo{Name: tool.Name, Description: tool.Description, Params: parseToolSchema(tool.InputSchema)})
+		}
+		if page.NextCursor == "" {
+			return tools, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// request sends one JSON-RPC request, running the handshake first if
+// needed. A nil response with a nil error means the server answered with
+// a non-200 status; body then holds what it sent.
+func (t *mcpTransport) request(method string, params []byte) (int, *rpcResponse, []byte, error) {
+	if err := t.initialize(); err != nil {
+		return 0, nil, nil, err
+	}
+	status, resp, body, err := t.post(method, params)
+	if status == http.StatusNotFound && t.sessionID() != "" {
+		// The server dropped our session; start a new one and retry once.
+		t.mu.Lock()
+		t.ready, t.session = false, ""
+		t.mu.Unlock()
+		if err := t.initialize(); err != nil {
+			return 0, nil, nil, err
+		}
+		status, resp, body, err = t.post(method, params)
+	}
+	return status, resp, body, err
+}
+
+// initialize runs the MCP handshake unless it already succeeded.
+func (t *mcpTransport) initialize() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.ready {
+		return nil
+	}
+	params, _ := json.Marshal(map[string]interface{}{
+		"protocolVersion": mcpProtocolVersion,
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]string{"name": "openclaw-commander", "version": "1"},
+	})
+	t.nextID++
+	status, resp, body, session, err := t.send("initialize", params, t.nextID, "")
+	switch {
+	case err != nil:
+		return err
+	case resp == nil:
+		return parseAPIError("initialize", status, body)
+	case resp.Error != nil:
+		return fmt.Errorf("mcp initialize: %s", resp.Error.Message)
+	}
+	if _, _, _, _, err := t.send("notifications/initialized", nil, 0, session); err != nil {
+		return err
+	}
+	t.session, t.ready = session, true
+	return nil
+}
+
+func (t *mcpTransport) sessionID() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.session
+}
+
+// post sends a request within the current session.
+func (t *mcpTransport) post(method string, params []byte) (int, *rpcResponse, []byte, error) {
+	t.mu.Lock()
+	t.nextID++
+	id, session := t.nextID, t.session
+	t.mu.Unlock()
+	status, resp, body, _, err := t.send(method, params, id, session)
+	return status, resp, body, err
+}
+
+// send POSTs a JSON-RPC request, or a notification when id is 0, and
+// returns the response, the raw body of non-200 replies, and the session
+// ID the server assigned.
+func (t *mcpTransport) send(method string, params []byte, id int, session string) (int, *rpcResponse, []byte, string, error) {
+	msg := map[string]interface{}{"jsonrpc": "2.0", "method": method}
+	if len(params) > 0 {
+		msg["params"] = json.RawMessage(params)
+	}
+	if id != 0 {
+		msg["id"] = id
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return 0, nil, nil, "", fmt.Errorf("marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest("POST", t.cfg.GatewayURL, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, nil, "", fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/json, text/event-stream")
+	httpReq.Header.Set("MCP-Protocol-Version", mcpProtocolVersion)
+	if t.cfg.Token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+t.cfg.Token)
+	}
+	if session != "" {
+		httpReq.Header.Set("Mcp-Session-Id", session)
+	}
+	resp, err := t.http.Do(httpReq)
+	if err != nil {
+		return 0, nil, nil, "", fmt.Errorf("gateway request: %w", err)
+	}
+	defer resp.Body.Close()
+	newSession := resp.Header.Get("Mcp-Session-Id")
+
+	if id == 0 {
+		io.Copy(io.Discard, resp.Body)
+		if resp.StatusCode >= 300 {
+			return resp.StatusCode, nil, nil, newSession, fmt.Errorf("%s: HTTP %d", method, resp.StatusCode)
+		}
+		return resp.StatusCode, nil, nil, newSession, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		data, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return resp.StatusCode, nil, nil, newSession, fmt.Errorf("read response: %w", err)
+		}
+		return resp.StatusCode, nil, data, newSession, nil
+	}
+
+	var rpc *rpcResponse
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		rpc, err = readSSEResponse(resp.Body, id)
+	} else {
+		var data []byte
+		if data, err = io.ReadAll(resp.Body); err == nil {
+			rpc = new(rpcResponse)
+			if jerr := json.Unmarshal(data, rpc); jerr != nil {
+				err = classified(ErrKindParse, fmt.Errorf("parse %s response: %w", method, jerr))
+			}
+		}
+	}
+	if err != nil {
+		return resp.StatusCode, nil, nil, newSession, err
+	}
+	return resp.StatusCode, rpc, nil, newSession, nil
+}
+
+// readSSEResponse reads an SSE stream until the JSON-RPC response with id
+// arrives, skipping server notifications and requests sent before it.
+func readSSEResponse(r io.Reader, id int) (*rpcResponse, error) {
+	want := fmt.Sprint(id)
+	events := newSSEReader(r)
+	for {
+		_, data, err := events.next()
+		if err == io.EOF {
+			return nil, classified(ErrKindParse, fmt.Errorf("event stream ended without a response to request %s", want))
+		}
+		if err != nil {
+			return nil, err
+		}
+		var msg rpcResponse
+		if json.Unmarshal([]byte(data), &msg) != nil {
+			continue
+		}
+		if msg.Method != "" || strings.Trim(string(msg.ID), `"`) != want {
+			continue
+		}
+		return &msg, nil
+	}
+}
+
+// rpcStatus maps a JSON-RPC error code to the HTTP status the
+// /tools/invoke gateway would have answered with.
+func rpcStatus(code int) int {
+	switch code {
+	case -32601: // method not found
+		return http.StatusNotFound
+	case -32600, -32602: // invalid request, invalid params
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// rpcErrorBody renders e in the gateway's error shape for parseAPIError.
+func rpcErrorBody(e *rpcError) []byte {
+	body, _ := json.Marshal(map[string]interface{}{
+		"ok": false,
+		"error": map[string]interface{}{
+			"code":    fmt.Sprint(e.Code),
+			"message": e.Message,
+			"details": e.Data,
+		},
+	})
+	return body
+}
@@ -0,0 +1,77 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/atotto/clipboard"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// DefaultImagePathPattern matches paths to images and screenshots that
+// agents mention in tool output, including Windows paths such as
+// C:\shots\a.png.
+const DefaultImagePathPattern = `(?i)(?:(?:~|\.{1,2})?[/\\]|[a-z]:[/\\])[^\s"'<>()\[\]{}]+\.(?:png|jpe?g|gif|webp|bmp|svg)`
+
+// FindPaths returns the distinct paths matched by re in the tool calls and
+// results of msgs, in order of last appearance (the most recent last).
+func FindPaths(msgs []HistoryMessage, re *regexp.Regexp) []string {
+	var found []string
+	for _, msg := range msgs {
+		if msg.Role != "toolResult" && msg.Role != "toolUse" && msg.Role != "tool" {
+			continue
+		}
+		found = append(found, re.FindAllString(msg.ToolArgs, -1)...)
+		found = append(found, re.FindAllString(msg.Text, -1)...)
+	}
+	seen := make(map[string]bool, len(found))
+	var out []string
+	for i := len(found) - 1; i >= 0; i-- {
+		p := strings.TrimRight(found[i], ".,;:")
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+	return out
+}
+
+// OpenFile launches path with the desktop's default application
+// (open on macOS, xdg-open elsewhere) without waiting for it to exit.
+func OpenFile(path string) error {
+	path = config.ExpandHome(path)
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("cmd", "/c", "start", "", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("open %s: %w", path, err)
+	}
+	// Reap the launcher in the background
+	go cmd.Wait()
+	return nil
+}
+
+// CopyToClipboard places text on the system clipboard.
+func CopyToClipboard(text string) error {
+	if clipboard.Unsupported {
+		return fmt.Errorf("no clipboard utility found (install xclip, xsel, or wl-clipboard)")
+	}
+	return clipboard.WriteAll(text)
+}
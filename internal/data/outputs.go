@@ -0,0 +1,104 @@
+package data
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RunOutputs is what a run produced: the files it wrote or edited, the links
+// it reported, and its final answer.
+type RunOutputs struct {
+	Files  []string
+	Links  []string
+	Answer string
+}
+
+// Empty reports whether nothing was collected.
+func (o RunOutputs) Empty() bool {
+	return len(o.Files) == 0 && len(o.Links) == 0 && o.Answer == ""
+}
+
+// fileWriteTools are the tools whose path argument is a file the run
+// produced, across OpenClaw, Claude Code, and OpenAI-style agents.
+var fileWriteTools = map[string]bool{
+	"write": true, "file_write": true, "edit": true, "file_edit": true,
+	"multiedit": true, "notebookedit": true, "create_file": true,
+	"str_replace_editor": true, "str_replace_based_edit_tool": true,
+}
+
+// linkOutputTools are the tools whose output can contain links the run
+// produced, such as the URL of a pull request opened from the shell.
+var linkOutputTools = map[string]bool{"exec": true, "bash": true, "shell": true}
+
+var outputLinkPattern = regexp.MustCompile(`https?://[^\s"'<>()\[\]{}]+`)
+
+// CollectOutputs gathers a run's outputs from its messages: paths of
+// successful file writes and edits, links in assistant replies and shell
+// output, and the last assistant reply as the final answer. Files and
+// links are listed once, in order of first appearance.
+func CollectOutputs(msgs []HistoryMessage) RunOutputs {
+	var out RunOutputs
+	seenFiles := make(map[string]bool)
+	seenLinks := make(map[string]bool)
+	addLinks := func(text string) {
+		for _, link := range outputLinkPattern.FindAllString(text, -1) {
+			link = strings.TrimRight(link, ".,;:!?`*")
+			if !seenLinks[link] {
+				seenLinks[link] = true
+				out.Links = append(out.Links, link)
+			}
+		}
+	}
+	for _, msg := range msgs {
+		switch msg.Role {
+		case "assistant":
+			if text := strings.TrimSpace(msg.Text); text != "" {
+				out.Answer = text
+				addLinks(text)
+			}
+		case "toolResult", "toolUse", "tool":
+			tool := strings.ToLower(msg.ToolName)
+			if msg.ToolError {
+				continue
+			}
+			if fileWriteTools[tool] {
+				if path := extractArgValue(msg.ToolArgs, "file_path", "path"); path != "" && !seenFiles[path] {
+					seenFiles[path] = true
+					out.Files = append(out.Files, path)
+				}
+			}
+			if linkOutputTools[tool] {
+				addLinks(msg.Text)
+			}
+		}
+	}
+	return out
+}
+
+// FormatOutputs renders outputs for the log panel.
+func FormatOutputs(o RunOutputs) string {
+	if o.Empty() {
+		return "No outputs found: the run wrote no files, reported no links, and gave no final answer.\n"
+	}
+	var b strings.Builder
+	if len(o.Files) > 0 {
+		fmt.Fprintf(&b, "─── FILES (%d) ───\n", len(o.Files))
+		for _, f := range o.Files {
+			b.WriteString("  " + f + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if len(o.Links) > 0 {
+		fmt.Fprintf(&b, "─── LINKS (%d) ───\n", len(o.Links))
+		for _, l := range o.Links {
+			b.WriteString("  " + l + "\n")
+		}
+		b.WriteString("\n")
+	}
+	if o.Answer != "" {
+		b.WriteString("─── FINAL ANSWER ───\n")
+		b.WriteString(o.Answer + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,129 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JSONMaxDepth is how deeply nested JSON in tool output is laid out; deeper
+// objects and arrays are collapsed to a count of their members.
+const JSONMaxDepth = 6
+
+// PrettyJSON lays out text that is a single JSON object or array one member
+// per line, keys in their original order. It reports false for anything
+// else, which is shown as it is.
+func PrettyJSON(text string, maxDepth int) (string, bool) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') || !json.Valid([]byte(trimmed)) {
+		return "", false
+	}
+	dec := json.NewDecoder(strings.NewReader(trimmed))
+	dec.UseNumber()
+	var b bytes.Buffer
+	if err := writeJSONValue(&b, dec, 0, maxDepth); err != nil {
+		return "", false
+	}
+	return b.String(), true
+}
+
+// writeJSONValue writes the next value from dec, indented for depth.
+func writeJSONValue(b *bytes.Buffer, dec *json.Decoder, depth, maxDepth int) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		return writeJSONToken(b, tok)
+	}
+	open, end := string(delim), "]"
+	if delim == '{' {
+		end = "}"
+	}
+	if depth >= maxDepth {
+		n, err := skipJSONMembers(dec)
+		if err != nil {
+			return err
+		}
+		unit := "item"
+		if delim == '{' {
+			n, unit = n/2, "key" // keys and values both count
+		}
+		if n != 1 {
+			unit += "s"
+		}
+		fmt.Fprintf(b, "%s… %d %s%s", open, n, unit, end)
+		return nil
+	}
+	if !dec.More() {
+		dec.Token() // the closing delimiter
+		b.WriteString(open + end)
+		return nil
+	}
+	indent := strings.Repeat("  ", depth+1)
+	b.WriteString(open + "\n")
+	for first := true; dec.More(); first = false {
+		if !first {
+			b.WriteString(",\n")
+		}
+		b.WriteString(indent)
+		if delim == '{' {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if err := writeJSONToken(b, key); err != nil {
+				return err
+			}
+			b.WriteString(": ")
+		}
+		if err := writeJSONValue(b, dec, depth+1, maxDepth); err != nil {
+			return err
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	b.WriteString("\n" + strings.Repeat("  ", depth) + end)
+	return nil
+}
+
+// writeJSONToken writes a scalar as JSON, leaving <, >, and & as they are.
+func writeJSONToken(b *bytes.Buffer, tok json.Token) error {
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(tok); err != nil {
+		return err
+	}
+	b.Truncate(b.Len() - 1) // Encode ends with a newline
+	return nil
+}
+
+// skipJSONMembers consumes the rest of an object or array whose opening
+// delimiter was just read, returning how many values it held at its top
+// level, keys included.
+func skipJSONMembers(dec *json.Decoder) (int, error) {
+	n := 0
+	for depth := 1; depth > 0; {
+		if depth == 1 && dec.More() {
+			n++
+		}
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return n, io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return n, err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return n, nil
+}
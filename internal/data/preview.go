@@ -0,0 +1,71 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// PreviewMaxBytes caps how much of a file ReadFilePreview returns.
+const PreviewMaxBytes = 64 << 10
+
+// ReadFilePreview returns the start of the file at path, at most
+// PreviewMaxBytes of it. The file is read locally when it exists on this
+// machine, otherwise through the gateway's read tool, as agents usually
+// write on the gateway host. truncated is set when there is more.
+func (c *Client) ReadFilePreview(path string) (content string, truncated bool, err error) {
+	f, err := os.Open(config.ExpandHome(path))
+	if err == nil {
+		defer f.Close()
+		buf, err := io.ReadAll(io.LimitReader(f, PreviewMaxBytes+1))
+		if err != nil {
+			return "", false, err
+		}
+		return previewText(string(buf))
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return "", false, err
+	}
+
+	body, err := c.invoke(toolRequest{Tool: "read", Args: map[string]interface{}{"path": path}})
+	if err != nil {
+		return "", false, err
+	}
+	resp, err := decodeResponse("read", body)
+	if err != nil {
+		return "", false, err
+	}
+	if apiErr := resultError("read", resp.Result); apiErr != nil {
+		return "", false, apiErr
+	}
+	var result TextResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return "", false, classified(ErrKindParse, fmt.Errorf("parse read result: %w", err))
+	}
+	var sb strings.Builder
+	for _, item := range result.Content {
+		if item.Type == "text" {
+			sb.WriteString(item.Text)
+		}
+	}
+	return previewText(sb.String())
+}
+
+// previewText cuts s to PreviewMaxBytes and normalizes its line endings.
+func previewText(s string) (string, bool, error) {
+	truncated := len(s) > PreviewMaxBytes
+	if truncated {
+		s = s[:PreviewMaxBytes]
+	}
+	if strings.IndexByte(s, 0) >= 0 {
+		return "", false, errors.New("binary file")
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return StripANSI(s), truncated, nil
+}
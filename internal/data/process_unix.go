@@ -0,0 +1,125 @@
+//go:build !windows
+
+package data
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// scanProcesses lists agent processes from ps along with their resource use.
+func scanProcesses() ([]Process, map[int]psUsage, error) {
+	out, err := exec.Command("ps", "axo", "pid,etime,time,rss,command").Output()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var procs []Process
+	usage := make(map[int]psUsage)
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if !relevantProcess(line) || strings.HasPrefix(line, "PID") || strings.Contains(line, "ps axo") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 5 {
+			continue
+		}
+
+		pid := fields[0]
+		n, _ := strconv.Atoi(pid)
+		usage[n] = parseUsage(fields[2], fields[3])
+		procs = append(procs, Process{
+			SessionName: "pid:" + pid,
+			Status:      "running",
+			Runtime:     fields[1],
+			Command:     truncateCommand(strings.Join(fields[4:], " ")),
+			PID:         n,
+		})
+	}
+	return procs, usage, nil
+}
+
+// sampleUsage reads cumulative CPU time and RSS for the given PIDs.
+func sampleUsage(pids []int) map[int]psUsage {
+	if len(pids) == 0 {
+		return nil
+	}
+	list := make([]string, len(pids))
+	for i, pid := range pids {
+		list[i] = strconv.Itoa(pid)
+	}
+	out, err := exec.Command("ps", "-o", "pid=,time=,rss=", "-p", strings.Join(list, ",")).Output()
+	if err != nil && len(out) == 0 {
+		return nil
+	}
+	usage := make(map[int]psUsage, len(pids))
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		usage[pid] = parseUsage(fields[1], fields[2])
+	}
+	return usage
+}
+
+// signalPID sends signal to pid, and first to its descendants with children
+// set.
+func signalPID(pid int, sig string, signal syscall.Signal, children bool) error {
+	targets := []int{pid}
+	if children {
+		targets = append(descendants(pid), pid)
+	}
+	for _, t := range targets {
+		proc, err := os.FindProcess(t)
+		if err != nil {
+			return err
+		}
+		// A child may exit on its own between listing and signaling
+		if err := proc.Signal(signal); err != nil && t == pid {
+			return fmt.Errorf("SIG%s %d: %w", sig, t, err)
+		}
+	}
+	return nil
+}
+
+// descendants returns the process IDs below pid, deepest first.
+func descendants(pid int) []int {
+	out, err := exec.Command("ps", "-A", "-o", "pid=,ppid=").Output()
+	if err != nil {
+		return nil
+	}
+	kids := make(map[int][]int)
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		child, err1 := strconv.Atoi(fields[0])
+		parent, err2 := strconv.Atoi(fields[1])
+		if err1 == nil && err2 == nil {
+			kids[parent] = append(kids[parent], child)
+		}
+	}
+
+	var walk func(p int) []int
+	walk = func(p int) []int {
+		var all []int
+		for _, k := range kids[p] {
+			all = append(all, walk(k)...)
+			all = append(all, k)
+		}
+		return all
+	}
+	return walk(pid)
+}
@@ -0,0 +1,183 @@
+//go:build windows
+
+package data
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// wmiQuery lists processes through WMI as JSON. Times are flattened to
+// numbers so the output doesn't depend on the PowerShell version.
+const wmiQuery = `$p = Get-CimInstance Win32_Process %s | Select-Object ProcessId, CommandLine, Name, WorkingSetSize,
+  @{n='CPU';e={[int64]$_.KernelModeTime + [int64]$_.UserModeTime}},
+  @{n='Started';e={if ($_.CreationDate) { ([DateTimeOffset]$_.CreationDate).ToUnixTimeSeconds() } else { 0 }}}
+ConvertTo-Json -Compress -InputObject @($p)`
+
+// wmiProcess is one Win32_Process row from wmiQuery.
+type wmiProcess struct {
+	ProcessID      int    `json:"ProcessId"`
+	CommandLine    string `json:"CommandLine"`
+	Name           string `json:"Name"`
+	WorkingSetSize int64  `json:"WorkingSetSize"`
+	CPU            int64  `json:"CPU"` // kernel + user time in 100ns units
+	Started        int64  `json:"Started"`
+}
+
+// queryWMI runs wmiQuery with an optional -Filter clause.
+func queryWMI(filter string) ([]wmiProcess, error) {
+	if filter != "" {
+		filter = `-Filter "` + filter + `"`
+	}
+	out, err := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command",
+		fmt.Sprintf(wmiQuery, filter)).Output()
+	if err != nil {
+		return nil, err
+	}
+	var procs []wmiProcess
+	if err := json.Unmarshal(out, &procs); err != nil {
+		return nil, fmt.Errorf("parse Win32_Process: %w", err)
+	}
+	return procs, nil
+}
+
+func (p wmiProcess) usage() psUsage {
+	return psUsage{cpuTime: time.Duration(p.CPU) * 100, rss: p.WorkingSetSize}
+}
+
+// scanProcesses lists agent processes from WMI along with their resource
+// use, falling back to tasklist (which has no command lines) when
+// PowerShell is unavailable.
+func scanProcesses() ([]Process, map[int]psUsage, error) {
+	wmi, err := queryWMI("")
+	if err != nil {
+		return scanTasklist()
+	}
+
+	var procs []Process
+	usage := make(map[int]psUsage)
+	for _, w := range wmi {
+		cmd := w.CommandLine
+		if cmd == "" {
+			cmd = w.Name
+		}
+		if !relevantProcess(cmd) || strings.Contains(cmd, "Win32_Process") {
+			continue
+		}
+		elapsed := ""
+		if w.Started > 0 {
+			elapsed = formatElapsed(time.Since(time.Unix(w.Started, 0)))
+		}
+		usage[w.ProcessID] = w.usage()
+		procs = append(procs, Process{
+			SessionName: "pid:" + strconv.Itoa(w.ProcessID),
+			Status:      "running",
+			Runtime:     elapsed,
+			Command:     truncateCommand(cmd),
+			PID:         w.ProcessID,
+		})
+	}
+	return procs, usage, nil
+}
+
+// scanTasklist lists agent processes by image name from tasklist.
+func scanTasklist() ([]Process, map[int]psUsage, error) {
+	out, err := exec.Command("tasklist", "/FO", "CSV", "/NH").Output()
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := csv.NewReader(strings.NewReader(string(out))).ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse tasklist: %w", err)
+	}
+
+	var procs []Process
+	usage := make(map[int]psUsage)
+	for _, row := range rows {
+		// "Image Name","PID","Session Name","Session#","Mem Usage"
+		if len(row) < 5 || !relevantProcess(row[0]) {
+			continue
+		}
+		pid, err := strconv.Atoi(row[1])
+		if err != nil {
+			continue
+		}
+		usage[pid] = psUsage{rss: parseTasklistMem(row[4])}
+		procs = append(procs, Process{
+			SessionName: "pid:" + row[1],
+			Status:      "running",
+			Command:     row[0],
+			PID:         pid,
+		})
+	}
+	return procs, usage, nil
+}
+
+// parseTasklistMem parses tasklist memory usage such as "12,345 K".
+func parseTasklistMem(s string) int64 {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+	kb, _ := strconv.ParseInt(digits, 10, 64)
+	return kb * 1024
+}
+
+// formatElapsed formats d the way ps prints etime: [[dd-]hh:]mm:ss.
+func formatElapsed(d time.Duration) string {
+	secs := int(d.Seconds())
+	days, secs := secs/86400, secs%86400
+	h, m, s := secs/3600, secs%3600/60, secs%60
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%d-%02d:%02d:%02d", days, h, m, s)
+	case h > 0:
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
+
+// sampleUsage reads cumulative CPU time and working set for the given PIDs.
+func sampleUsage(pids []int) map[int]psUsage {
+	if len(pids) == 0 {
+		return nil
+	}
+	clauses := make([]string, len(pids))
+	for i, pid := range pids {
+		clauses[i] = "ProcessId=" + strconv.Itoa(pid)
+	}
+	wmi, err := queryWMI(strings.Join(clauses, " OR "))
+	if err != nil {
+		return nil
+	}
+	usage := make(map[int]psUsage, len(wmi))
+	for _, w := range wmi {
+		usage[w.ProcessID] = w.usage()
+	}
+	return usage
+}
+
+// signalPID stops pid with taskkill. Windows has no signals: KILL
+// terminates forcibly, the others ask the process to close. With children
+// set the whole process tree is stopped.
+func signalPID(pid int, sig string, _ syscall.Signal, children bool) error {
+	args := []string{"/PID", strconv.Itoa(pid)}
+	if children {
+		args = append(args, "/T")
+	}
+	if sig == "KILL" {
+		args = append(args, "/F")
+	}
+	if out, err := exec.Command("taskkill", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("taskkill %d: %s", pid, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
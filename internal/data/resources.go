@@ -0,0 +1,135 @@
+package data
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ResourceHistorySize is how many samples are kept per process. At the 3s
+// process refresh this covers the last few minutes.
+const ResourceHistorySize = 120
+
+// ResourceRetention is how long the history of a process is kept after it
+// has left the process list, so a process that just died can still be
+// looked at.
+const ResourceRetention = 10 * time.Minute
+
+// ResourceSample is one CPU/memory reading for a process.
+type ResourceSample struct {
+	At  time.Time
+	CPU float64 // percent of one core since the previous sample
+	RSS int64   // resident memory in bytes
+}
+
+// resourceRing is a fixed-size ring buffer of samples for one process.
+type resourceRing struct {
+	samples []ResourceSample
+	next    int
+	full    bool
+	cpuTime time.Duration // cumulative CPU time at the last sample
+	listed  time.Time     // when the process was last in the process list
+}
+
+func (r *resourceRing) add(s ResourceSample) {
+	if r.samples == nil {
+		r.samples = make([]ResourceSample, ResourceHistorySize)
+	}
+	r.samples[r.next] = s
+	r.next = (r.next + 1) % len(r.samples)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// ordered returns the samples oldest first.
+func (r *resourceRing) ordered() []ResourceSample {
+	if !r.full {
+		return append([]ResourceSample(nil), r.samples[:r.next]...)
+	}
+	out := make([]ResourceSample, 0, len(r.samples))
+	out = append(out, r.samples[r.next:]...)
+	return append(out, r.samples[:r.next]...)
+}
+
+// psUsage is a raw reading of a process's resource use.
+type psUsage struct {
+	cpuTime time.Duration
+	rss     int64
+}
+
+// recordResources stores a sample for every process with a known PID and
+// fills in its latest CPU and RSS. A listed process that wasn't sampled,
+// such as one that died, keeps its history as it was; history is dropped
+// ResourceRetention after its process has left the list.
+func (c *Client) recordResources(procs []Process, usage map[int]psUsage) {
+	now := time.Now()
+	c.resourcesMu.Lock()
+	defer c.resourcesMu.Unlock()
+
+	for i := range procs {
+		p := &procs[i]
+		if ring := c.resources[p.SessionName]; ring != nil {
+			ring.listed = now
+		}
+		u, ok := usage[p.PID]
+		if p.PID == 0 || !ok {
+			continue
+		}
+		ring := c.resources[p.SessionName]
+		if ring == nil {
+			ring = &resourceRing{listed: now}
+			c.resources[p.SessionName] = ring
+		}
+
+		sample := ResourceSample{At: now, RSS: u.rss}
+		if ring.next > 0 || ring.full {
+			prev := ring.samples[(ring.next-1+len(ring.samples))%len(ring.samples)]
+			if wall := now.Sub(prev.At); wall > 0 && u.cpuTime >= ring.cpuTime {
+				sample.CPU = float64(u.cpuTime-ring.cpuTime) / float64(wall) * 100
+			}
+		}
+		ring.cpuTime = u.cpuTime
+		ring.add(sample)
+		p.CPU, p.RSS = sample.CPU, sample.RSS
+	}
+	for name, ring := range c.resources {
+		if now.Sub(ring.listed) > ResourceRetention {
+			delete(c.resources, name)
+		}
+	}
+}
+
+// ResourceHistory returns the recorded samples for a process, oldest first.
+func (c *Client) ResourceHistory(name string) []ResourceSample {
+	c.resourcesMu.Lock()
+	defer c.resourcesMu.Unlock()
+	if ring := c.resources[name]; ring != nil {
+		return ring.ordered()
+	}
+	return nil
+}
+
+func parseUsage(cpuTime, rssKB string) psUsage {
+	kb, _ := strconv.ParseInt(rssKB, 10, 64)
+	return psUsage{cpuTime: parseCPUTime(cpuTime), rss: kb * 1024}
+}
+
+// parseCPUTime parses ps cumulative CPU time: "[dd-]hh:mm:ss" on Linux and
+// "mm:ss.cc" on macOS.
+func parseCPUTime(s string) time.Duration {
+	var days float64
+	if d, rest, ok := strings.Cut(s, "-"); ok {
+		days, _ = strconv.ParseFloat(d, 64)
+		s = rest
+	}
+	var secs float64
+	for _, part := range strings.Split(s, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0
+		}
+		secs = secs*60 + n
+	}
+	return time.Duration((days*86400 + secs) * float64(time.Second))
+}
@@ -0,0 +1,87 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// PlanRetention returns the archived runs that the policy would purge,
+// oldest first. Runs older than MaxAge are always selected; then, if the
+// remaining archive exceeds MaxTotalBytes, the oldest runs are added until
+// it fits. Runs the operator labeled are skipped entirely when KeepLabeled
+// is set; the label read from a transcript's first prompt doesn't count,
+// since nearly every run has one.
+func PlanRetention(runs []ArchivedRun, policy config.Retention, now time.Time) []ArchivedRun {
+	if !policy.Enabled() {
+		return nil
+	}
+
+	sorted := make([]ArchivedRun, len(runs))
+	copy(sorted, runs)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ModifiedAt < sorted[j].ModifiedAt
+	})
+
+	var total int64
+	for _, r := range sorted {
+		total += r.Size
+	}
+
+	var purge []ArchivedRun
+	selected := make(map[string]bool)
+	if policy.MaxAge > 0 {
+		cutoff := now.Add(-policy.MaxAge).UnixMilli()
+		for _, r := range sorted {
+			if r.ModifiedAt >= cutoff {
+				break
+			}
+			if policy.KeepLabeled && r.Renamed {
+				continue
+			}
+			purge = append(purge, r)
+			selected[r.Path] = true
+			total -= r.Size
+		}
+	}
+
+	if policy.MaxTotalBytes > 0 {
+		for _, r := range sorted {
+			if total <= policy.MaxTotalBytes {
+				break
+			}
+			if selected[r.Path] || (policy.KeepLabeled && r.Renamed) {
+				continue
+			}
+			purge = append(purge, r)
+			selected[r.Path] = true
+			total -= r.Size
+		}
+	}
+
+	sort.Slice(purge, func(i, j int) bool {
+		return purge[i].ModifiedAt < purge[j].ModifiedAt
+	})
+	return purge
+}
+
+// PurgeArchivedRuns deletes the transcripts of the given runs and returns
+// how many were removed. It keeps going past failures and reports the first.
+func (c *Client) PurgeArchivedRuns(runs []ArchivedRun) (int, error) {
+	removed := 0
+	var firstErr error
+	for _, r := range runs {
+		if err := os.Remove(r.Path); err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("remove %s: %w", r.SessionID, err)
+			}
+			continue
+		}
+		WriteRunMeta(r.Path, RunMeta{}) // the sidecar goes with it
+		removed++
+	}
+	return removed, firstErr
+}
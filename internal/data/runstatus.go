@@ -0,0 +1,75 @@
+package data
+
+import (
+	"bytes"
+	"io"
+	"os"
+)
+
+// How an archived run ended, as read from the end of its transcript.
+const (
+	RunCompleted = "completed"
+	RunFailed    = "failed"  // the last turn ended in an error, or a tool call failed last
+	RunAborted   = "aborted" // the last turn was aborted
+)
+
+// tailBytes is how much of the end of a transcript is read to find out how
+// the run ended.
+const tailBytes = 64 * 1024
+
+// runOutcome returns how the run in msgs ended, or "" when it has no
+// messages.
+func runOutcome(msgs []HistoryMessage) string {
+	if len(msgs) == 0 {
+		return ""
+	}
+	lastStop := ""
+	for _, m := range msgs {
+		if m.Role == "assistant" {
+			lastStop = m.StopReason
+		}
+	}
+	switch {
+	case lastStop == "aborted":
+		return RunAborted
+	case lastStop == "error" || msgs[len(msgs)-1].ToolError:
+		return RunFailed
+	}
+	return RunCompleted
+}
+
+// readTranscriptOutcome reads the end of the transcript at path, in the
+// named format, and returns how the run ended.
+func readTranscriptOutcome(path, formatName string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	var format TranscriptFormat = genericFormat{}
+	for _, tf := range transcriptFormats {
+		if tf.Name() == formatName {
+			format = tf
+			break
+		}
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		return ""
+	}
+	offset := max(0, info.Size()-tailBytes)
+	tail, err := io.ReadAll(io.NewSectionReader(f, offset, info.Size()-offset))
+	if err != nil {
+		return ""
+	}
+	if offset > 0 {
+		// Drop the partial line the tail starts in
+		if i := bytes.IndexByte(tail, '\n'); i >= 0 {
+			tail = tail[i+1:]
+		}
+	}
+	msgs, _ := format.Parse(bytes.NewReader(tail))
+	return runOutcome(msgs)
+}
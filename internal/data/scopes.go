@@ -0,0 +1,114 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ScopesTool is the gateway tool that reports the scopes of the token the
+// Commander authenticates with. Gateways without it answer with a not-found
+// error.
+const ScopesTool = "auth_scopes"
+
+// ErrScopesUnsupported is returned by FetchScopes when the gateway has no
+// ScopesTool.
+var ErrScopesUnsupported = errors.New("the gateway has no " + ScopesTool + " tool")
+
+// Actions the gateway token may not be allowed to perform.
+const (
+	PermSpawn   = "spawn"   // start a sub-agent
+	PermMessage = "message" // send a session a message
+	PermKill    = "kill"    // signal a process
+	PermAbort   = "abort"   // abort a session's run
+)
+
+// ScopeAdmin allows every action.
+const ScopeAdmin = "operator.admin"
+
+// permScopes is the scope each action needs; ScopeAdmin covers them all.
+var permScopes = map[string]string{
+	PermSpawn:   "operator.write",
+	PermMessage: "operator.write",
+	PermKill:    ScopeAdmin,
+	PermAbort:   "operator.write",
+}
+
+// Scopes is what the gateway token is allowed to do.
+type Scopes []string
+
+// NeededScope returns the scope that allows action.
+func NeededScope(action string) string {
+	return permScopes[action]
+}
+
+// Allows reports whether the scopes include one that allows action.
+func (s Scopes) Allows(action string) bool {
+	need, ok := permScopes[action]
+	if !ok {
+		return true
+	}
+	for _, scope := range s {
+		if scope == need || scope == ScopeAdmin || scope == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// FetchScopes asks the gateway which scopes the token has.
+func (c *Client) FetchScopes() (Scopes, error) {
+	body, err := c.invoke(toolRequest{Tool: ScopesTool, Args: map[string]interface{}{}})
+	if err != nil {
+		return nil, scopesError(err)
+	}
+	resp, err := decodeResponse(ScopesTool, body)
+	if err != nil {
+		return nil, scopesError(err)
+	}
+	if apiErr := resultError(ScopesTool, resp.Result); apiErr != nil {
+		return nil, scopesError(apiErr)
+	}
+
+	// Scopes come as a list, or as an OAuth-style space-separated string
+	var result struct {
+		Details struct {
+			Scopes []string `json:"scopes"`
+			Scope  string   `json:"scope"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return nil, classified(ErrKindParse, fmt.Errorf("parse %s result: %w", ScopesTool, err))
+	}
+	scopes := append(Scopes{}, result.Details.Scopes...)
+	scopes = append(scopes, strings.Fields(result.Details.Scope)...)
+	return scopes, nil
+}
+
+// scopesError turns a not-found answer about the scopes tool itself into
+// ErrScopesUnsupported.
+func scopesError(err error) error {
+	if missingTool(err) {
+		return ErrScopesUnsupported
+	}
+	return err
+}
+
+// IsForbidden reports whether err is the gateway refusing an action the
+// token isn't allowed to perform, as opposed to rejecting the token itself.
+func IsForbidden(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.Status == http.StatusForbidden {
+		return true
+	}
+	switch strings.ToLower(apiErr.Code) {
+	case "forbidden", "denied", "missing_scope", "insufficient_scope":
+		return true
+	}
+	return false
+}
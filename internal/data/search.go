@@ -0,0 +1,127 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// HistorySearchTool is the gateway tool that searches session history
+// server-side. Gateways without it answer with a not-found error.
+const HistorySearchTool = "sessions_search"
+
+// ErrSearchUnsupported is returned by SearchHistory when the gateway has no
+// HistorySearchTool.
+var ErrSearchUnsupported = errors.New("the gateway has no " + HistorySearchTool + " tool")
+
+// SearchHit is one matching turn found by a gateway history search.
+type SearchHit struct {
+	SessionKey string
+	SessionID  string
+	Label      string
+	Index      int // position of the turn in the session's history
+	Role       string
+	Timestamp  int64 // Unix ms; 0 when unknown
+	Snippet    string
+}
+
+// searchHitJSON accepts the field names gateways use for a search hit.
+type searchHitJSON struct {
+	SessionKey   string `json:"sessionKey"`
+	Key          string `json:"key"`
+	SessionID    string `json:"sessionId"`
+	Label        string `json:"label"`
+	Index        *int   `json:"index"`
+	MessageIndex *int   `json:"messageIndex"`
+	Role         string `json:"role"`
+	Timestamp    int64  `json:"timestamp"`
+	Snippet      string `json:"snippet"`
+	Text         string `json:"text"`
+}
+
+// SearchHistory asks the gateway for turns matching query across all
+// sessions, at most limit of them, ordered by session and then by turn.
+func (c *Client) SearchHistory(query string, limit int) ([]SearchHit, error) {
+	body, err := c.invoke(toolRequest{
+		Tool: HistorySearchTool,
+		Args: map[string]interface{}{"query": query, "limit": limit},
+	})
+	if err != nil {
+		return nil, searchError(err)
+	}
+	resp, err := decodeResponse(HistorySearchTool, body)
+	if err != nil {
+		return nil, searchError(err)
+	}
+	if apiErr := resultError(HistorySearchTool, resp.Result); apiErr != nil {
+		return nil, searchError(apiErr)
+	}
+
+	var result struct {
+		Details struct {
+			Results []searchHitJSON `json:"results"`
+			Hits    []searchHitJSON `json:"hits"`
+		} `json:"details"`
+	}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return nil, classified(ErrKindParse, fmt.Errorf("parse %s result: %w", HistorySearchTool, err))
+	}
+	raw := append(result.Details.Results, result.Details.Hits...)
+
+	hits := make([]SearchHit, 0, len(raw))
+	for _, r := range raw {
+		h := SearchHit{
+			SessionKey: r.SessionKey,
+			SessionID:  r.SessionID,
+			Label:      r.Label,
+			Role:       r.Role,
+			Timestamp:  r.Timestamp,
+			Snippet:    r.Snippet,
+		}
+		if h.SessionKey == "" {
+			h.SessionKey = r.Key
+		}
+		if h.Snippet == "" {
+			h.Snippet = r.Text
+		}
+		switch {
+		case r.Index != nil:
+			h.Index = *r.Index
+		case r.MessageIndex != nil:
+			h.Index = *r.MessageIndex
+		}
+		h.Snippet = strings.Join(strings.Fields(StripANSI(h.Snippet)), " ")
+		hits = append(hits, h)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].SessionKey != hits[j].SessionKey {
+			return hits[i].SessionKey < hits[j].SessionKey
+		}
+		return hits[i].Index < hits[j].Index
+	})
+	return hits, nil
+}
+
+// searchError turns a not-found answer about the search tool itself into
+// ErrSearchUnsupported.
+func searchError(err error) error {
+	if missingTool(err) {
+		return ErrSearchUnsupported
+	}
+	return err
+}
+
+// missingTool reports whether err is the gateway saying the tool called
+// doesn't exist.
+func missingTool(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	code := strings.ToLower(apiErr.Code + " " + apiErr.Message)
+	return apiErr.Status == http.StatusNotFound || strings.Contains(code, "unknown tool") ||
+		strings.Contains(code, "tool not found") || (strings.Contains(code, "not_found") && strings.Contains(code, "tool"))
+}
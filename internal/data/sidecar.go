@@ -0,0 +1,75 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+)
+
+// RunMeta is what an operator added to an archived run after the fact. It
+// lives in a sidecar file next to the transcript, so it travels with it.
+type RunMeta struct {
+	Label string `json:"label,omitempty"` // replaces the label read from the transcript
+	Note  string `json:"note,omitempty"`
+}
+
+// SidecarPath returns the path of the metadata sidecar of the transcript
+// at path: run.jsonl has run.meta.json.
+func SidecarPath(path string) string {
+	return strings.TrimSuffix(path, ".jsonl") + ".meta.json"
+}
+
+// ReadRunMeta reads the sidecar of the transcript at path. A missing
+// sidecar is an empty RunMeta.
+func ReadRunMeta(path string) (RunMeta, error) {
+	var meta RunMeta
+	raw, err := os.ReadFile(SidecarPath(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return meta, nil
+	}
+	if err != nil {
+		return meta, err
+	}
+	return meta, json.Unmarshal(raw, &meta)
+}
+
+// WriteRunMeta saves meta as the sidecar of the transcript at path. An
+// empty RunMeta removes the sidecar.
+func WriteRunMeta(path string, meta RunMeta) error {
+	sidecar := SidecarPath(path)
+	if meta == (RunMeta{}) {
+		if err := os.Remove(sidecar); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	raw, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write then rename, so the scan never reads half a sidecar
+	tmp := sidecar + ".tmp"
+	if err := os.WriteFile(tmp, append(raw, '\n'), 0o644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, sidecar); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// applyRunMeta puts the operator's label and note from run's sidecar over
+// what was read from the transcript.
+func applyRunMeta(run *ArchivedRun) {
+	meta, err := ReadRunMeta(run.Path)
+	if err != nil {
+		return
+	}
+	if meta.Label != "" {
+		run.Label, run.Renamed = meta.Label, true
+	}
+	run.Note = meta.Note
+}
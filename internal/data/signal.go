@@ -0,0 +1,57 @@
+package data
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// KillSignals are the signals offered when killing a process, in menu order.
+var KillSignals = []string{"TERM", "INT", "HUP", "KILL"}
+
+var signalsByName = map[string]syscall.Signal{
+	"TERM": syscall.SIGTERM,
+	"INT":  syscall.SIGINT,
+	"HUP":  syscall.SIGHUP,
+	"KILL": syscall.SIGKILL,
+}
+
+// processPID returns the OS process ID of p, or 0 when only the gateway
+// knows the process.
+func processPID(p Process) int {
+	if p.PID > 0 {
+		return p.PID
+	}
+	if pid, ok := strings.CutPrefix(p.SessionName, "pid:"); ok {
+		n, _ := strconv.Atoi(pid)
+		return n
+	}
+	return 0
+}
+
+// SignalProcess sends sig (one of KillSignals) to p. With children set, the
+// process's descendants are signaled first so they can't be orphaned.
+// Processes without a known PID are stopped through the gateway's process
+// tool, which is asked to use sig.
+func (c *Client) SignalProcess(p Process, sig string, children bool) error {
+	signal, ok := signalsByName[sig]
+	if !ok {
+		return fmt.Errorf("unknown signal %q", sig)
+	}
+
+	pid := processPID(p)
+	if pid == 0 {
+		body, err := c.invoke(toolRequest{
+			Tool: "process",
+			Args: map[string]interface{}{"action": "kill", "sessionId": p.SessionName, "signal": "SIG" + sig},
+		})
+		if err != nil {
+			return err
+		}
+		_, err = decodeResponse("process", body)
+		return err
+	}
+
+	return signalPID(pid, sig, signal, children)
+}
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SpawnEnvTool is the gateway tool that sets environment variables for the
+// commands of a sub-agent about to be spawned, which it knows by label.
+// The values go to the gateway alone, so API keys never appear in a
+// transcript. Gateways without it answer with a not-found error.
+const SpawnEnvTool = "sessions_env"
+
+// ErrSpawnEnvUnsupported is returned by SpawnSession when environment
+// variables are given and the gateway has no SpawnEnvTool.
+var ErrSpawnEnvUnsupported = errors.New("the gateway has no " + SpawnEnvTool +
+	" tool to pass environment variables; set them in openclaw.json instead")
+
+// envName matches a valid environment variable name.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseEnv reads space-separated KEY=value pairs. A value holding spaces is
+// quoted, "like this" or 'like this'. A later pair wins over an earlier one
+// with the same key.
+func ParseEnv(text string) (map[string]string, error) {
+	env := map[string]string{}
+	rest := strings.TrimSpace(text)
+	for rest != "" {
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("%q is not KEY=value", strings.Fields(rest)[0])
+		}
+		name := rest[:eq]
+		if !envName.MatchString(name) {
+			return nil, fmt.Errorf("%q is not a variable name", name)
+		}
+		rest = rest[eq+1:]
+		var value string
+		if rest != "" && (rest[0] == '"' || rest[0] == '\'') {
+			end := strings.IndexByte(rest[1:], rest[0])
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed quote in the value of %s", name)
+			}
+			value, rest = rest[1:end+1], rest[end+2:]
+			if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+				return nil, fmt.Errorf("missing space after the value of %s", name)
+			}
+		} else if sp := strings.IndexAny(rest, " \t"); sp >= 0 {
+			value, rest = rest[:sp], rest[sp:]
+		} else {
+			value, rest = rest, ""
+		}
+		env[name] = value
+		rest = strings.TrimSpace(rest)
+	}
+	return env, nil
+}
+
+// EnvNames returns the names of the variables in env, sorted.
+func EnvNames(env map[string]string) []string {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetSpawnEnv hands env to the gateway for the sub-agent that will be
+// spawned as label.
+func (c *Client) SetSpawnEnv(label string, env map[string]string) error {
+	body, err := c.invoke(toolRequest{Tool: SpawnEnvTool, Args: map[string]interface{}{"label": label, "env": env}})
+	if err != nil {
+		return spawnEnvError(err)
+	}
+	resp, err := decodeResponse(SpawnEnvTool, body)
+	if err != nil {
+		return spawnEnvError(err)
+	}
+	if apiErr := resultError(SpawnEnvTool, resp.Result); apiErr != nil {
+		return spawnEnvError(apiErr)
+	}
+	return nil
+}
+
+// spawnEnvError turns a not-found answer about the env tool itself into
+// ErrSpawnEnvUnsupported.
+func spawnEnvError(err error) error {
+	if missingTool(err) {
+		return ErrSpawnEnvUnsupported
+	}
+	return err
+}
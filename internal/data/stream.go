@@ -0,0 +1,193 @@
+package data
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// EventStreamPath is the gateway's server-sent event stream of session
+// messages. Subscribed with ?sessionKey=, it sends a "message" event each
+// time a message of that session is added or grows while it streams, its
+// data being {"sessionKey": ..., "message": ...} with the message in the
+// sessions_history shape. Gateways without it answer 404.
+const EventStreamPath = "/events"
+
+// streamConnectTimeout bounds the wait for the stream's response headers;
+// once connected the stream stays open for as long as the gateway keeps it.
+const streamConnectTimeout = 10 * time.Second
+
+// ErrStreamUnsupported is returned by OpenLogStream when the gateway has no
+// event stream, so logs have to be polled.
+var ErrStreamUnsupported = errors.New("the gateway has no event stream")
+
+// LogStream is a live subscription to the messages of one session.
+type LogStream struct {
+	SessionKey string
+
+	cancel context.CancelFunc
+	body   io.ReadCloser
+	events *sseReader
+}
+
+// OpenLogStream subscribes to the messages of sessionKey. Only the gateway's
+// own HTTP API streams; over MCP, and when the gateway doesn't know
+// EventStreamPath, it returns ErrStreamUnsupported.
+func (c *Client) OpenLogStream(sessionKey string) (*LogStream, error) {
+	if c.cfg.Transport == config.TransportMCP {
+		return nil, ErrStreamUnsupported
+	}
+	u := c.cfg.GatewayURL + EventStreamPath + "?sessionKey=" + url.QueryEscape(sessionKey)
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+
+	// The client's timeout would cut the stream off; bound only the connect
+	timer := time.AfterFunc(streamConnectTimeout, cancel)
+	start := time.Now()
+	resp, err := (&http.Client{Transport: c.http.Transport}).Do(req)
+	stopped := timer.Stop()
+	if err != nil {
+		cancel()
+		c.recordTrace(TraceEntry{At: start, Tool: "GET " + EventStreamPath, Duration: time.Since(start), Status: traceError(err), Failed: true})
+		if !stopped {
+			err = fmt.Errorf("no answer in %s", streamConnectTimeout)
+		}
+		return nil, classified(ErrKindNetwork, fmt.Errorf("event stream: %w", err))
+	}
+	c.recordTrace(TraceEntry{At: start, Tool: "GET " + EventStreamPath, Duration: time.Since(start),
+		Status: fmt.Sprint(resp.StatusCode), Failed: resp.StatusCode != http.StatusOK})
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusMethodNotAllowed:
+		resp.Body.Close()
+		cancel()
+		return nil, ErrStreamUnsupported
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		resp.Body.Close()
+		cancel()
+		return nil, parseAPIError(EventStreamPath, resp.StatusCode, body)
+	case !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream"):
+		// A catch-all route, such as a web UI answering every path
+		resp.Body.Close()
+		cancel()
+		return nil, ErrStreamUnsupported
+	}
+	return &LogStream{SessionKey: sessionKey, cancel: cancel, body: resp.Body, events: newSSEReader(resp.Body)}, nil
+}
+
+// Next blocks until the session has a new or grown message and returns it
+// as history messages, parsed as FetchSessionMessages would. It returns an
+// error once the stream ends or is closed.
+func (s *LogStream) Next() ([]HistoryMessage, error) {
+	for {
+		name, payload, err := s.events.next()
+		if err != nil {
+			return nil, err
+		}
+		if name != "" && name != "message" {
+			continue
+		}
+		var event struct {
+			SessionKey string          `json:"sessionKey"`
+			Message    json.RawMessage `json:"message"`
+		}
+		if json.Unmarshal([]byte(payload), &event) != nil || len(event.Message) == 0 {
+			continue
+		}
+		if event.SessionKey != "" && event.SessionKey != s.SessionKey {
+			continue
+		}
+		if msgs := parseGatewayMessage(event.Message); len(msgs) > 0 {
+			return msgs, nil
+		}
+	}
+}
+
+// Close ends the subscription; a Next in progress returns an error.
+func (s *LogStream) Close() {
+	s.cancel()
+	s.body.Close()
+}
+
+// MergeStreamed folds msgs, the messages Next returned, into the messages
+// shown so far. A message the stream sends again as it grows replaces the
+// one it sent before, which sits at the end with the same timestamp.
+func MergeStreamed(shown, msgs []HistoryMessage) []HistoryMessage {
+	first := msgs[0]
+	end := len(shown)
+	for first.Timestamp != 0 && end > 0 && shown[end-1].Timestamp == first.Timestamp && sameMessage(shown[end-1].Role, first.Role) {
+		end--
+	}
+	merged := make([]HistoryMessage, 0, end+len(msgs))
+	return append(append(merged, shown[:end]...), msgs...)
+}
+
+// sameMessage reports whether history messages with roles a and b can come
+// from the same gateway message, which for an assistant message is split
+// into its tool calls and its text.
+func sameMessage(a, b string) bool {
+	split := func(role string) bool { return role == "assistant" || role == "toolUse" }
+	return a == b || split(a) && split(b)
+}
+
+// sseReader reads the events of a server-sent event stream.
+type sseReader struct {
+	scanner *bufio.Scanner
+}
+
+func newSSEReader(r io.Reader) *sseReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	return &sseReader{scanner: scanner}
+}
+
+// next returns the name and data of the next event. Comments, such as
+// keep-alives, are skipped. At the end of the stream it returns the last
+// event if it wasn't terminated, then io.EOF.
+func (r *sseReader) next() (name, data string, err error) {
+	var buf strings.Builder
+	pending := false
+	for r.scanner.Scan() {
+		line := strings.TrimSuffix(r.scanner.Text(), "\r")
+		switch {
+		case line == "":
+			if pending {
+				return name, buf.String(), nil
+			}
+			name = "" // an event without data isn't dispatched
+		case strings.HasPrefix(line, "data:"):
+			if pending {
+				buf.WriteByte('\n')
+			}
+			buf.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			pending = true
+		case strings.HasPrefix(line, "event:"):
+			name = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		}
+	}
+	if pending {
+		return name, buf.String(), nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return "", "", fmt.Errorf("read event stream: %w", err)
+	}
+	return "", "", io.EOF
+}
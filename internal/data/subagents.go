@@ -0,0 +1,48 @@
+package data
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// SpawnLinkPrefix introduces the spawned session in a formatted
+// sessions_spawn tool result, so the UI can find and follow it.
+const SpawnLinkPrefix = "spawned sub-agent "
+
+// subagentKeyPattern matches sub-agent session keys mentioned in free text.
+var subagentKeyPattern = regexp.MustCompile(`agent:[A-Za-z0-9_-]+:subagent:[A-Za-z0-9_-]+`)
+
+// SpawnedSession returns the session key (or, failing that, the session ID)
+// of the sub-agent announced in a sessions_spawn tool result, or "" when the
+// result names none.
+func SpawnedSession(resultText string) string {
+	var result map[string]interface{}
+	if json.Unmarshal([]byte(strings.TrimSpace(resultText)), &result) == nil {
+		for _, k := range []string{"childSessionKey", "sessionKey", "childSessionId", "sessionId"} {
+			if v, ok := result[k].(string); ok && v != "" {
+				return v
+			}
+		}
+	}
+	return subagentKeyPattern.FindString(resultText)
+}
+
+// isSpawnTool reports whether name is the sub-agent spawning tool.
+func isSpawnTool(name string) bool {
+	return strings.EqualFold(name, "sessions_spawn")
+}
+
+// SpawnLinkTarget extracts the session a formatted log line links to, or ""
+// when the line carries no spawn link.
+func SpawnLinkTarget(line string) string {
+	_, rest, ok := strings.Cut(line, SpawnLinkPrefix)
+	if !ok {
+		return ""
+	}
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
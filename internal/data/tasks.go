@@ -0,0 +1,197 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// SpawnTask is one entry in a bulk spawn task list.
+type SpawnTask struct {
+	Prompt string            `json:"prompt"`
+	Model  string            `json:"model"`
+	Label  string            `json:"label"`
+	Parent string            `json:"parent"` // optional parent session key or label
+	Env    map[string]string `json:"env"`    // optional environment variables for its commands
+}
+
+// TaskList is a bulk spawn file: the tasks plus how many may spawn at once.
+type TaskList struct {
+	Concurrency int         `json:"concurrency"`
+	Tasks       []SpawnTask `json:"tasks"`
+}
+
+// LoadTaskList reads a task list from a JSON or YAML file. JSON may be a
+// bare array of tasks or an object with "tasks" and "concurrency". YAML
+// supports the same shapes with flat task entries:
+//
+//	concurrency: 3
+//	tasks:
+//	  - prompt: Fix the flaky test in pkg/foo
+//	    model: opus
+//	    label: flaky-foo
+func LoadTaskList(path string) (*TaskList, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var list TaskList
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		list, err = parseTaskYAML(string(raw))
+	default:
+		trimmed := strings.TrimSpace(string(raw))
+		if strings.HasPrefix(trimmed, "[") {
+			err = json.Unmarshal(raw, &list.Tasks)
+		} else {
+			err = json.Unmarshal(raw, &list)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", filepath.Base(path), err)
+	}
+
+	for i, t := range list.Tasks {
+		if strings.TrimSpace(t.Prompt) == "" {
+			return nil, fmt.Errorf("task %d: prompt is required", i+1)
+		}
+	}
+	if len(list.Tasks) == 0 {
+		return nil, fmt.Errorf("%s: no tasks", filepath.Base(path))
+	}
+	if list.Concurrency < 1 {
+		list.Concurrency = 1
+	}
+	return &list, nil
+}
+
+// parseTaskYAML handles the small YAML subset used by task lists: a
+// top-level "concurrency" scalar and a list of flat maps (either at the top
+// level or under "tasks"), with plain, quoted, or "|" block scalar values.
+func parseTaskYAML(src string) (TaskList, error) {
+	var list TaskList
+	var cur *SpawnTask
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		item := strings.HasPrefix(trimmed, "- ") || trimmed == "-"
+		if !item && indent == 0 {
+			cur = nil // back at the top level
+		}
+		if item {
+			list.Tasks = append(list.Tasks, SpawnTask{})
+			cur = &list.Tasks[len(list.Tasks)-1]
+			trimmed = strings.TrimSpace(strings.TrimPrefix(trimmed, "-"))
+			indent += 2
+			if trimmed == "" {
+				continue
+			}
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return list, fmt.Errorf("line %d: expected key: value", i+1)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		// Block scalar: collect following lines indented deeper than the key.
+		if value == "|" || value == ">" {
+			folded := value == ">"
+			var block []string
+			for i+1 < len(lines) {
+				next := lines[i+1]
+				nextIndent := len(next) - len(strings.TrimLeft(next, " "))
+				if strings.TrimSpace(next) != "" && nextIndent <= indent {
+					break
+				}
+				block = append(block, next)
+				i++
+			}
+			value = dedent(block)
+			if folded {
+				value = strings.Join(strings.Fields(value), " ")
+			}
+		} else {
+			value = unquoteYAML(value)
+		}
+
+		if cur == nil {
+			switch key {
+			case "concurrency":
+				n, err := strconv.Atoi(value)
+				if err != nil {
+					return list, fmt.Errorf("line %d: concurrency must be a number", i+1)
+				}
+				list.Concurrency = n
+			case "tasks":
+			default:
+				return list, fmt.Errorf("line %d: unknown key %q", i+1, key)
+			}
+			continue
+		}
+
+		switch key {
+		case "prompt":
+			cur.Prompt = value
+		case "model":
+			cur.Model = value
+		case "label":
+			cur.Label = value
+		case "parent":
+			cur.Parent = value
+		default:
+			return list, fmt.Errorf("line %d: unknown task field %q", i+1, key)
+		}
+	}
+	return list, nil
+}
+
+// dedent strips the common leading indentation from a block scalar.
+func dedent(lines []string) string {
+	minIndent := -1
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		n := len(l) - len(strings.TrimLeft(l, " "))
+		if minIndent < 0 || n < minIndent {
+			minIndent = n
+		}
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		if len(l) >= minIndent && minIndent > 0 {
+			l = l[minIndent:]
+		}
+		out[i] = l
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n")
+}
+
+// unquoteYAML strips surrounding quotes and trailing comments from a scalar.
+func unquoteYAML(v string) string {
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		if v[0] == '"' {
+			if s, err := strconv.Unquote(v); err == nil {
+				return s
+			}
+		}
+		return strings.ReplaceAll(v[1:len(v)-1], "''", "'")
+	}
+	if idx := strings.Index(v, " #"); idx >= 0 {
+		v = strings.TrimSpace(v[:idx])
+	}
+	return v
+}
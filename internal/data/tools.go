@@ -0,0 +1,227 @@
+package data
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ToolInfo describes a gateway tool: what it does and the arguments its
+// input schema takes.
+type ToolInfo struct {
+	Name        string
+	Description string
+	Params      []ToolParam // required ones first, then by name
+}
+
+// ToolParam is one top-level argument of a tool.
+type ToolParam struct {
+	Name        string
+	Type        string // JSON schema type; "" when the schema doesn't say
+	Items       string // element type of an array
+	Description string
+	Required    bool
+	Enum        []string
+	Default     string // the schema default as JSON, or ""
+}
+
+// toolSchemaJSON is the part of a JSON schema the argument form uses.
+type toolSchemaJSON struct {
+	Properties map[string]struct {
+		Type        json.RawMessage   `json:"type"`
+		Description string            `json:"description"`
+		Enum        []json.RawMessage `json:"enum"`
+		Default     json.RawMessage   `json:"default"`
+		Items       *struct {
+			Type json.RawMessage `json:"type"`
+		} `json:"items"`
+	} `json:"properties"`
+	Required []string `json:"required"`
+}
+
+// parseToolSchema turns a tool's input schema into its parameters.
+func parseToolSchema(raw json.RawMessage) []ToolParam {
+	var schema toolSchemaJSON
+	if len(raw) == 0 || json.Unmarshal(raw, &schema) != nil {
+		return nil
+	}
+	required := map[string]bool{}
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	params := make([]ToolParam, 0, len(schema.Properties))
+	for name, prop := range schema.Properties {
+		p := ToolParam{
+			Name:        name,
+			Type:        schemaType(prop.Type),
+			Description: prop.Description,
+			Required:    required[name],
+		}
+		if prop.Items != nil {
+			p.Items = schemaType(prop.Items.Type)
+		}
+		for _, e := range prop.Enum {
+			var s string
+			if json.Unmarshal(e, &s) != nil {
+				s = string(e)
+			}
+			p.Enum = append(p.Enum, s)
+		}
+		if len(prop.Default) > 0 && string(prop.Default) != "null" {
+			p.Default = string(prop.Default)
+		}
+		params = append(params, p)
+	}
+	sort.Slice(params, func(i, j int) bool {
+		if params[i].Required != params[j].Required {
+			return params[i].Required
+		}
+		return params[i].Name < params[j].Name
+	})
+	return params
+}
+
+// schemaType reads a schema "type", which is a name or a list of names
+// such as ["string", "null"].
+func schemaType(raw json.RawMessage) string {
+	var name string
+	if json.Unmarshal(raw, &name) == nil {
+		return name
+	}
+	var names []string
+	json.Unmarshal(raw, &names)
+	for _, n := range names {
+		if n != "null" {
+			return n
+		}
+	}
+	return ""
+}
+
+// ErrArgRequired is returned by ParseArg for an empty required argument.
+var ErrArgRequired = errors.New("required")
+
+// ParseArg converts the text typed for p into its JSON value, checking it
+// against p's type and allowed values; other errors say what the value must
+// be. Empty text is nil: the argument is left out.
+func ParseArg(p ToolParam, text string) (interface{}, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		if p.Required {
+			return nil, ErrArgRequired
+		}
+		return nil, nil
+	}
+	if len(p.Enum) > 0 && p.Type != "array" {
+		found := false
+		for _, e := range p.Enum {
+			found = found || e == text
+		}
+		if !found {
+			return nil, fmt.Errorf("one of %s", strings.Join(p.Enum, ", "))
+		}
+	}
+	switch p.Type {
+	case "integer":
+		n, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("a whole number")
+		}
+		return n, nil
+	case "number":
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("a number")
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, fmt.Errorf("true or false")
+		}
+		return b, nil
+	case "array":
+		var v []interface{}
+		if strings.HasPrefix(text, "[") {
+			if err := json.Unmarshal([]byte(text), &v); err != nil {
+				return nil, fmt.Errorf("a JSON array: %v", err)
+			}
+			return v, nil
+		}
+		// A comma-separated list, for arrays of plain values
+		for _, item := range strings.Split(text, ",") {
+			elem, err := ParseArg(ToolParam{Type: p.Items, Required: true}, item)
+			if err != nil {
+				return nil, fmt.Errorf("items must be %v", err)
+			}
+			v = append(v, elem)
+		}
+		return v, nil
+	case "object":
+		var v map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &v); err != nil {
+			return nil, fmt.Errorf("a JSON object: %v", err)
+		}
+		return v, nil
+	case "string":
+		return text, nil
+	}
+	// Untyped: JSON when it parses, otherwise a string
+	var v interface{}
+	if json.Unmarshal([]byte(text), &v) == nil {
+		return v, nil
+	}
+	return text, nil
+}
+
+// ToolCatalog returns the tools the gateway offers with their arguments.
+// Only MCP gateways can list them.
+func (c *Client) ToolCatalog() ([]ToolInfo, error) {
+	return c.tr.listTools()
+}
+
+// InvokeTool calls tool with args, as given in the argument form, and
+// returns its result as text: the content it returned followed by its
+// details as indented JSON.
+func (c *Client) InvokeTool(tool string, args map[string]interface{}) (string, error) {
+	body, err := c.invoke(toolRequest{Tool: tool, Args: args})
+	if err != nil {
+		return "", err
+	}
+	resp, err := decodeResponse(tool, body)
+	if err != nil {
+		return "", err
+	}
+	if apiErr := resultError(tool, resp.Result); apiErr != nil {
+		return "", apiErr
+	}
+	var result struct {
+		TextResult
+		Details json.RawMessage `json:"details"`
+	}
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return "", classified(ErrKindParse, fmt.Errorf("parse %s result: %w", tool, err))
+	}
+	var b strings.Builder
+	for _, item := range result.Content {
+		if item.Type != "text" {
+			continue
+		}
+		if pretty, ok := PrettyJSON(item.Text, JSONMaxDepth); ok {
+			b.WriteString(pretty + "\n")
+		} else {
+			b.WriteString(strings.TrimRight(item.Text, "\n") + "\n")
+		}
+	}
+	if len(result.Details) > 0 && string(result.Details) != "null" {
+		var details interface{}
+		if json.Unmarshal(result.Details, &details) == nil {
+			pretty, _ := json.MarshalIndent(details, "", "  ")
+			b.WriteString("\ndetails:\n" + string(pretty) + "\n")
+		}
+	}
+	return StripANSI(b.String()), nil
+}
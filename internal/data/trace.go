@@ -0,0 +1,121 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// traceSize is how many recent gateway requests are kept for the trace view.
+const traceSize = 200
+
+// TraceEntry is one request the Commander made to the gateway, over HTTP or
+// through the openclaw CLI.
+type TraceEntry struct {
+	At        time.Time
+	Tool      string // tool name, "GET /health", or "cli: <subcommand>"
+	ArgsBytes int    // size of the request arguments
+	Duration  time.Duration
+	Status    string // HTTP status code, "ok", or a short error
+	Failed    bool
+}
+
+// recordTrace appends a request to the trace ring.
+func (c *Client) recordTrace(e TraceEntry) {
+	c.traceMu.Lock()
+	defer c.traceMu.Unlock()
+	c.trace = append(c.trace, e)
+	if len(c.trace) > traceSize {
+		c.trace = c.trace[len(c.trace)-traceSize:]
+	}
+}
+
+// traceCLI records an openclaw CLI invocation that started at start.
+func (c *Client) traceCLI(subcommand string, argsBytes int, start time.Time, err error) {
+	e := TraceEntry{At: start, Tool: "cli: " + subcommand, ArgsBytes: argsBytes, Duration: time.Since(start), Status: "ok"}
+	if err != nil {
+		e.Status, e.Failed = traceError(err), true
+	}
+	c.recordTrace(e)
+}
+
+// traceError shortens err for the status column.
+func traceError(err error) string {
+	s := strings.Join(strings.Fields(err.Error()), " ")
+	if len(s) > 40 {
+		s = s[:37] + "..."
+	}
+	return s
+}
+
+// Trace returns the recorded requests, oldest first.
+func (c *Client) Trace() []TraceEntry {
+	c.traceMu.Lock()
+	defer c.traceMu.Unlock()
+	return append([]TraceEntry(nil), c.trace...)
+}
+
+// FormatTrace renders a per-tool summary followed by the most recent
+// requests, newest first.
+func FormatTrace(entries []TraceEntry) string {
+	if len(entries) == 0 {
+		return "No gateway requests recorded yet."
+	}
+
+	type toolStats struct {
+		name   string
+		calls  int
+		failed int
+		total  time.Duration
+		worst  time.Duration
+	}
+	byTool := make(map[string]*toolStats)
+	for _, e := range entries {
+		st := byTool[e.Tool]
+		if st == nil {
+			st = &toolStats{name: e.Tool}
+			byTool[e.Tool] = st
+		}
+		st.calls++
+		st.total += e.Duration
+		st.worst = max(st.worst, e.Duration)
+		if e.Failed {
+			st.failed++
+		}
+	}
+	stats := make([]*toolStats, 0, len(byTool))
+	for _, st := range byTool {
+		stats = append(stats, st)
+	}
+	// Busiest first: the tools most likely to be hammering the gateway
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].calls != stats[j].calls {
+			return stats[i].calls > stats[j].calls
+		}
+		return stats[i].name < stats[j].name
+	})
+
+	span := time.Since(entries[0].At).Round(time.Second)
+	var b strings.Builder
+	fmt.Fprintf(&b, "Gateway requests: %d in the last %s\n\n", len(entries), span)
+	fmt.Fprintf(&b, "  %-28s %6s %6s %8s %8s\n", "TOOL", "CALLS", "FAILED", "AVG", "MAX")
+	for _, st := range stats {
+		avg := st.total / time.Duration(st.calls)
+		fmt.Fprintf(&b, "  %-28s %6d %6d %8s %8s\n", st.name, st.calls, st.failed,
+			avg.Round(time.Millisecond), st.worst.Round(time.Millisecond))
+	}
+
+	b.WriteString("\nRecent requests (newest first):\n\n")
+	fmt.Fprintf(&b, "  %-8s %-28s %7s %8s  %s\n", "TIME", "TOOL", "ARGS", "LATENCY", "STATUS")
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		status := e.Status
+		if e.Failed {
+			status = "✗ " + status
+		}
+		fmt.Fprintf(&b, "  %-8s %-28s %6dB %8s  %s\n", e.At.Format("15:04:05"), e.Tool, e.ArgsBytes,
+			e.Duration.Round(time.Millisecond), status)
+	}
+	return b.String()
+}
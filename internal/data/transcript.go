@@ -0,0 +1,542 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+)
+
+// TranscriptFormat parses one kind of agent transcript stored as JSONL.
+type TranscriptFormat interface {
+	// Name identifies the format, e.g. "openclaw" or "claude-code".
+	Name() string
+	// Detect reports whether sample, the first decoded lines of a file,
+	// looks like this format.
+	Detect(sample []map[string]json.RawMessage) bool
+	// Parse reads the whole transcript.
+	Parse(r io.Reader) ([]HistoryMessage, error)
+}
+
+// transcriptFormats are tried in order; the generic format accepts anything.
+var transcriptFormats = []TranscriptFormat{
+	claudeCodeFormat{},
+	openclawFormat{},
+	openAIFormat{},
+	genericFormat{},
+}
+
+// detectSampleLines is how many decodable lines format detection looks at.
+const detectSampleLines = 20
+
+// DetectTranscriptFormat picks the format of a transcript from head, the
+// start of the file.
+func DetectTranscriptFormat(head []byte) TranscriptFormat {
+	var sample []map[string]json.RawMessage
+	for _, line := range bytes.Split(head, []byte("\n")) {
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(bytes.TrimSuffix(line, []byte("\r")), &obj) != nil {
+			continue
+		}
+		sample = append(sample, obj)
+		if len(sample) == detectSampleLines {
+			break
+		}
+	}
+	for _, f := range transcriptFormats {
+		if f.Detect(sample) {
+			return f
+		}
+	}
+	return genericFormat{}
+}
+
+// ParseTranscriptFile detects the format of the transcript at path and
+// parses it.
+func ParseTranscriptFile(path string) ([]HistoryMessage, TranscriptFormat, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	br := bufio.NewReaderSize(f, 64*1024)
+	head, _ := br.Peek(64 * 1024)
+	if isEncryptedTranscript(head) {
+		raw, err := io.ReadAll(br)
+		if err != nil {
+			return nil, nil, err
+		}
+		plain, err := decryptTranscript(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		return parseTranscript(plain)
+	}
+	return parseTranscriptReader(head, br)
+}
+
+// scanLines calls fn for each line of r, allowing long lines. Lines may end
+// in \n or \r\n, as transcripts written on Windows do.
+func scanLines(r io.Reader, fn func(line []byte)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 256*1024), 4*1024*1024)
+	for scanner.Scan() {
+		fn(bytes.TrimSuffix(scanner.Bytes(), []byte("\r")))
+	}
+	return scanner.Err()
+}
+
+// contentBlock is one element of a message's content array. The fields
+// cover the OpenClaw, Anthropic, and OpenAI block shapes.
+type contentBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`   // OpenClaw toolCall
+	Input     json.RawMessage `json:"input"`       // Anthropic tool_use
+	ToolUseID string          `json:"tool_use_id"` // Anthropic tool_result
+	Content   blocks          `json:"content"`     // Anthropic tool_result
+	IsError   bool            `json:"is_error"`
+	Thinking  string          `json:"thinking"` // Anthropic/OpenClaw thinking
+	Summary   blocks          `json:"summary"`  // OpenAI reasoning
+}
+
+// blocks decodes a content field that is either a string or an array of
+// content blocks.
+type blocks []contentBlock
+
+func (b *blocks) UnmarshalJSON(raw []byte) error {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		*b = blocks{{Type: "text", Text: s}}
+		return nil
+	}
+	var arr []contentBlock
+	if json.Unmarshal(raw, &arr) == nil {
+		*b = arr
+	}
+	return nil
+}
+
+// text joins the text blocks, including OpenAI's input_text/output_text.
+func (b blocks) text() string {
+	var parts []string
+	for _, c := range b {
+		switch c.Type {
+		case "text", "input_text", "output_text":
+			if c.Text != "" {
+				parts = append(parts, c.Text)
+			}
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// thinking joins the reasoning blocks: Anthropic and OpenClaw thinking,
+// OpenAI reasoning summaries, and a marker for redacted thinking.
+func (b blocks) thinking() string {
+	var parts []string
+	for _, c := range b {
+		switch c.Type {
+		case "thinking":
+			if c.Thinking != "" {
+				parts = append(parts, c.Thinking)
+			} else if c.Text != "" {
+				parts = append(parts, c.Text)
+			}
+		case "reasoning":
+			if summary := c.Summary.allText(); summary != "" {
+				parts = append(parts, summary)
+			} else if c.Text != "" {
+				parts = append(parts, c.Text)
+			}
+		case "redacted_thinking":
+			parts = append(parts, "[redacted]")
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// allText joins the text of every block regardless of type.
+func (b blocks) allText() string {
+	var parts []string
+	for _, c := range b {
+		if c.Text != "" {
+			parts = append(parts, c.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// toolCall is a tool invocation waiting to be paired with its result.
+type toolCall struct {
+	Name  string
+	Args  string
+	Input json.RawMessage
+}
+
+func hasKey(obj map[string]json.RawMessage, keys ...string) bool {
+	for _, k := range keys {
+		if _, ok := obj[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func stringField(obj map[string]json.RawMessage, key string) string {
+	var s string
+	json.Unmarshal(obj[key], &s)
+	return s
+}
+
+// openclawFormat is OpenClaw's session transcript: a "session" header line
+// followed by {"type":"message","message":{...}} entries.
+type openclawFormat struct{}
+
+func (openclawFormat) Name() string { return "openclaw" }
+
+func (openclawFormat) Detect(sample []map[string]json.RawMessage) bool {
+	for _, obj := range sample {
+		switch stringField(obj, "type") {
+		case "session":
+			return true
+		case "message":
+			if hasKey(obj, "message") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (openclawFormat) Parse(r io.Reader) ([]HistoryMessage, error) {
+	var msgs []HistoryMessage
+	// Track pending tool calls from assistant messages to pair with toolResults
+	var pendingToolCalls []toolCall
+
+	err := scanLines(r, func(line []byte) {
+		var entry struct {
+			Type    string `json:"type"`
+			Message struct {
+				Role       string `json:"role"`
+				Content    blocks `json:"content"`
+				ToolName   string `json:"toolName,omitempty"`
+				IsError    bool   `json:"isError,omitempty"`
+				StopReason string `json:"stopReason,omitempty"`
+				Usage      struct {
+					Input       int `json:"input"`
+					Output      int `json:"output"`
+					TotalTokens int `json:"totalTokens"`
+				} `json:"usage"`
+			} `json:"message"`
+			Role      string          `json:"role"`
+			Content   blocks          `json:"content"`
+			Model     string          `json:"model,omitempty"`
+			ToolName  string          `json:"toolName,omitempty"`
+			IsError   bool            `json:"isError,omitempty"`
+			Timestamp json.RawMessage `json:"timestamp,omitempty"`
+		}
+		if json.Unmarshal(line, &entry) != nil {
+			return
+		}
+
+		role := entry.Message.Role
+		content := entry.Message.Content
+		toolName := entry.Message.ToolName
+		isError := entry.Message.IsError
+		if role == "" {
+			role = entry.Role
+			content = entry.Content
+			toolName = entry.ToolName
+			isError = entry.IsError
+		}
+
+		if role == "" || (entry.Type != "" && entry.Type != "message") {
+			return
+		}
+		ts := parseTimestamp(entry.Timestamp)
+
+		switch role {
+		case "assistant":
+			// Extract tool calls from content
+			for _, c := range content {
+				if c.Type == "toolCall" || c.Type == "tool_use" {
+					pendingToolCalls = append(pendingToolCalls, toolCall{Name: c.Name, Args: extractToolArgsFromJSON(c.Arguments), Input: c.Arguments})
+				}
+			}
+			u := entry.Message.Usage
+			tokens := u.TotalTokens
+			if tokens == 0 {
+				tokens = u.Input + u.Output
+			}
+			msgs = append(msgs, HistoryMessage{
+				Role:       role,
+				Model:      entry.Model,
+				Text:       content.text(),
+				Thinking:   content.thinking(),
+				Timestamp:  ts,
+				Tokens:     tokens,
+				StopReason: entry.Message.StopReason,
+			})
+
+		case "toolResult", "tool":
+			msg := HistoryMessage{
+				Role:      role,
+				Model:     entry.Model,
+				ToolName:  toolName,
+				ToolError: isError,
+				Text:      content.text(),
+				Timestamp: ts,
+			}
+			// Pair with pending tool call args if available
+			if len(pendingToolCalls) > 0 {
+				msg.ToolArgs = pendingToolCalls[0].Args
+				msg.ToolInput = pendingToolCalls[0].Input
+				if msg.ToolName == "" {
+					msg.ToolName = pendingToolCalls[0].Name
+				}
+				pendingToolCalls = pendingToolCalls[1:]
+			} else {
+				msg.ToolArgs = extractToolArgs(line)
+			}
+			msgs = append(msgs, msg)
+
+		default:
+			msgs = append(msgs, HistoryMessage{Role: role, Model: entry.Model, Text: content.text(), Timestamp: ts})
+		}
+	})
+	return msgs, err
+}
+
+// claudeCodeFormat is a Claude Code transcript (~/.claude/projects/*/*.jsonl):
+// "user"/"assistant" entries carrying Anthropic messages, with tool results
+// delivered as tool_result blocks in user messages.
+type claudeCodeFormat struct{}
+
+func (claudeCodeFormat) Name() string { return "claude-code" }
+
+func (claudeCodeFormat) Detect(sample []map[string]json.RawMessage) bool {
+	for _, obj := range sample {
+		switch stringField(obj, "type") {
+		case "user", "assistant":
+			if hasKey(obj, "uuid", "sessionId") && hasKey(obj, "message") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (claudeCodeFormat) Parse(r io.Reader) ([]HistoryMessage, error) {
+	var msgs []HistoryMessage
+	pending := make(map[string]toolCall)
+	countedUsage := make(map[string]bool) // response IDs whose usage is counted
+	tokens := 0                           // usage not yet credited to a message
+
+	err := scanLines(r, func(line []byte) {
+		var entry struct {
+			Type    string `json:"type"`
+			Message struct {
+				ID         string `json:"id"`
+				Role       string `json:"role"`
+				Model      string `json:"model"`
+				Content    blocks `json:"content"`
+				StopReason string `json:"stop_reason"`
+				Usage      struct {
+					Input       int `json:"input_tokens"`
+					Output      int `json:"output_tokens"`
+					CacheRead   int `json:"cache_read_input_tokens"`
+					CacheCreate int `json:"cache_creation_input_tokens"`
+				} `json:"usage"`
+			} `json:"message"`
+			Timestamp json.RawMessage `json:"timestamp"`
+		}
+		if json.Unmarshal(line, &entry) != nil || (entry.Type != "user" && entry.Type != "assistant") {
+			return
+		}
+		ts := parseTimestamp(entry.Timestamp)
+		content := entry.Message.Content
+
+		if entry.Type == "assistant" {
+			for _, c := range content {
+				if c.Type == "tool_use" {
+					pending[c.ID] = toolCall{Name: c.Name, Args: extractToolArgsFromJSON(c.Input), Input: c.Input}
+				}
+			}
+			// Each content block of a response is logged as its own entry
+			// repeating the response's usage; count it once and credit it
+			// to the next entry with text
+			if id := entry.Message.ID; id == "" || !countedUsage[id] {
+				countedUsage[id] = true
+				u := entry.Message.Usage
+				tokens += u.Input + u.Output + u.CacheRead + u.CacheCreate
+			}
+			text, thinking := content.text(), content.thinking()
+			if text != "" || thinking != "" {
+				msg := HistoryMessage{
+					Role:       "assistant",
+					Model:      entry.Message.Model,
+					Text:       text,
+					Thinking:   thinking,
+					Timestamp:  ts,
+					StopReason: entry.Message.StopReason,
+				}
+				if text != "" {
+					msg.Tokens, tokens = tokens, 0
+				}
+				msgs = append(msgs, msg)
+			}
+			return
+		}
+
+		for _, c := range content {
+			if c.Type != "tool_result" {
+				continue
+			}
+			call := pending[c.ToolUseID]
+			delete(pending, c.ToolUseID)
+			msgs = append(msgs, HistoryMessage{
+				Role:      "toolResult",
+				ToolName:  call.Name,
+				ToolArgs:  call.Args,
+				ToolInput: call.Input,
+				ToolError: c.IsError,
+				Text:      c.Content.text(),
+				Timestamp: ts,
+			})
+		}
+		if text := content.text(); text != "" {
+			msgs = append(msgs, HistoryMessage{Role: "user", Text: text, Timestamp: ts})
+		}
+	})
+	return msgs, err
+}
+
+// openAIFormat is OpenAI-style chat JSONL: one chat message per line, or one
+// {"messages": [...]} conversation per line as in fine-tuning files.
+type openAIFormat struct{}
+
+func (openAIFormat) Name() string { return "openai" }
+
+func (openAIFormat) Detect(sample []map[string]json.RawMessage) bool {
+	for _, obj := range sample {
+		if hasKey(obj, "messages") {
+			return true
+		}
+		if hasKey(obj, "role") && hasKey(obj, "content", "tool_calls") {
+			return true
+		}
+	}
+	return false
+}
+
+type openAIMessage struct {
+	Role      string `json:"role"`
+	Content   blocks `json:"content"`
+	Name      string `json:"name"`
+	ToolCalls []struct {
+		ID       string `json:"id"`
+		Function struct {
+			Name      string `json:"name"`
+			Arguments string `json:"arguments"`
+		} `json:"function"`
+	} `json:"tool_calls"`
+	ToolCallID string `json:"tool_call_id"`
+
+	ReasoningContent string `json:"reasoning_content"` // DeepSeek-style reasoning
+}
+
+func (openAIFormat) Parse(r io.Reader) ([]HistoryMessage, error) {
+	var msgs []HistoryMessage
+	pending := make(map[string]toolCall)
+
+	add := func(m openAIMessage) {
+		switch m.Role {
+		case "assistant":
+			for _, tc := range m.ToolCalls {
+				pending[tc.ID] = toolCall{
+					Name:  tc.Function.Name,
+					Args:  extractToolArgsFromJSON(json.RawMessage(tc.Function.Arguments)),
+					Input: json.RawMessage(tc.Function.Arguments),
+				}
+			}
+			thinking := m.Content.thinking()
+			if thinking == "" {
+				thinking = m.ReasoningContent
+			}
+			if text := m.Content.text(); text != "" || thinking != "" {
+				msgs = append(msgs, HistoryMessage{Role: "assistant", Text: text, Thinking: thinking})
+			}
+		case "tool", "function":
+			call := pending[m.ToolCallID]
+			delete(pending, m.ToolCallID)
+			if call.Name == "" {
+				call.Name = m.Name
+			}
+			msgs = append(msgs, HistoryMessage{Role: "toolResult", ToolName: call.Name, ToolArgs: call.Args, ToolInput: call.Input, Text: m.Content.text()})
+		case "":
+		default:
+			msgs = append(msgs, HistoryMessage{Role: m.Role, Text: m.Content.text()})
+		}
+	}
+
+	err := scanLines(r, func(line []byte) {
+		var conv struct {
+			Messages []openAIMessage `json:"messages"`
+		}
+		if json.Unmarshal(line, &conv) == nil && len(conv.Messages) > 0 {
+			for _, m := range conv.Messages {
+				add(m)
+			}
+			return
+		}
+		var m openAIMessage
+		if json.Unmarshal(line, &m) == nil {
+			add(m)
+		}
+	})
+	return msgs, err
+}
+
+// genericFormat accepts any JSONL whose lines carry a role-like and a
+// text-like field, such as {"speaker": "user", "text": "..."}.
+type genericFormat struct{}
+
+func (genericFormat) Name() string { return "jsonl" }
+
+func (genericFormat) Detect([]map[string]json.RawMessage) bool { return true }
+
+func (genericFormat) Parse(r io.Reader) ([]HistoryMessage, error) {
+	var msgs []HistoryMessage
+	err := scanLines(r, func(line []byte) {
+		var obj map[string]json.RawMessage
+		if json.Unmarshal(line, &obj) != nil {
+			return
+		}
+		var role string
+		for _, k := range []string{"role", "speaker", "author", "from", "type"} {
+			if role = stringField(obj, k); role != "" {
+				break
+			}
+		}
+		var text string
+		for _, k := range []string{"content", "text", "message", "output"} {
+			var b blocks
+			if raw, ok := obj[k]; ok && json.Unmarshal(raw, &b) == nil {
+				if text = b.text(); text != "" {
+					break
+				}
+			}
+		}
+		if role == "" || text == "" {
+			return
+		}
+		msgs = append(msgs, HistoryMessage{Role: strings.ToLower(role), Text: text, Timestamp: parseTimestamp(obj["timestamp"])})
+	})
+	return msgs, err
+}
@@ -0,0 +1,85 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// transport carries tool calls and heartbeats to the gateway. Whatever the
+// wire protocol, responses come back in the /tools/invoke envelope
+// ({"ok": ..., "result": {"content": [...], "details": ...}}) so the
+// fetchers above it don't change.
+type transport interface {
+	// call invokes tool with JSON-encoded args, returning the HTTP status
+	// and the response body.
+	call(tool string, args []byte) (int, []byte, error)
+	// ping checks that the gateway is up, returning the HTTP status.
+	ping() (int, error)
+	// pingName labels heartbeats in the request trace.
+	pingName() string
+	// listTools returns the tools the gateway offers.
+	listTools() ([]ToolInfo, error)
+}
+
+// newTransport picks the transport for cfg.Transport.
+func newTransport(cfg config.Config, client *http.Client) transport {
+	if cfg.Transport == config.TransportMCP {
+		return &mcpTransport{cfg: cfg, http: client}
+	}
+	return &httpTransport{cfg: cfg, http: client}
+}
+
+// httpTransport is the OpenClaw gateway's own API: POST /tools/invoke and
+// GET /health.
+type httpTransport struct {
+	cfg  config.Config
+	http *http.Client
+}
+
+func (t *httpTransport) call(tool string, args []byte) (int, []byte, error) {
+	body, err := json.Marshal(struct {
+		Tool string          `json:"tool"`
+		Args json.RawMessage `json:"args"`
+	}{tool, args})
+	if err != nil {
+		return 0, nil, fmt.Errorf("marshal request: %w", err)
+	}
+	httpReq, err := http.NewRequest("POST", t.cfg.GatewayURL+"/tools/invoke", bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if t.cfg.Token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+t.cfg.Token)
+	}
+	resp, err := t.http.Do(httpReq)
+	if err != nil {
+		return 0, nil, fmt.Errorf("gateway request: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("read response: %w", err)
+	}
+	return resp.StatusCode, data, nil
+}
+
+func (t *httpTransport) ping() (int, error) {
+	resp, err := t.http.Get(t.cfg.GatewayURL + "/health")
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func (t *httpTransport) pingName() string { return "GET /health" }
+
+func (t *httpTransport) listTools() ([]ToolInfo, error) {
+	return nil, fmt.Errorf("the /tools/invoke gateway does not list its tools")
+}
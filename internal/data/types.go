@@ -1,6 +1,10 @@
 package data
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
 
 // Session represents an OpenClaw agent session.
 type Session struct {
@@ -22,6 +26,55 @@ type Session struct {
 	AbortedLastRun bool   `json:"abortedLastRun"`
 	Status         string `json:"status"`
 	ErrorMessage   string `json:"errorMessage"`
+	Workspace      string `json:"workspaceDir"`
+
+	// Project is the git repository root containing the session's
+	// workspace, or the workspace itself; filled in by FetchSessions.
+	Project string `json:"-"`
+}
+
+// AgentID returns the agent a session belongs to, parsed from keys of the
+// form "agent:<id>:...". Keys without an agent prefix belong to "main".
+func (s Session) AgentID() string {
+	parts := strings.Split(s.Key, ":")
+	if len(parts) >= 3 && parts[0] == "agent" && parts[1] != "" {
+		return parts[1]
+	}
+	return "main"
+}
+
+// EffectiveStatus is the status the Commander shows for a session:
+// "failed", "completed", "running", or "idle". Explicit status and error
+// fields win; otherwise activity in the last five minutes means running.
+func (s Session) EffectiveStatus() string {
+	// Check explicit status/error fields first
+	if s.ErrorMessage != "" || s.Status == "failed" || s.Status == "error" {
+		return "failed"
+	}
+	if s.Status == "completed" || s.Status == "done" {
+		return "completed"
+	}
+	if s.AbortedLastRun {
+		return "failed"
+	}
+
+	// Infer from activity
+	var age time.Duration
+	if s.AgeMs > 0 {
+		age = time.Duration(s.AgeMs) * time.Millisecond
+	} else if s.UpdatedAt > 0 {
+		age = time.Since(time.UnixMilli(s.UpdatedAt))
+	}
+
+	if age < 5*time.Minute {
+		return "running"
+	}
+	return "idle"
+}
+
+// IsSubagent reports whether the session was spawned as a sub-agent.
+func (s Session) IsSubagent() bool {
+	return strings.Contains(s.Key, ":subagent:")
 }
 
 // ModelAlias returns a short alias for a model name.
@@ -127,6 +180,9 @@ type Process struct {
 	Status      string
 	Runtime     string
 	Command     string
+	PID         int     // 0 when unknown
+	CPU         float64 // latest CPU percent, from resource sampling
+	RSS         int64   // latest resident memory in bytes
 }
 
 // GatewayHealth represents the gateway health check response.
@@ -192,11 +248,16 @@ func (v VerboseLevel) Next() VerboseLevel {
 type HistoryMessage struct {
 	Role      string
 	Model     string
-	Text      string // for user/assistant
-	ToolName  string // for toolUse/toolResult
-	ToolArgs  string // summary of tool args
-	ToolError bool   // true if tool failed
+	Text      string          // for user/assistant
+	Thinking  string          // reasoning shown apart from Text, for assistant
+	ToolName  string          // for toolUse/toolResult
+	ToolArgs  string          // summary of tool args
+	ToolInput json.RawMessage // tool args as called, when the transcript has them
+	ToolError bool            // true if tool failed
 	Timestamp int64
+
+	Tokens     int    // tokens used by an assistant turn, when recorded
+	StopReason string // why an assistant turn ended, e.g. "error" or "aborted"
 }
 
 // ArchivedRun represents a completed sub-agent run with a transcript on disk.
@@ -206,4 +267,10 @@ type ArchivedRun struct {
 	Size       int64
 	ModifiedAt int64
 	Path       string
+	Format     string // transcript format name, e.g. "openclaw" or "claude-code"
+	Outcome    string // RunCompleted, RunFailed, or RunAborted; "" when unknown
+	Encrypted  bool   // stored encrypted at rest
+	Sealed     bool   // encrypted, and the transcript key can't open it here
+	Renamed    bool   // Label was set by the operator rather than read from the transcript
+	Note       string // the operator's note on the run
 }
@@ -0,0 +1,219 @@
+package data
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// WorkspaceStatus summarizes uncommitted changes in a session's workspace.
+type WorkspaceStatus struct {
+	Path       string
+	Branch     string
+	Files      int // changed tracked files plus untracked files
+	Insertions int
+	Deletions  int
+	Untracked  int
+}
+
+// Summary renders the status as e.g. "+3 files, ~120 lines".
+func (w WorkspaceStatus) Summary() string {
+	if w.Files == 0 {
+		return "clean"
+	}
+	return fmt.Sprintf("+%d files, ~%d lines", w.Files, w.Insertions+w.Deletions)
+}
+
+// SessionWorkspace returns the workspace directory for a session: the
+// session's own workspace if reported, otherwise the working directory in
+// its transcript header, otherwise the agent's configured
+// workspace from openclaw.json, otherwise ~/.openclaw/workspace.
+// It returns "" when no such directory exists.
+func (c *Client) SessionWorkspace(s Session) string {
+	return loadWorkspaceConfig().workspace(s)
+}
+
+// workspaceConfig is the workspace configuration from openclaw.json.
+type workspaceConfig struct {
+	defaults string
+	agents   map[string]string // agent ID -> workspace
+}
+
+func loadWorkspaceConfig() workspaceConfig {
+	w := workspaceConfig{agents: make(map[string]string)}
+	if data, err := os.ReadFile(filepath.Join(homeDir(), ".openclaw", "openclaw.json")); err == nil {
+		var cfg struct {
+			Agents struct {
+				Defaults struct {
+					Workspace string `json:"workspace"`
+				} `json:"defaults"`
+				List []struct {
+					ID        string `json:"id"`
+					Workspace string `json:"workspace"`
+				} `json:"list"`
+			} `json:"agents"`
+		}
+		if json.Unmarshal(data, &cfg) == nil {
+			for _, a := range cfg.Agents.List {
+				w.agents[a.ID] = a.Workspace
+			}
+			w.defaults = cfg.Agents.Defaults.Workspace
+		}
+	}
+	return w
+}
+
+func (w workspaceConfig) workspace(s Session) string {
+	candidates := []string{
+		s.Workspace,
+		transcriptCwd(TranscriptPath(s)),
+		w.agents[s.AgentID()],
+		w.defaults,
+		filepath.Join(homeDir(), ".openclaw", "workspace"),
+	}
+	for _, dir := range candidates {
+		if dir == "" {
+			continue
+		}
+		dir = config.ExpandHome(dir)
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
+// transcriptCwd returns the working directory recorded in a transcript's
+// session header line, if any.
+func transcriptCwd(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+	line, _ := bufio.NewReaderSize(f, 4096).ReadSlice('\n')
+	var header struct {
+		Type string `json:"type"`
+		Cwd  string `json:"cwd"`
+	}
+	if json.Unmarshal(line, &header) != nil || header.Type != "session" {
+		return ""
+	}
+	return header.Cwd
+}
+
+// assignProjects sets Project on each session from its workspace.
+func assignProjects(sessions []Session) {
+	w := loadWorkspaceConfig()
+	roots := make(map[string]string) // workspace -> project, many sessions share one
+	for i := range sessions {
+		dir := w.workspace(sessions[i])
+		if dir == "" {
+			continue
+		}
+		root, ok := roots[dir]
+		if !ok {
+			root = projectRoot(dir)
+			roots[dir] = root
+		}
+		sessions[i].Project = root
+	}
+}
+
+// projectRoot returns the root of the git repository containing dir, or dir
+// itself when it is not inside one.
+func projectRoot(dir string) string {
+	for d := dir; ; {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d
+		}
+		parent := filepath.Dir(d)
+		if parent == d {
+			return dir
+		}
+		d = parent
+	}
+}
+
+// ProjectName is the short name shown for a project path.
+func ProjectName(project string) string {
+	if project == "" {
+		return ""
+	}
+	return filepath.Base(project)
+}
+
+// FetchWorkspaceStatus runs a lightweight git status/diff in dir.
+func (c *Client) FetchWorkspaceStatus(dir string) (*WorkspaceStatus, error) {
+	st := &WorkspaceStatus{Path: dir}
+
+	out, err := exec.Command("git", "-C", dir, "status", "--porcelain", "--branch").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status: %w", err)
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "## "):
+			branch := strings.TrimPrefix(line, "## ")
+			if idx := strings.Index(branch, "..."); idx > 0 {
+				branch = branch[:idx]
+			}
+			st.Branch = branch
+		case strings.HasPrefix(line, "??"):
+			st.Untracked++
+			st.Files++
+		default:
+			st.Files++
+		}
+	}
+
+	// --shortstat: " 3 files changed, 100 insertions(+), 20 deletions(-)"
+	out, err = exec.Command("git", "-C", dir, "diff", "HEAD", "--shortstat").Output()
+	if err == nil {
+		for _, part := range strings.Split(strings.TrimSpace(string(out)), ",") {
+			fields := strings.Fields(part)
+			if len(fields) < 2 {
+				continue
+			}
+			n, _ := strconv.Atoi(fields[0])
+			switch {
+			case strings.HasPrefix(fields[1], "insertion"):
+				st.Insertions = n
+			case strings.HasPrefix(fields[1], "deletion"):
+				st.Deletions = n
+			}
+		}
+	}
+	return st, nil
+}
+
+// FetchWorkspaceDiff returns the full diff of uncommitted changes in dir,
+// followed by the list of untracked files.
+func (c *Client) FetchWorkspaceDiff(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "diff", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("git diff: %w", err)
+	}
+	var b strings.Builder
+	b.Write(out)
+
+	untracked, err := exec.Command("git", "-C", dir, "ls-files", "--others", "--exclude-standard").Output()
+	if err == nil && len(strings.TrimSpace(string(untracked))) > 0 {
+		b.WriteString("\nUntracked files:\n")
+		for _, f := range strings.Split(strings.TrimSpace(string(untracked)), "\n") {
+			b.WriteString("  " + f + "\n")
+		}
+	}
+	if b.Len() == 0 {
+		return "No uncommitted changes in " + dir, nil
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,232 @@
+// Package fakegateway replays recorded gateway responses, and records them
+// from a real gateway, so the fetch and format pipeline can run against
+// fixed data: for demos, bug reports, and integration tests that must not
+// depend on a live gateway.
+package fakegateway
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Exchange is one recorded gateway request and its response. Fixture files
+// hold one Exchange per line.
+type Exchange struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Tool   string          `json:"tool,omitempty"` // /tools/invoke only
+	Args   json.RawMessage `json:"args,omitempty"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Text   string          `json:"text,omitempty"` // body that isn't JSON
+}
+
+// LoadFixtures reads the exchanges recorded in path.
+func LoadFixtures(path string) ([]Exchange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var exchanges []Exchange
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 256*1024), 64*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var e Exchange
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+		}
+		exchanges = append(exchanges, e)
+	}
+	return exchanges, scanner.Err()
+}
+
+// Server answers gateway requests from recorded exchanges. A tool call is
+// answered by the exchange with the same tool and arguments, or else by
+// the last one recorded for the tool; /health is OK unless recorded
+// otherwise. Anything else gets a 404 in the gateway's error shape.
+type Server struct {
+	exchanges []Exchange
+}
+
+// NewServer returns a fake gateway serving exchanges.
+func NewServer(exchanges []Exchange) *Server {
+	return &Server{exchanges: exchanges}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var tool string
+	var args json.RawMessage
+	if r.Method == http.MethodPost && r.URL.Path == "/tools/invoke" {
+		var req struct {
+			Tool string          `json:"tool"`
+			Args json.RawMessage `json:"args"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+			return
+		}
+		tool, args = req.Tool, req.Args
+	}
+
+	if e, ok := s.match(r.Method, r.URL.Path, tool, args); ok {
+		if e.Text != "" {
+			w.WriteHeader(e.Status)
+			io.WriteString(w, e.Text)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(e.Status)
+		w.Write(e.Body)
+		return
+	}
+	if r.URL.Path == "/health" {
+		w.Write([]byte(`{"ok":true}`))
+		return
+	}
+	what := r.Method + " " + r.URL.Path
+	if tool != "" {
+		what = "tool " + tool
+	}
+	writeError(w, http.StatusNotFound, "not_found", "no fixture for "+what)
+}
+
+// match finds the exchange that answers a request.
+func (s *Server) match(method, path, tool string, args json.RawMessage) (Exchange, bool) {
+	var fallback *Exchange
+	for i := range s.exchanges {
+		e := &s.exchanges[i]
+		if e.Method != method || e.Path != path || e.Tool != tool {
+			continue
+		}
+		if sameJSON(e.Args, args) {
+			return *e, true
+		}
+		fallback = e
+	}
+	if fallback != nil {
+		return *fallback, true
+	}
+	return Exchange{}, false
+}
+
+// sameJSON reports whether a and b encode the same value, ignoring layout
+// and key order.
+func sameJSON(a, b json.RawMessage) bool {
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return len(a) == 0 && len(b) == 0
+	}
+	ca, _ := json.Marshal(va)
+	cb, _ := json.Marshal(vb)
+	return bytes.Equal(ca, cb)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"ok":    false,
+		"error": map[string]string{"code": code, "message": message},
+	})
+}
+
+// Start serves h on a free loopback port in the background, for the rest of
+// the program, and returns its base URL. Tests use httptest.NewServer, which
+// they can close.
+func Start(h http.Handler) (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	go http.Serve(ln, h)
+	return "http://" + ln.Addr().String(), nil
+}
+
+// Recorder proxies requests to a real gateway and appends every exchange
+// to a fixture file that Server can replay.
+type Recorder struct {
+	upstream string
+	client   *http.Client
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewRecorder returns a recorder forwarding to the gateway at upstream
+// through client, writing exchanges to out.
+func NewRecorder(upstream string, client *http.Client, out io.Writer) *Recorder {
+	return &Recorder{upstream: upstream, client: client, out: out}
+}
+
+func (rec *Recorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	reqBody, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", err.Error())
+		return
+	}
+	req, err := http.NewRequest(r.Method, rec.upstream+r.URL.RequestURI(), bytes.NewReader(reqBody))
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "bad_gateway", err.Error())
+		return
+	}
+	req.Header = r.Header.Clone()
+	resp, err := rec.client.Do(req)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "bad_gateway", err.Error())
+		return
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, "bad_gateway", err.Error())
+		return
+	}
+
+	e := Exchange{Method: r.Method, Path: r.URL.Path, Status: resp.StatusCode}
+	if json.Valid(body) {
+		e.Body = body
+	} else {
+		e.Text = string(body)
+	}
+	if r.URL.Path == "/tools/invoke" {
+		var tr struct {
+			Tool string          `json:"tool"`
+			Args json.RawMessage `json:"args"`
+		}
+		if json.Unmarshal(reqBody, &tr) == nil {
+			e.Tool, e.Args = tr.Tool, tr.Args
+		}
+	}
+	rec.write(e)
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(resp.StatusCode)
+	w.Write(body)
+}
+
+// write appends e to the fixture file. Bearer tokens are never recorded:
+// only the request's tool and arguments and the response are kept.
+func (rec *Recorder) write(e Exchange) {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.out.Write(append(line, '\n'))
+}
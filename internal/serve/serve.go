@@ -0,0 +1,225 @@
+// Package serve exposes the Commander's aggregated view — merged sessions,
+// processes, archived runs, and gateway health — as JSON over HTTP, so
+// dashboards and scripts can use it without reimplementing the data layer.
+package serve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// Poll intervals, matching the TUI's refresh rates.
+const (
+	sessionsInterval  = 5 * time.Second
+	processesInterval = 3 * time.Second
+	healthInterval    = 10 * time.Second
+)
+
+type sessionJSON struct {
+	data.Session
+	EffectiveStatus string `json:"effectiveStatus"`
+	Project         string `json:"project,omitempty"`
+	Activity        []int  `json:"activity"` // per-bucket counts over the last 30 minutes
+}
+
+type processJSON struct {
+	Name    string  `json:"name"`
+	Status  string  `json:"status"`
+	Runtime string  `json:"runtime"`
+	Command string  `json:"command"`
+	PID     int     `json:"pid,omitempty"`
+	CPU     float64 `json:"cpu"`
+	RSS     int64   `json:"rss"`
+}
+
+type archivedJSON struct {
+	SessionID  string `json:"sessionId"`
+	Label      string `json:"label"`
+	Size       int64  `json:"size"`
+	ModifiedAt int64  `json:"modifiedAt"`
+	Path       string `json:"path"`
+	Format     string `json:"format,omitempty"`
+}
+
+type healthJSON struct {
+	*data.GatewayHealth
+	Level       string  `json:"level"`
+	Calls       int     `json:"calls"`
+	Errors      int     `json:"errors"`
+	ErrorRate   float64 `json:"errorRate"`
+	P50Ms       int64   `json:"p50Ms"`
+	P95Ms       int64   `json:"p95Ms"`
+	P99Ms       int64   `json:"p99Ms"`
+	Consecutive int     `json:"consecutiveFailures"`
+}
+
+// snapshot is the latest aggregated view, refreshed by the pollers.
+type snapshot struct {
+	Sessions  []sessionJSON     `json:"sessions"`
+	Processes []processJSON     `json:"processes"`
+	History   []archivedJSON    `json:"history"`
+	Health    *healthJSON       `json:"health"`
+	Errors    map[string]string `json:"errors,omitempty"` // last fetch error per source
+	UpdatedAt map[string]int64  `json:"updatedAt"`        // Unix ms of each source's last refresh
+}
+
+type server struct {
+	client *data.Client
+
+	mu   sync.RWMutex
+	snap snapshot
+}
+
+// Run polls the gateway like the TUI does and serves the results on addr
+// until interrupted. An address without a host binds to loopback only.
+func Run(cfg config.Config, addr string) error {
+	if host, port, err := net.SplitHostPort(addr); err == nil && host == "" {
+		addr = net.JoinHostPort("127.0.0.1", port)
+	}
+	s := &server{
+		client: data.NewClient(cfg),
+		snap:   snapshot{Errors: map[string]string{}, UpdatedAt: map[string]int64{}},
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go s.poll(ctx, sessionsInterval, s.refreshSessions)
+	go s.poll(ctx, processesInterval, s.refreshProcesses)
+	go s.poll(ctx, healthInterval, s.refreshHealth)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api", s.handle(func(sn snapshot) any { return sn }))
+	mux.HandleFunc("GET /api/sessions", s.handle(func(sn snapshot) any { return sn.Sessions }))
+	mux.HandleFunc("GET /api/processes", s.handle(func(sn snapshot) any { return sn.Processes }))
+	mux.HandleFunc("GET /api/history", s.handle(func(sn snapshot) any { return sn.History }))
+	mux.HandleFunc("GET /api/health", s.handle(func(sn snapshot) any { return sn.Health }))
+
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdown)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving Commander API on http://%s/api\n", addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// poll runs refresh now and then every interval until ctx is done.
+func (s *server) poll(ctx context.Context, interval time.Duration, refresh func()) {
+	refresh()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			refresh()
+		}
+	}
+}
+
+// record stores the outcome of refreshing source.
+func (s *server) record(source string, err error, update func(*snapshot)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.snap.Errors[source] = err.Error()
+		return
+	}
+	delete(s.snap.Errors, source)
+	update(&s.snap)
+	s.snap.UpdatedAt[source] = time.Now().UnixMilli()
+}
+
+func (s *server) refreshSessions() {
+	sessions, err := s.client.FetchSessions()
+	if err != nil {
+		s.record("sessions", err, nil)
+		return
+	}
+	now := time.Now()
+	out := make([]sessionJSON, 0, len(sessions))
+	for _, sess := range sessions {
+		out = append(out, sessionJSON{
+			Session:         sess,
+			EffectiveStatus: sess.EffectiveStatus(),
+			Project:         sess.Project,
+			Activity:        s.client.SessionActivity(sess, now),
+		})
+	}
+	s.record("sessions", nil, func(sn *snapshot) { sn.Sessions = out })
+
+	runs, err := s.client.FetchArchivedRuns(sessions)
+	history := make([]archivedJSON, 0, len(runs))
+	for _, r := range runs {
+		history = append(history, archivedJSON{r.SessionID, r.Label, r.Size, r.ModifiedAt, r.Path, r.Format})
+	}
+	s.record("history", err, func(sn *snapshot) { sn.History = history })
+}
+
+func (s *server) refreshProcesses() {
+	procs, err := s.client.FetchProcesses()
+	out := make([]processJSON, 0, len(procs))
+	for _, p := range procs {
+		out = append(out, processJSON{p.SessionName, p.Status, p.Runtime, p.Command, p.PID, p.CPU, p.RSS})
+	}
+	s.record("processes", err, func(sn *snapshot) { sn.Processes = out })
+}
+
+func (s *server) refreshHealth() {
+	h, err := s.client.FetchGatewayHealth()
+	if h == nil {
+		h = &data.GatewayHealth{Ts: time.Now().UnixMilli()}
+	}
+	st := s.client.HealthStats()
+	health := &healthJSON{
+		GatewayHealth: h,
+		Level:         st.Level.String(),
+		Calls:         st.Calls,
+		Errors:        st.Errors,
+		ErrorRate:     st.ErrorRate,
+		P50Ms:         st.P50.Milliseconds(),
+		P95Ms:         st.P95.Milliseconds(),
+		P99Ms:         st.P99.Milliseconds(),
+		Consecutive:   st.Consecutive,
+	}
+	// The graded health is meaningful even when the heartbeat failed
+	s.record("health", nil, func(sn *snapshot) { sn.Health = health })
+	if err != nil {
+		s.mu.Lock()
+		s.snap.Errors["health"] = err.Error()
+		s.mu.Unlock()
+	}
+}
+
+// handle serves the part of the snapshot selected by pick as JSON.
+func (s *server) handle(pick func(snapshot) any) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		body, err := json.MarshalIndent(pick(s.snap), "", "  ")
+		s.mu.RUnlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(append(body, '\n'))
+	}
+}
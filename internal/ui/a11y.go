@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Accessibility mode (config.A11y) swaps emoji and glyph indicators for
+// words, marks focus and selection with text rather than color alone, and
+// stacks the panels so screen readers meet them in reading order.
+
+// statusMark returns the indicator for a session status.
+func (m Model) statusMark(status string) string {
+	if m.cfg.A11y {
+		return fmt.Sprintf("%-9s", strings.ToUpper(status))
+	}
+	return m.sessionGlyph(status)
+}
+
+// processMark returns the indicator for a process status.
+func (m Model) processMark(status string) string {
+	if m.cfg.A11y {
+		return fmt.Sprintf("%-9s", strings.ToUpper(status))
+	}
+	return processIndicator(status)
+}
+
+// cursorMark returns the row prefix for a list or form entry.
+func (m Model) cursorMark(selected bool) string {
+	switch {
+	case !selected:
+		return "  "
+	case m.cfg.A11y:
+		return "> "
+	default:
+		return "▸ "
+	}
+}
+
+// deco prefixes text with a decorative emoji, dropped in accessibility mode
+// and with ASCII glyphs.
+func (m Model) deco(emoji, text string) string {
+	if m.cfg.A11y || m.asciiGlyphs() {
+		return text
+	}
+	return emoji + " " + text
+}
+
+// activityColumn renders recent transcript activity: a sparkline, or the
+// event count in accessibility mode.
+func (m Model) activityColumn(counts []int) string {
+	if !m.cfg.A11y {
+		return sparkline(counts)
+	}
+	total := 0
+	for _, c := range counts {
+		total += c
+	}
+	return dimStyle.Render(fmt.Sprintf("%d events", total))
+}
+
+// viewLinear renders the list, then the log panel, then the status bar,
+// without borders.
+func (m Model) viewLinear() string {
+	listHeight := max(5, m.height/3)
+	var widget []string
+	widgetRows := 0
+	if m.state.MainWidget {
+		widget = []string{"-- Main session --", m.renderMainWidget()}
+		widgetRows = 1 + m.widgetHeight() // heading + widget
+	}
+	logHeight := max(5, m.height-listHeight-4-m.headerHeight()-widgetRows)
+
+	listHeading, logHeading := "List", "Logs"
+	if m.activePanel == panelList {
+		listHeading += " (focused)"
+	} else {
+		logHeading += " (focused)"
+	}
+
+	list := lipgloss.NewStyle().Width(m.width).MaxHeight(listHeight).
+		Render(m.renderListPanel(m.width, listHeight))
+	logs := lipgloss.NewStyle().Width(m.width).MaxHeight(logHeight).
+		Render(m.renderLogPanel(m.logWidth(), logHeight))
+
+	bottom := m.renderStatusBar()
+	switch {
+	case m.veiled:
+		logs, widget = m.renderVeil(m.logWidth(), 3), nil
+	case m.setup != nil:
+		bottom = m.renderGatewaySetup()
+	case m.spawning:
+		bottom = m.renderSpawnForm()
+	case m.confirmingSend:
+		bottom = m.renderSendPreview()
+	}
+	parts := []string{
+		m.renderHeader(),
+		"-- " + listHeading + " --", list,
+		"-- " + logHeading + " --", logs,
+	}
+	parts = append(parts, widget...)
+	return strings.Join(append(parts, bottom), "\n")
+}
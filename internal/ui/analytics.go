@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// analyticsTitle is the log view title of the archive analytics view.
+const analyticsTitle = "Archive analytics"
+
+// analyticsDays is how many recent days the per-day section covers.
+const analyticsDays = 14
+
+// archiveStatsMsg carries the indexer's latest aggregates.
+type archiveStatsMsg struct{ stats data.ArchiveStats }
+
+// indexArchive refreshes the archive index in the background unless a run
+// is already in progress. Unchanged transcripts are not re-read.
+func (m *Model) indexArchive() tea.Cmd {
+	if m.indexing {
+		return nil
+	}
+	m.indexing = true
+	client := m.client
+	runs := m.archived
+	return func() tea.Msg {
+		return archiveStatsMsg{client.IndexArchive(runs)}
+	}
+}
+
+// showAnalytics opens the analytics view, or a placeholder until the first
+// index completes.
+func (m *Model) showAnalytics() {
+	if m.archiveStats == nil {
+		m.showLogView(analyticsTitle, "Indexing archived transcripts…\n")
+		return
+	}
+	m.showLogView(analyticsTitle, renderAnalytics(*m.archiveStats))
+}
+
+// refreshAnalytics re-renders an open analytics view after an index run.
+func (m *Model) refreshAnalytics() {
+	if m.logView != analyticsTitle || m.archiveStats == nil {
+		return
+	}
+	m.refreshLogView(renderAnalytics(*m.archiveStats))
+}
+
+func renderAnalytics(st data.ArchiveStats) string {
+	if st.Runs == 0 {
+		return "No archived runs to analyze.\n"
+	}
+	var b strings.Builder
+	failedPct := 100 * float64(st.Failed) / float64(st.Runs)
+	b.WriteString(fmt.Sprintf("Runs:             %d\n", st.Runs))
+	b.WriteString(fmt.Sprintf("Succeeded/failed: %d / %d (%.0f%% failed)\n", st.Runs-st.Failed, st.Failed, failedPct))
+	b.WriteString(fmt.Sprintf("Median duration:  %s\n", formatDuration(st.MedianDuration)))
+	b.WriteString(fmt.Sprintf("Tokens:           %s total, %s per run\n",
+		formatTokens(st.TotalTokens), formatTokens(st.TotalTokens/st.Runs)))
+
+	days := st.Days
+	if len(days) > analyticsDays {
+		days = days[len(days)-analyticsDays:]
+	}
+	peakRuns, peakTokens := 0, 0.0
+	for _, d := range days {
+		peakRuns = max(peakRuns, d.Runs)
+		if perRun := float64(d.Tokens) / float64(d.Runs); perRun > peakTokens {
+			peakTokens = perRun
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\nRuns per day (last %d days with runs)\n", len(days)))
+	for _, d := range days {
+		bar := strings.Repeat("█", max(1, d.Runs*30/peakRuns))
+		line := fmt.Sprintf("  %s  %-30s %3d", d.Day, bar, d.Runs)
+		if d.Failed > 0 {
+			line += fmt.Sprintf("  (%d failed)", d.Failed)
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\nTokens per run over time\n")
+	for _, d := range days {
+		perRun := float64(d.Tokens) / float64(d.Runs)
+		width := 0
+		if peakTokens > 0 {
+			width = int(perRun * 30 / peakTokens)
+		}
+		b.WriteString(fmt.Sprintf("  %s  %-30s %s\n", d.Day, strings.Repeat("▪", width), formatTokens(int(perRun))))
+	}
+
+	if len(st.Tools) > 0 {
+		b.WriteString("\nMost used tools\n")
+		tools := st.Tools
+		if len(tools) > 10 {
+			tools = tools[:10]
+		}
+		for _, t := range tools {
+			bar := strings.Repeat("█", max(1, t.Calls*30/tools[0].Calls))
+			b.WriteString(fmt.Sprintf("  %-16s %-30s %d\n", t.Name, bar, t.Calls))
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\nIndexed %s\n", st.IndexedAt.Format("15:04:05")))
+	return b.String()
+}
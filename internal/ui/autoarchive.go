@@ -0,0 +1,144 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// lastActivity returns when s was last active (Unix ms), or 0 when the
+// gateway didn't say.
+func lastActivity(s data.Session, now time.Time) int64 {
+	if s.UpdatedAt > 0 {
+		return s.UpdatedAt
+	}
+	if s.AgeMs > 0 {
+		return now.UnixMilli() - s.AgeMs
+	}
+	return 0
+}
+
+// visibleSessions returns the sessions that aren't archived.
+func (m Model) visibleSessions() []data.Session {
+	if len(m.state.Archived) == 0 {
+		return m.sessions
+	}
+	var out []data.Session
+	for _, s := range m.sessions {
+		if _, archived := m.state.Archived[s.Key]; !archived {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// unarchiveActive brings back archived sessions that have been active
+// since they were archived, and forgets sessions the gateway no longer
+// lists.
+func (m *Model) unarchiveActive() {
+	if len(m.state.Archived) == 0 {
+		return
+	}
+	now := time.Now()
+	listed := make(map[string]bool, len(m.sessions))
+	changed := false
+	for _, s := range m.sessions {
+		listed[s.Key] = true
+		at, ok := m.state.Archived[s.Key]
+		// A minute's slack, as activity derived from ageMs drifts
+		if ok && lastActivity(s, now) > at+time.Minute.Milliseconds() {
+			delete(m.state.Archived, s.Key)
+			changed = true
+		}
+	}
+	for key := range m.state.Archived {
+		if !listed[key] {
+			delete(m.state.Archived, key)
+			changed = true
+		}
+	}
+	if changed {
+		m.state.Save()
+	}
+}
+
+// idleSessions returns the sessions the auto-archive policy applies to:
+// not running, not the main session, and idle for longer than configured.
+func (m Model) idleSessions(now time.Time) []data.Session {
+	idle := m.cfg.AutoArchive.Idle
+	main, _ := mainSession(m.sessions)
+	var out []data.Session
+	for _, s := range m.visibleSessions() {
+		at := lastActivity(s, now)
+		if at == 0 || s.Key == main.Key || s.EffectiveStatus() == "running" || m.archiveOffered[s.Key] {
+			continue
+		}
+		if now.Sub(time.UnixMilli(at)) > idle {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// autoArchive applies the auto-archive policy after a session refresh:
+// idle sessions are archived at once, or with ask set, offered for
+// archiving once per run.
+func (m *Model) autoArchive() {
+	if m.cfg.AutoArchive.Idle <= 0 {
+		return
+	}
+	idle := m.idleSessions(time.Now())
+	if len(idle) == 0 {
+		return
+	}
+	if !m.cfg.AutoArchive.Ask {
+		m.archiveSessions(idle)
+		m.setStatus(fmt.Sprintf("archived %d sessions idle over %s; they are in History", len(idle), formatDuration(m.cfg.AutoArchive.Idle)))
+		return
+	}
+	// Don't take the keyboard from a prompt in use
+	if m.pending != nil || m.panicking || m.typing() {
+		return
+	}
+	for _, s := range idle {
+		m.archiveOffered[s.Key] = true
+	}
+	prompt := fmt.Sprintf("Archive %d sessions idle over %s?", len(idle), formatDuration(m.cfg.AutoArchive.Idle))
+	m.pending = &confirmation{prompt: prompt, run: func(m *Model) tea.Cmd {
+		m.archiveSessions(idle)
+		m.setStatus(fmt.Sprintf("archived %d sessions; they are in History", len(idle)))
+		return m.scanArchive()
+	}}
+}
+
+// archiveSelected archives the session under the cursor.
+func (m *Model) archiveSelected() tea.Cmd {
+	ss := m.filteredSessions()
+	if m.activeTab != tabSessions || m.sessionCursor >= len(ss) {
+		m.setStatus("archive: select a session")
+		return nil
+	}
+	s := ss[m.sessionCursor]
+	m.archiveSessions([]data.Session{s})
+	m.setStatus("archived " + sessionDisplayName(s) + "; it is in History until it is active again")
+	return m.scanArchive()
+}
+
+// archiveSessions hides ss from the Sessions tab, which lists their
+// transcripts in History instead, and keeps the cursor in range.
+func (m *Model) archiveSessions(ss []data.Session) {
+	if m.state.Archived == nil {
+		m.state.Archived = make(map[string]int64)
+	}
+	now := time.Now()
+	for _, s := range ss {
+		m.state.Archived[s.Key] = lastActivity(s, now)
+	}
+	m.state.Save()
+	if n := len(m.filteredSessions()); m.sessionCursor >= n {
+		m.sessionCursor = max(0, n-1)
+	}
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// bulkTaskDoneMsg reports the outcome of one task in a bulk spawn.
+type bulkTaskDoneMsg struct {
+	index int
+	err   error
+}
+
+// bulkSpawn tracks the progress of spawning every task in a task list,
+// at most list.Concurrency at a time.
+type bulkSpawn struct {
+	path    string
+	list    *data.TaskList
+	status  []string // pending, spawning, done, failed
+	errs    []string
+	next    int // index of the next task to start
+	running int
+
+	// deadline is the expected duration of each task, recorded for its
+	// label once it has spawned; 0 for none
+	deadline time.Duration
+}
+
+func (b *bulkSpawn) finished() bool {
+	return b.next >= len(b.list.Tasks) && b.running == 0
+}
+
+func (b *bulkSpawn) counts() (done, failed int) {
+	for _, st := range b.status {
+		switch st {
+		case "done":
+			done++
+		case "failed":
+			failed++
+		}
+	}
+	return done, failed
+}
+
+// startBulkSpawn loads the task list at path and starts the first batch.
+func (m *Model) startBulkSpawn(path string) tea.Cmd {
+	path = config.ExpandHome(path)
+	list, err := data.LoadTaskList(path)
+	if err != nil {
+		m.setStatus("bulk spawn: " + err.Error())
+		return nil
+	}
+	taken := m.takenLabels()
+	for i, t := range list.Tasks {
+		if t.Label == "" {
+			list.Tasks[i].Label = autoLabel(t.Prompt, taken)
+		}
+	}
+	return m.runBulkSpawn(path, list)
+}
+
+// runBulkSpawn spawns the tasks in list, showing progress under the name
+// path in the log panel.
+func (m *Model) runBulkSpawn(path string, list *data.TaskList) tea.Cmd {
+	m.bulk = &bulkSpawn{
+		path:   path,
+		list:   list,
+		status: make([]string, len(list.Tasks)),
+		errs:   make([]string, len(list.Tasks)),
+	}
+	for i := range m.bulk.status {
+		m.bulk.status[i] = "pending"
+	}
+	cmds := m.nextBulkTasks()
+	m.showLogView("Bulk spawn", m.renderBulkTable())
+	if m.bulk.finished() {
+		m.setStatus("bulk spawn: no task could be started")
+	}
+	return tea.Batch(cmds...)
+}
+
+// nextBulkTasks starts pending tasks until the concurrency limit is reached.
+func (m *Model) nextBulkTasks() []tea.Cmd {
+	b := m.bulk
+	var cmds []tea.Cmd
+	for b.running < b.list.Concurrency && b.next < len(b.list.Tasks) {
+		i := b.next
+		b.next++
+		task := b.list.Tasks[i]
+
+		parentID := m.bulkParent(task.Parent)
+		if parentID == "" {
+			b.status[i] = "failed"
+			b.errs[i] = "no parent session " + task.Parent
+			continue
+		}
+
+		b.status[i] = "spawning"
+		b.running++
+		client := m.client
+		cmds = append(cmds, func() tea.Msg {
+			_, err := client.SpawnSession(parentID, task.Prompt, task.Model, task.Label, task.Env)
+			return bulkTaskDoneMsg{index: i, err: err}
+		})
+	}
+	return cmds
+}
+
+// bulkParent resolves a task's parent (session key or label) to a session
+// ID, defaulting to the first eligible parent, usually the main session.
+func (m *Model) bulkParent(parent string) string {
+	candidates := spawnParentCandidates(m.sessions)
+	if parent == "" {
+		if len(candidates) == 0 {
+			return ""
+		}
+		return candidates[0].SessionID
+	}
+	for _, s := range candidates {
+		if s.Key == parent || s.Label == parent || s.SessionID == parent {
+			return s.SessionID
+		}
+	}
+	return ""
+}
+
+// handleBulkTaskDone records a finished task and starts the next ones.
+func (m *Model) handleBulkTaskDone(msg bulkTaskDoneMsg) tea.Cmd {
+	b := m.bulk
+	if b == nil || msg.index >= len(b.status) {
+		return nil
+	}
+	b.running--
+	if msg.err != nil {
+		b.status[msg.index] = "failed"
+		b.errs[msg.index] = msg.err.Error()
+	} else {
+		b.status[msg.index] = "done"
+		if b.deadline > 0 {
+			m.setDeadline(b.list.Tasks[msg.index].Label, b.deadline)
+		}
+	}
+
+	cmds := m.nextBulkTasks()
+	if m.logView == "Bulk spawn" {
+		m.refreshLogView(m.renderBulkTable())
+	}
+	if b.finished() {
+		done, failed := b.counts()
+		m.setStatus(fmt.Sprintf("bulk spawn finished: %d spawned, %d failed", done, failed))
+		cmds = append(cmds, m.fetchSessions)
+	}
+	return tea.Batch(cmds...)
+}
+
+// renderBulkTable renders the progress table shown in the log panel.
+func (m Model) renderBulkTable() string {
+	b := m.bulk
+	done, failed := b.counts()
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%s — %d tasks, concurrency %d\n", filepath.Base(b.path), len(b.list.Tasks), b.list.Concurrency))
+	sb.WriteString(fmt.Sprintf("%d spawned, %d failed, %d running, %d pending\n\n",
+		done, failed, b.running, len(b.list.Tasks)-b.next))
+
+	for i, t := range b.list.Tasks {
+		var mark string
+		switch {
+		case m.cfg.A11y:
+			mark = " "
+		case b.status[i] == "done":
+			mark = "✓"
+		case b.status[i] == "failed":
+			mark = "✗"
+		case b.status[i] == "spawning":
+			mark = "…"
+		default:
+			mark = "·"
+		}
+		label := t.Label
+		if label == "" {
+			label = strings.SplitN(t.Prompt, "\n", 2)[0]
+		}
+		if len(label) > 40 {
+			label = label[:37] + "..."
+		}
+		model := "(default)"
+		if t.Model != "" {
+			model = data.ModelAlias(t.Model)
+		}
+		sb.WriteString(fmt.Sprintf(" %s %3d  %-9s %-40s %s\n", mark, i+1, b.status[i], label, model))
+		if b.errs[i] != "" {
+			sb.WriteString("         " + b.errs[i] + "\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// busyCheckLimit is how many recent messages are fetched to tell whether a
+// session is in the middle of a tool call.
+const busyCheckLimit = 10
+
+// queuedSend is a message held back until its target finishes a tool call.
+type queuedSend struct {
+	key       string
+	sessionID string
+	target    string // display name
+	text      string
+	tool      string // the tool that was running when it was queued
+}
+
+// busyCheckMsg reports the tool a message target is running, if any.
+type busyCheckMsg struct {
+	send queuedSend
+	err  error
+}
+
+// queuedReadyMsg carries the queued sends whose targets are no longer in a
+// tool call.
+type queuedReadyMsg struct{ ready []queuedSend }
+
+// queuedSentMsg reports the delivery of a queued send.
+type queuedSentMsg struct {
+	send queuedSend
+	err  error
+}
+
+// pendingTool returns the name of the tool call at the end of msgs that has
+// no result yet, or "" when the session isn't waiting on a tool.
+func pendingTool(msgs []data.HistoryMessage) string {
+	results := 0
+	for i := len(msgs) - 1; i >= 0; i-- {
+		switch msgs[i].Role {
+		case "toolResult":
+			results++
+		case "toolUse":
+			if results == 0 {
+				if msgs[i].ToolName == "" {
+					return "a tool"
+				}
+				return msgs[i].ToolName
+			}
+			results--
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// sendChecked sends text to the message target, first checking whether a
+// running target is in the middle of a tool call, which a message may
+// interrupt or queue behind.
+func (m *Model) sendChecked(text string) tea.Cmd {
+	var target data.Session
+	for _, s := range m.sessions {
+		if s.SessionID == m.msgTarget {
+			target = s
+		}
+	}
+	if target.EffectiveStatus() != "running" {
+		return m.sendMessage(text)
+	}
+	send := queuedSend{key: m.msgTargetKey, sessionID: m.msgTarget, target: m.msgTargetName, text: text}
+	m.setStatus(fmt.Sprintf("checking what %s is doing…", send.target))
+	client := m.client
+	return func() tea.Msg {
+		msgs, err := client.FetchSessionMessages(send.key, busyCheckLimit, send.sessionID)
+		send.tool = pendingTool(msgs)
+		return busyCheckMsg{send: send, err: err}
+	}
+}
+
+// handleBusyCheck sends the message when its target isn't running a tool,
+// and otherwise asks whether to queue it, send it anyway, or cancel.
+func (m *Model) handleBusyCheck(msg busyCheckMsg) tea.Cmd {
+	if msg.send.sessionID != m.msgTarget || msg.send.text != m.msgInput.Value() {
+		return nil // the composer has moved on
+	}
+	if msg.err != nil {
+		// Not knowing is no reason to hold the message back
+		cmd := m.sendMessage(msg.send.text)
+		m.setStatus("couldn't check " + msg.send.target + " for a running tool: " + msg.err.Error())
+		return cmd
+	}
+	if msg.send.tool == "" {
+		m.setStatus("")
+		return m.sendMessage(msg.send.text)
+	}
+	m.setStatus("")
+	m.busySend = &msg.send
+	return nil
+}
+
+// handleBusySend handles keys while asking about a target that is running a
+// tool: q queues the message until the tool finishes, s sends it now, n or
+// esc goes back to the composer.
+func (m *Model) handleBusySend(msg tea.KeyMsg) tea.Cmd {
+	send := *m.busySend
+	switch msg.String() {
+	case "q":
+		m.busySend = nil
+		m.queuedSends = append(m.queuedSends, send)
+		m.msgInput.SetValue("")
+		m.setStatus(m.deco("⏳", fmt.Sprintf("queued for %s until %s finishes", send.target, send.tool)))
+	case "s", "y", "enter":
+		m.busySend = nil
+		return m.sendMessage(send.text)
+	case "n", "esc":
+		// Back to the composer with the text intact
+		m.busySend = nil
+		m.messaging = true
+		m.msgInput.Focus()
+		return textinput.Blink
+	}
+	return nil
+}
+
+// renderBusySend shows the queue/send/cancel choice for the status bar.
+func (m Model) renderBusySend() string {
+	return statusThinking.Render(fmt.Sprintf("%s is running %s; a message now may interrupt it or wait behind it.", m.busySend.target, m.busySend.tool)) +
+		dimStyle.Render("  q:queue until done  s:send now  n/esc:cancel")
+}
+
+// checkQueued looks for queued sends whose targets have finished their tool
+// call.
+func (m Model) checkQueued() tea.Cmd {
+	if len(m.queuedSends) == 0 {
+		return nil
+	}
+	queued := append([]queuedSend(nil), m.queuedSends...)
+	client := m.client
+	return func() tea.Msg {
+		var ready []queuedSend
+		for _, q := range queued {
+			msgs, err := client.FetchSessionMessages(q.key, busyCheckLimit, q.sessionID)
+			if err == nil && pendingTool(msgs) == "" {
+				ready = append(ready, q)
+			}
+		}
+		return queuedReadyMsg{ready}
+	}
+}
+
+// sendQueued delivers the queued sends that are ready, dropping them from
+// the queue.
+func (m *Model) sendQueued(ready []queuedSend) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, r := range ready {
+		for i, q := range m.queuedSends {
+			if q == r {
+				m.queuedSends = append(m.queuedSends[:i], m.queuedSends[i+1:]...)
+				cmds = append(cmds, deliverQueued(m.client, r))
+				break
+			}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// deliverQueued sends a queued message.
+func deliverQueued(client *data.Client, q queuedSend) tea.Cmd {
+	return func() tea.Msg {
+		_, err := client.SendMessage(q.sessionID, q.text)
+		return queuedSentMsg{send: q, err: err}
+	}
+}
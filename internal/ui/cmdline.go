@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// cmdlineUsage lists the ":" commands, for a command the line doesn't know.
+const cmdlineUsage = "commands: <n>  q  filter <query>  tab <name>  kill <n> [signal]  timeline [hours]  ignored  unignore <n|all>  spawn [-m model] [-l label] [-d 45m] [-e KEY=value] <task>"
+
+// fieldEquals matches field=value in a typed filter, which the filter
+// syntax writes field:value.
+var fieldEquals = regexp.MustCompile(`(?i)(^|\s)(-?[a-z]+)=`)
+
+// runCommandLine runs an ex-style command typed at the ":" prompt. A bare
+// number is a jump and is handled by the prompt itself.
+func (m *Model) runCommandLine(line string) tea.Cmd {
+	name, arg, _ := strings.Cut(strings.TrimSpace(line), " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case "q", "q!", "qa", "quit":
+		return tea.Quit
+	case "f", "filter":
+		m.filter = fieldEquals.ReplaceAllString(arg, "$1$2:")
+		m.searchInput.SetValue(m.filter)
+		m.setCursor(0)
+		return nil
+	case "tab":
+		i := config.StartTabIndex(arg)
+		if i < 0 {
+			m.setStatus("tabs: " + strings.Join(config.StartTabs, ", "))
+			return nil
+		}
+		m.activeTab = i
+		return nil
+	case "kill":
+		return m.killByIndex(arg)
+	case "timeline":
+		span, ok := parseTimelineHours(arg)
+		if !ok {
+			m.setStatus("usage: :timeline [hours, or a duration like 90m or 2d]")
+			return nil
+		}
+		return m.openTimeline(span)
+	case "ignored":
+		m.showIgnored()
+		return nil
+	case "unignore":
+		return m.unignore(arg)
+	case "spawn":
+		if arg == "" {
+			return m.openSpawnForm()
+		}
+		return m.spawnInline(arg)
+	}
+	m.setStatus(fmt.Sprintf("unknown command %q; %s", name, cmdlineUsage))
+	return nil
+}
+
+// killByIndex handles ":kill <n> [signal]": it selects process n of the
+// Processes list and kills it as x would, with signal preselected.
+func (m *Model) killByIndex(arg string) tea.Cmd {
+	fields := strings.Fields(arg)
+	if len(fields) == 0 || len(fields) > 2 {
+		m.setStatus("usage: :kill <n> [TERM|INT|HUP|KILL]")
+		return nil
+	}
+	pp := m.filteredProcesses()
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n < 1 || n > len(pp) {
+		m.setStatus("no process " + fields[0])
+		return nil
+	}
+	signal := -1
+	if len(fields) == 2 {
+		name := strings.TrimPrefix(strings.ToUpper(fields[1]), "SIG")
+		for i, s := range data.KillSignals {
+			if s == name {
+				signal = i
+			}
+		}
+		if signal < 0 {
+			m.setStatus("signals: " + strings.Join(data.KillSignals, ", "))
+			return nil
+		}
+	}
+	m.activeTab = tabProcesses
+	m.processCursor = n - 1
+	m.activePanel = panelList
+	if !m.can(data.PermKill) {
+		return nil
+	}
+	if signal >= 0 && m.cfg.ConfirmLevel(config.ActionKill) == config.ConfirmNone {
+		return killProcess(m.client, pp[n-1], data.KillSignals[signal], false)
+	}
+	cmd := m.openKillMenu()
+	if signal >= 0 {
+		m.killSignal = signal
+	}
+	return cmd
+}
+
+// spawnInline handles ":spawn [-m model] [-l label] [-d duration]
+// [-e KEY=value]... <task>", spawning under the first spawn parent without
+// the form.
+func (m *Model) spawnInline(arg string) tea.Cmd {
+	if !m.can(data.PermSpawn) {
+		return nil
+	}
+	var model, label, due string
+	var pairs []string
+	words := strings.Fields(arg)
+	for len(words) > 1 {
+		var dst *string
+		switch words[0] {
+		case "-e", "--env":
+			pairs, words = append(pairs, words[1]), words[2:]
+			continue
+		case "-m", "--model":
+			dst = &model
+		case "-l", "--label":
+			dst = &label
+		case "-d", "--deadline":
+			dst = &due
+		}
+		if dst == nil {
+			break
+		}
+		*dst, words = words[1], words[2:]
+	}
+	prompt := strings.Join(words, " ")
+	if prompt == "" || strings.HasPrefix(prompt, "-") && len(words) == 1 {
+		m.setStatus("usage: :spawn [-m model] [-l label] [-d 45m] [-e KEY=value]... <task>")
+		return nil
+	}
+	env, err := data.ParseEnv(strings.Join(pairs, " "))
+	if err != nil {
+		m.setStatus("env: " + err.Error())
+		return nil
+	}
+	parents := spawnParentCandidates(m.sessions)
+	if len(parents) == 0 {
+		m.setStatus("no parent session found")
+		return nil
+	}
+	if label == "" {
+		label = autoLabel(prompt, m.takenLabels())
+	}
+	var deadline time.Duration
+	if due != "" {
+		d, ok := parseDeadline(due)
+		if !ok {
+			m.setStatus("invalid expected duration " + due + " (try 45m or 2h)")
+			return nil
+		}
+		deadline = d
+	}
+	m.rememberPrompt(prompt)
+	m.setStatus(m.deco("🚀", "spawning "+label+"..."))
+	parentID, client := parents[0].SessionID, m.client
+	return func() tea.Msg {
+		result, err := client.SpawnSession(parentID, prompt, model, label, env)
+		if err != nil {
+			return spawnFailedMsg{err}
+		}
+		return spawnSuccessMsg{result, label, deadline}
+	}
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// compactDoneMsg reports that the gateway finished compacting a session.
+type compactDoneMsg struct {
+	key  string
+	name string
+	err  error
+}
+
+// compaction is a compaction waiting for the refreshed session list that
+// shows its effect.
+type compaction struct {
+	name   string
+	before int  // context tokens before compacting
+	done   bool // the gateway has finished
+}
+
+// contextFill returns how full a session's context window is, or false
+// when the window size isn't known.
+func contextFill(s data.Session) (float64, bool) {
+	if s.ContextTokens <= 0 {
+		return 0, false
+	}
+	return float64(s.TotalTokens) / float64(s.ContextTokens), true
+}
+
+// contextColumn flags sessions whose context is nearly full in the list,
+// as a reminder that C compacts them.
+func contextColumn(s data.Session) string {
+	fill, ok := contextFill(s)
+	if !ok || fill < contextWarnRatio {
+		return ""
+	}
+	text := fmt.Sprintf("ctx %d%%", int(fill*100))
+	if fill >= 1 {
+		return statusFailed.Render(text)
+	}
+	return statusThinking.Render(text)
+}
+
+// compactSelected compacts the session under the cursor.
+func (m *Model) compactSelected() tea.Cmd {
+	if m.activeTab != tabSessions {
+		return nil
+	}
+	sessions := m.filteredSessions()
+	if m.sessionCursor >= len(sessions) {
+		return nil
+	}
+	s := sessions[m.sessionCursor]
+	return m.compactSession(s.Key, sessionDisplayName(s))
+}
+
+// compactSession asks the gateway to compact a session's context and
+// remembers its size, so the next session refresh can report the saving.
+func (m *Model) compactSession(key, name string) tea.Cmd {
+	if _, busy := m.compactions[key]; busy {
+		m.setStatus("already compacting " + name)
+		return nil
+	}
+	before := 0
+	if s, ok := m.sessionByKey(key); ok {
+		before = s.TotalTokens
+	}
+	if m.compactions == nil {
+		m.compactions = make(map[string]compaction)
+	}
+	m.compactions[key] = compaction{name: name, before: before}
+	m.setStatus(m.deco("⏳", "compacting "+name+"..."))
+	client := m.client
+	return func() tea.Msg {
+		return compactDoneMsg{key: key, name: name, err: client.CompactSession(key)}
+	}
+}
+
+// handleCompactDone refreshes the sessions after a compaction, or reports
+// why it failed.
+func (m *Model) handleCompactDone(msg compactDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		delete(m.compactions, msg.key)
+		m.setStatus(fmt.Sprintf("compact %s: %v", msg.name, msg.err))
+		return nil
+	}
+	if c, ok := m.compactions[msg.key]; ok {
+		c.done = true
+		m.compactions[msg.key] = c
+	}
+	return m.fetchSessions
+}
+
+// reportCompactions shows the before and after context size of finished
+// compactions once the refreshed session list has arrived.
+func (m *Model) reportCompactions() {
+	for key, c := range m.compactions {
+		if !c.done {
+			continue
+		}
+		delete(m.compactions, key)
+		s, ok := m.sessionByKey(key)
+		if !ok {
+			m.setStatus("compacted " + c.name)
+			continue
+		}
+		text := fmt.Sprintf("compacted %s: %s → %s context tokens", c.name, formatTokens(c.before), formatTokens(s.TotalTokens))
+		if s.ContextTokens > 0 {
+			text += fmt.Sprintf(" of %s", formatTokens(s.ContextTokens))
+		}
+		m.setStatus(m.deco("🗜", text))
+	}
+}
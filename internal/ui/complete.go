@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// slashCommands are offered when completing a leading "/" in the message
+// composer: Commander's own composerCommands plus the chat commands OpenClaw
+// agents understand.
+var slashCommands = []string{
+	"/abort", "/compact", "/help", "/later", "/model", "/new", "/reset",
+	"/status", "/stop", "/summarize", "/think", "/verbose",
+}
+
+// maxCompletionsShown caps the candidate list shown in the status bar.
+const maxCompletionsShown = 8
+
+// complete performs tab completion on the word before the cursor of ti:
+// a leading "/" completes slash commands, "@" completes session labels, and
+// words containing "/" or starting with "~" or "." complete file paths.
+// It reports whether the word was completable, and when several candidates
+// remain it returns them for display.
+func complete(ti *textinput.Model, sessions []data.Session) (bool, []string) {
+	value := []rune(ti.Value())
+	pos := min(ti.Position(), len(value))
+	start := pos
+	for start > 0 && value[start-1] != ' ' && value[start-1] != '\n' {
+		start--
+	}
+	word := string(value[start:pos])
+	if word == "" {
+		return false, nil
+	}
+
+	var candidates []string
+	suffix := " "
+	switch {
+	case strings.HasPrefix(word, "@"):
+		candidates = completeLabels(word[1:], sessions)
+	case strings.HasPrefix(word, "/") && start == 0 && !strings.Contains(word[1:], "/"):
+		candidates = completePrefix(word, slashCommands)
+		if len(candidates) == 0 {
+			candidates = completePath(word)
+			suffix = ""
+		}
+	case strings.IndexFunc(word, isPathSeparator) >= 0 || strings.HasPrefix(word, "~") || strings.HasPrefix(word, "."):
+		candidates = completePath(word)
+		suffix = ""
+	default:
+		return false, nil
+	}
+	if len(candidates) == 0 {
+		return false, nil
+	}
+
+	replacement := commonPrefix(candidates)
+	if len(candidates) == 1 {
+		replacement += suffix
+	}
+	if !strings.HasPrefix(word, "@") || len(candidates) == 1 {
+		newValue := string(value[:start]) + replacement + string(value[pos:])
+		ti.SetValue(newValue)
+		ti.SetCursor(start + len([]rune(replacement)))
+	}
+
+	if len(candidates) == 1 {
+		return true, nil
+	}
+	shown := make([]string, 0, maxCompletionsShown)
+	for _, c := range candidates {
+		if len(shown) == maxCompletionsShown {
+			break
+		}
+		shown = append(shown, filepath.Base(strings.TrimSuffix(c, "/")))
+	}
+	return true, shown
+}
+
+func completePrefix(prefix string, options []string) []string {
+	var out []string
+	for _, o := range options {
+		if strings.HasPrefix(o, prefix) {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// completeLabels matches session labels (or display names) by prefix,
+// case-insensitively.
+func completeLabels(prefix string, sessions []data.Session) []string {
+	seen := make(map[string]bool)
+	var out []string
+	lower := strings.ToLower(prefix)
+	for _, s := range sessions {
+		name := sessionDisplayName(s)
+		if name == "" || strings.Contains(name, " ") || seen[name] {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(name), lower) {
+			seen[name] = true
+			out = append(out, name)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// isPathSeparator reports whether r separates path elements: "/" anywhere,
+// and the backslash as well on Windows.
+func isPathSeparator(r rune) bool {
+	return r == '/' || r == filepath.Separator
+}
+
+// completePath lists filesystem entries matching word. Directories get a
+// trailing "/" so completion can continue into them; "~" is kept as typed.
+func completePath(word string) []string {
+	dirPart, base := "", word
+	if i := strings.LastIndexFunc(word, isPathSeparator); i >= 0 {
+		dirPart, base = word[:i+1], word[i+1:]
+	}
+	dir := dirPart
+	if dir == "" {
+		dir = "."
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		if strings.HasPrefix(dir, "~") && len(dir) > 1 && isPathSeparator(rune(dir[1])) {
+			dir = filepath.Join(home, dir[2:])
+		} else if word == "~" {
+			return []string{"~/"}
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var out []string
+	for _, e := range entries {
+		name := e.Name()
+		if !strings.HasPrefix(name, base) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(base, ".")) {
+			continue
+		}
+		if e.IsDir() {
+			name += "/"
+		}
+		out = append(out, dirPart+name)
+	}
+	return out
+}
+
+// commonPrefix returns the longest prefix shared by all candidates.
+func commonPrefix(candidates []string) string {
+	prefix := candidates[0]
+	for _, c := range candidates[1:] {
+		for !strings.HasPrefix(c, prefix) {
+			_, size := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-size]
+		}
+	}
+	return prefix
+}
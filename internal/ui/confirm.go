@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// confirmation is a destructive action waiting for the user's go-ahead.
+type confirmation struct {
+	prompt string               // what will happen, e.g. "Abort main#7bb3?"
+	phrase string               // text that must be typed to proceed; "" accepts y
+	run    func(*Model) tea.Cmd // the action itself
+}
+
+// runs wraps a ready command as a confirmation action.
+func runs(cmd tea.Cmd) func(*Model) tea.Cmd {
+	return func(*Model) tea.Cmd { return cmd }
+}
+
+// confirmAction runs the action named by action (see config.ActionKill
+// etc.) on target as the confirmation policy for the gateway says: at once,
+// after y, or after typing target.
+func (m *Model) confirmAction(action, prompt, target string, run func(*Model) tea.Cmd) tea.Cmd {
+	switch m.cfg.ConfirmLevel(action) {
+	case config.ConfirmNone:
+		return run(m)
+	case config.ConfirmType:
+		return m.confirmTyped(prompt, target, run)
+	}
+	m.pending = &confirmation{prompt: prompt, run: run}
+	return nil
+}
+
+// confirmTyped asks for phrase to be typed before running the action. It is
+// used on its own and as the second step of the actions whose y/n prompt is
+// built in (the kill menu, retention plan, and delivery preview).
+func (m *Model) confirmTyped(prompt, phrase string, run func(*Model) tea.Cmd) tea.Cmd {
+	m.pending = &confirmation{prompt: prompt, phrase: phrase, run: run}
+	m.confirmInput.SetValue("")
+	m.confirmInput.Focus()
+	return textinput.Blink
+}
+
+// requiresTyping reports whether action needs its target typed after the
+// built-in y/n prompt.
+func (m Model) requiresTyping(action string) bool {
+	return m.cfg.ConfirmLevel(action) == config.ConfirmType
+}
+
+// handleConfirmation handles keys while a confirmation is pending.
+func (m *Model) handleConfirmation(msg tea.KeyMsg) tea.Cmd {
+	p := m.pending
+	if p.phrase == "" {
+		switch {
+		case key.Matches(msg, keys.ConfirmY), key.Matches(msg, keys.Enter):
+			m.pending = nil
+			return p.run(m)
+		case key.Matches(msg, keys.ConfirmN), key.Matches(msg, keys.Escape):
+			m.pending = nil
+			m.setStatus("cancelled")
+		}
+		return nil
+	}
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.pending = nil
+		m.setStatus("cancelled")
+		return nil
+	case key.Matches(msg, keys.Enter):
+		m.pending = nil
+		if strings.TrimSpace(m.confirmInput.Value()) != p.phrase {
+			m.setStatus("cancelled: typed name did not match " + p.phrase)
+			return nil
+		}
+		return p.run(m)
+	}
+	var cmd tea.Cmd
+	m.confirmInput, cmd = m.confirmInput.Update(msg)
+	return cmd
+}
+
+// renderConfirmation shows the pending confirmation for the status bar.
+func (m Model) renderConfirmation() string {
+	p := m.pending
+	if p.phrase == "" {
+		return statusThinking.Render(p.prompt + " [y/n]")
+	}
+	return statusFailed.Render(fmt.Sprintf("%s Type %s to confirm: ", p.prompt, p.phrase)) + m.confirmInput.View()
+}
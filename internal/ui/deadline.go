@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// parseDeadline parses an expected duration such as "45m", "2h", or "1h30m".
+func parseDeadline(s string) (time.Duration, bool) {
+	if d, ok := parseAge(s); ok && d > 0 {
+		return d, true
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, true
+	}
+	return 0, false
+}
+
+// setDeadline records that the task spawned with label is expected to finish
+// within d. Deadlines are persisted so they survive a restart.
+func (m *Model) setDeadline(label string, d time.Duration) {
+	if m.state.Deadlines == nil {
+		m.state.Deadlines = make(map[string]int64)
+	}
+	m.state.Deadlines[label] = time.Now().Add(d).UnixMilli()
+	m.state.Save()
+}
+
+// deadlineFor returns the due time tracked for a session's label.
+func (m Model) deadlineFor(s data.Session) (time.Time, bool) {
+	if s.Label == "" {
+		return time.Time{}, false
+	}
+	due, ok := m.state.Deadlines[s.Label]
+	return time.UnixMilli(due), ok
+}
+
+// checkDeadlines drops deadlines of tasks that completed or were archived
+// and raises a notification the first time a task runs past its deadline.
+func (m *Model) checkDeadlines() {
+	if len(m.state.Deadlines) == 0 {
+		return
+	}
+	changed := false
+	for _, r := range m.archived {
+		if _, ok := m.state.Deadlines[r.Label]; ok && r.Label != "" {
+			delete(m.state.Deadlines, r.Label)
+			changed = true
+		}
+	}
+	now := time.Now()
+	for _, s := range m.sessions {
+		due, ok := m.deadlineFor(s)
+		if !ok {
+			continue
+		}
+		if s.EffectiveStatus() == "completed" {
+			delete(m.state.Deadlines, s.Label)
+			changed = true
+			continue
+		}
+		if now.After(due) && !m.overdueNotified[s.Label] {
+			if m.overdueNotified == nil {
+				m.overdueNotified = make(map[string]bool)
+			}
+			m.overdueNotified[s.Label] = true
+			m.setStatus(m.deco("⏰", fmt.Sprintf("%s is past its deadline (%s overdue)", s.Label, formatDuration(now.Sub(due)))))
+		}
+	}
+	if changed {
+		m.state.Save()
+	}
+}
+
+// deadlineColumn renders the countdown or overdue indicator for a session
+// row, or "" when it has no deadline.
+func (m Model) deadlineColumn(s data.Session) string {
+	due, ok := m.deadlineFor(s)
+	if !ok || s.EffectiveStatus() == "completed" {
+		return ""
+	}
+	left := time.Until(due)
+	if left < 0 {
+		return statusFailed.Render(m.deco("⚠", "OVERDUE "+formatDuration(-left)))
+	}
+	return statusThinking.Render(m.deco("⏱", "due "+formatDuration(left)))
+}
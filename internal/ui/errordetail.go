@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+const errorDetailTitle = "Error details"
+
+// showErrorDetail opens the full text of the last error in the log panel,
+// with the request that failed and what to try next. The error stays
+// available after the status bar moves on, until a newer one replaces it
+// or esc dismisses it.
+func (m *Model) showErrorDetail() {
+	if m.detailErr == nil {
+		m.setStatus("no error to show")
+		return
+	}
+	m.showLogView(errorDetailTitle, m.formatErrorDetail(m.detailErr, m.detailReq))
+}
+
+// noteErr ties the status-bar message to err and keeps err, with the most
+// recent failed request, which is the one behind it, for the detail view.
+func (m *Model) noteErr(err error) {
+	m.lastErr = err
+	m.detailErr = err
+	m.detailReq = nil
+	if m.client == nil {
+		return
+	}
+	trace := m.client.Trace()
+	for i := len(trace) - 1; i >= 0; i-- {
+		if trace[i].Failed {
+			req := trace[i]
+			m.detailReq = &req
+			break
+		}
+	}
+}
+
+// dismissError clears the status-bar message and forgets the last error.
+func (m *Model) dismissError() {
+	m.setStatus("")
+	m.detailErr = nil
+	m.detailReq = nil
+}
+
+// formatErrorDetail renders err in full, with req, the request that failed,
+// when known.
+func (m Model) formatErrorDetail(err error, req *data.TraceEntry) string {
+	var b strings.Builder
+	kind := data.KindOf(err)
+	b.WriteString("─── ERROR ───\n")
+	b.WriteString(err.Error() + "\n\n")
+	fmt.Fprintf(&b, "Kind:     %s\n", kind)
+
+	var apiErr *data.APIError
+	if errors.As(err, &apiErr) {
+		fmt.Fprintf(&b, "Tool:     %s\n", apiErr.Tool)
+		if apiErr.Status != 0 {
+			fmt.Fprintf(&b, "Status:   %d\n", apiErr.Status)
+		}
+		if apiErr.Code != "" {
+			fmt.Fprintf(&b, "Code:     %s\n", apiErr.Code)
+		}
+		if apiErr.Message != "" {
+			fmt.Fprintf(&b, "Message:  %s\n", apiErr.Message)
+		}
+		if len(apiErr.Details) > 0 && string(apiErr.Details) != "null" {
+			var pretty bytes.Buffer
+			if json.Indent(&pretty, apiErr.Details, "  ", "  ") == nil {
+				b.WriteString("Details:\n  " + pretty.String() + "\n")
+			} else {
+				b.WriteString("Details:  " + string(apiErr.Details) + "\n")
+			}
+		}
+	}
+	fmt.Fprintf(&b, "Gateway:  %s (%s)\n", m.cfg.GatewayURL, transportName(m.cfg.Transport))
+
+	if req != nil {
+		b.WriteString("\n─── REQUEST ───\n")
+		fmt.Fprintf(&b, "%s  %s  args %d B  %s  %s\n", req.At.Format("15:04:05"), req.Tool, req.ArgsBytes,
+			req.Duration.Round(1e6), req.Status)
+	}
+
+	b.WriteString("\n─── WHAT TO TRY ───\n")
+	for _, tip := range m.errorRemedies(kind, apiErr) {
+		b.WriteString("• " + tip + "\n")
+	}
+	return b.String()
+}
+
+// errorRemedies suggests fixes for a failure of the given kind.
+func (m Model) errorRemedies(kind data.ErrorKind, apiErr *data.APIError) []string {
+	var tips []string
+	switch kind {
+	case data.ErrKindNetwork:
+		tips = append(tips,
+			"Check that the gateway is running and reachable at "+m.cfg.GatewayURL+"; H shows its health.",
+			"Point the Commander at another gateway with --url.")
+		if m.cfg.Proxy != "" {
+			tips = append(tips, "Requests go through the proxy "+m.cfg.Proxy+"; check that it is up, or drop --proxy.")
+		}
+	case data.ErrKindAuth:
+		tips = append(tips,
+			"Check the token: gateway.auth.token in "+shortenHome(config.OpenclawPath())+", OPENCLAW_GATEWAY_TOKEN, or --token, later ones winning.")
+		if apiErr != nil && apiErr.Tool != "" {
+			tips = append(tips, "If the token is valid, its scope may not cover "+apiErr.Tool+"; grant it in the gateway's tool policy or use a token that has it.")
+		}
+	case data.ErrKindParse:
+		tips = append(tips,
+			"The gateway answered in a shape the Commander doesn't understand; the gateway and Commander versions may not match (openclaw --version).",
+			"T shows the recent requests and their status.")
+	default:
+		msg := ""
+		if apiErr != nil {
+			msg = strings.ToLower(apiErr.Code + " " + apiErr.Message)
+		}
+		switch {
+		case strings.Contains(msg, "rate") || strings.Contains(msg, "429"):
+			tips = append(tips, "The request was rate limited; wait a moment before retrying.")
+		case strings.Contains(msg, "not found") || strings.Contains(msg, "not_found"):
+			tips = append(tips, "The session or tool no longer exists; refresh the list.")
+		default:
+			tips = append(tips, "The gateway ran the request and reported the failure above; the gateway's log has more.")
+		}
+	}
+	if m.lastRetry != nil {
+		tips = append(tips, "Press R to retry the failed fetch.")
+	}
+	return tips
+}
+
+// transportName names a configured transport, which is http when unset.
+func transportName(t string) string {
+	if t == "" {
+		return config.TransportHTTP
+	}
+	return t
+}
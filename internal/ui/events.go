@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// maxEvents is how many events the activity feed keeps.
+const maxEvents = 500
+
+// recordEvents adds events to the top of the activity feed, keeping the
+// cursor on the event it was on.
+func (m *Model) recordEvents(events []data.Event) {
+	if len(events) == 0 {
+		return
+	}
+	feed := make([]data.Event, 0, len(events)+len(m.events))
+	for i := len(events) - 1; i >= 0; i-- {
+		feed = append(feed, events[i])
+	}
+	feed = append(feed, m.events...)
+	if len(feed) > maxEvents {
+		feed = feed[:maxEvents]
+	}
+	m.events = feed
+	if m.eventCursor > 0 {
+		m.eventCursor = min(m.eventCursor+len(events), len(feed)-1)
+	}
+}
+
+func (m Model) filteredEvents() []data.Event {
+	if m.filter == "" {
+		return m.events
+	}
+	var out []data.Event
+	q := parseFilter(m.filter)
+	for _, e := range m.events {
+		if q.matches(eventFilterItem(e)) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func eventFilterItem(e data.Event) filterItem {
+	return filterItem{
+		text: []string{sessionDisplayName(e.Session), e.Session.Key, e.Detail},
+		fields: map[string]string{
+			"status":  string(e.Kind),
+			"channel": e.Session.Channel,
+			"model":   e.Session.Model,
+			"label":   e.Session.Label,
+		},
+		age:    time.Since(e.At),
+		hasAge: true,
+	}
+}
+
+// eventIcon marks an event's kind in the feed, spelled out in accessibility
+// mode.
+func (m Model) eventIcon(kind data.EventKind) string {
+	glyph, style := "•", dimStyle
+	switch kind {
+	case data.EventCreated:
+		glyph, style = "●", accentStyle
+	case data.EventCompleted:
+		glyph, style = "✓", statusRunning
+	case data.EventFailed:
+		glyph, style = "✗", statusFailed
+	case data.EventDenied:
+		glyph, style = "⊘", errorKindStyle(data.ErrKindAuth)
+	case data.EventMessage:
+		glyph, style = "✉", queryStyle
+	}
+	if m.cfg.A11y {
+		return style.Render(fmt.Sprintf("%-9s", kind))
+	}
+	return style.Render(glyph)
+}
+
+func (m Model) renderEventList(width, maxItems int) string {
+	events := m.filteredEvents()
+	if len(events) == 0 {
+		msg := "No activity yet — session starts, completions, failures, and channel messages appear here as they happen."
+		if m.filter != "" {
+			msg = "No activity matches the filter."
+		}
+		return m.renderEmptyState(msg)
+	}
+
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" Activity (%d events)", len(events))) +
+		listPosition(m.eventCursor, len(events), maxItems-1) + "\n")
+
+	start, end := listWindow(m.eventCursor, len(events), maxItems-1)
+	for i := start; i < end; i++ {
+		e := events[i]
+		name := sessionDisplayName(e.Session)
+		if len(name) > 24 {
+			name = name[:21] + "..."
+		}
+		line := fmt.Sprintf("%s%s %s %s %-24s", m.cursorMark(i == m.eventCursor), indexColumn(i, len(events)),
+			dimStyle.Render(e.At.Format("15:04:05")), m.eventIcon(e.Kind), name)
+		if detail := e.Detail; detail != "" {
+			if room := max(10, width-lipgloss.Width(line)-1); len(detail) > room {
+				detail = detail[:room-3] + "..."
+			}
+			line += " " + dimStyle.Render(detail)
+		}
+		if i == m.eventCursor {
+			line = selectedStyle.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
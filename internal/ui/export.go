@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// exportLogView writes the log panel exactly as rendered — after verbose
+// level, source filter, and compression are applied — to a file under
+// config.ExportDir, so a curated view can be attached to a bug report.
+// It returns "" when there is nothing to export.
+func (m Model) exportLogView() (string, error) {
+	if m.logContent == "" || m.logContent == "Loading..." {
+		return "", nil
+	}
+	dir := config.ExportDir()
+	if dir == "" {
+		return "", fmt.Errorf("cannot resolve home directory")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	title := m.logView
+	name := m.logView
+	switch {
+	case title != "":
+	case m.diffView:
+		title = "Diff: " + m.selectedLogID
+		name = m.selectedLogID + "-diff"
+	default:
+		title = strings.TrimSpace(data.StripANSI(m.logTitle()))
+		name = m.selectedLogID
+	}
+	now := time.Now()
+
+	var b strings.Builder
+	b.WriteString("# " + title + "\n")
+	if path := m.logPath(); m.logView == "" && path != "" {
+		b.WriteString("# transcript: " + path + "\n")
+	}
+	b.WriteString("# exported " + now.Format(time.RFC3339) + "\n")
+	if m.logView == "" && !m.diffView && m.selectedLogTab != tabProcesses {
+		source := m.sourceFilter
+		if source == "" {
+			source = "all"
+		}
+		b.WriteString(fmt.Sprintf("# verbose: %s  source: %s\n", m.verboseLevel, source))
+	}
+	b.WriteString("\n")
+	b.WriteString(strings.TrimRight(m.logContent, "\n") + "\n")
+
+	name = strings.Trim(unsafeFileChars.ReplaceAllString(name, "-"), "-")
+	if name == "" {
+		name = "log"
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.txt", name, now.Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// exportSessionsCSV writes the sessions in the list, with the current
+// filter and project view applied, as CSV under config.ExportDir for
+// usage reporting in a spreadsheet. It returns "" when there are no
+// sessions to export.
+func (m Model) exportSessionsCSV() (string, error) {
+	sessions := m.filteredSessions()
+	if len(sessions) == 0 {
+		return "", nil
+	}
+	dir := config.ExportDir()
+	if dir == "" {
+		return "", fmt.Errorf("cannot resolve home directory")
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, "sessions-"+time.Now().Format("20060102-150405")+".csv")
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", err
+	}
+	w := csv.NewWriter(f)
+	w.Write([]string{"key", "label", "model", "status", "inputTokens", "outputTokens", "totalTokens", "updatedAt"})
+	for _, s := range sessions {
+		updated := ""
+		if s.UpdatedAt > 0 {
+			updated = time.UnixMilli(s.UpdatedAt).Format(time.RFC3339)
+		}
+		w.Write([]string{
+			s.Key,
+			s.Label,
+			s.Model,
+			s.EffectiveStatus(),
+			strconv.Itoa(s.InputTokens),
+			strconv.Itoa(s.OutputTokens),
+			strconv.Itoa(s.TotalTokens),
+			updated,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
+
+// runExportedMsg reports pushing a run to its exporters.
+type runExportedMsg struct {
+	name  string
+	where []string
+	errs  []error
+}
+
+// exportRun offers to push the selected History run to the configured
+// exporters whose label pattern matches it, and reports false when none
+// does.
+func (m *Model) exportRun() bool {
+	runs := m.filteredArchived()
+	if m.historyCursor >= len(runs) {
+		return false
+	}
+	run := runs[m.historyCursor]
+	var exporters []config.Exporter
+	var targets []string
+	for _, e := range m.cfg.Exporters {
+		if !e.Matches(run.Label) {
+			continue
+		}
+		exporters = append(exporters, e)
+		switch e.Type {
+		case config.ExportS3:
+			targets = append(targets, "s3://"+e.Bucket)
+		case config.ExportGit:
+			targets = append(targets, "git "+shortenHome(e.Repo))
+		default:
+			targets = append(targets, e.Type+" dataset "+shortenHome(config.ExpandHome(e.Path)))
+		}
+	}
+	if len(exporters) == 0 {
+		return false
+	}
+	name := run.Label
+	if name == "" {
+		name = run.SessionID
+	}
+	name = strings.Trim(unsafeFileChars.ReplaceAllString(name, "-"), "-")
+	if name == "" {
+		name = "run"
+	}
+	name += "-" + time.UnixMilli(run.ModifiedAt).Format("20060102-150405")
+	client := m.client
+	m.pending = &confirmation{
+		prompt: fmt.Sprintf("Push %s to %s?", name, strings.Join(targets, ", ")),
+		run: func(m *Model) tea.Cmd {
+			m.setStatus("exporting " + name + "…")
+			return func() tea.Msg {
+				msg := runExportedMsg{name: name}
+				msgs, err := client.ReadTranscriptMessages(run.Path)
+				if err != nil {
+					msg.errs = append(msg.errs, err)
+					return msg
+				}
+				export := data.RunExport{Name: name, Transcript: run.Path, Summary: data.RunSummary(run, msgs), Messages: msgs}
+				for _, e := range exporters {
+					where, err := data.ExportRun(e, export)
+					if err != nil {
+						msg.errs = append(msg.errs, err)
+						continue
+					}
+					msg.where = append(msg.where, where)
+				}
+				return msg
+			}
+		},
+	}
+	return true
+}
+
+// handleRunExported reports where a run was pushed and what failed.
+func (m *Model) handleRunExported(msg runExportedMsg) {
+	switch {
+	case len(msg.errs) == 0:
+		m.setStatus(fmt.Sprintf("exported %s to %s", msg.name, strings.Join(msg.where, ", ")))
+	case len(msg.where) == 0:
+		m.setStatus(fmt.Sprintf("export %s: %v", msg.name, msg.errs[0]))
+		m.noteErr(msg.errs[0])
+	default:
+		m.setStatus(fmt.Sprintf("exported %s to %s; %d failed: %v", msg.name, strings.Join(msg.where, ", "), len(msg.errs), msg.errs[0]))
+	}
+}
@@ -0,0 +1,118 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxFavorites is how many sessions fit on the favorites bar, one per
+// alt+1..alt+9.
+const maxFavorites = 9
+
+// favoriteLabelWidth caps a session name on the favorites bar.
+const favoriteLabelWidth = 16
+
+// favoritesHeight is the height of the favorites bar above the fleet
+// summary: a line while any session is a favorite.
+func (m Model) favoritesHeight() int {
+	if len(m.state.Favorites) == 0 {
+		return 0
+	}
+	return 1
+}
+
+// toggleFavorite adds the selected session to the favorites bar, or takes
+// it off.
+func (m *Model) toggleFavorite() {
+	ss := m.filteredSessions()
+	if m.activeTab != tabSessions || m.sessionCursor >= len(ss) {
+		return
+	}
+	s := ss[m.sessionCursor]
+	for i, k := range m.state.Favorites {
+		if k == s.Key {
+			m.state.Favorites = append(m.state.Favorites[:i], m.state.Favorites[i+1:]...)
+			m.state.Save()
+			m.setStatus("removed " + sessionDisplayName(s) + " from favorites")
+			return
+		}
+	}
+	if len(m.state.Favorites) >= maxFavorites {
+		m.setStatus(fmt.Sprintf("the favorites bar is full (%d); remove one with * first", maxFavorites))
+		return
+	}
+	m.state.Favorites = append(m.state.Favorites, s.Key)
+	m.state.Save()
+	m.setStatus(fmt.Sprintf("favorite %d: %s (alt+%d)", len(m.state.Favorites), sessionDisplayName(s), len(m.state.Favorites)))
+}
+
+// jumpToFavorite selects favorite n (1-based) in the Sessions list and
+// opens its log.
+func (m *Model) jumpToFavorite(n int) tea.Cmd {
+	if n < 1 || n > len(m.state.Favorites) {
+		m.setStatus(fmt.Sprintf("no favorite %d; * adds the selected session", n))
+		return nil
+	}
+	s, ok := m.sessionByKey(m.state.Favorites[n-1])
+	if !ok {
+		m.setStatus(fmt.Sprintf("favorite %d isn't listed by the gateway", n))
+		return nil
+	}
+	m.activeTab = tabSessions
+	for i, fs := range m.filteredSessions() {
+		if fs.Key == s.Key {
+			m.sessionCursor = i
+		}
+	}
+	m.logTrail = nil
+	return m.openLog(s.Key, tabSessions)
+}
+
+// favoriteNumber parses alt+1..alt+9.
+func favoriteNumber(msg tea.KeyMsg) (int, bool) {
+	if !msg.Alt || msg.Type != tea.KeyRunes || len(msg.Runes) != 1 {
+		return 0, false
+	}
+	r := msg.Runes[0]
+	if r < '1' || r > '9' {
+		return 0, false
+	}
+	return int(r - '0'), true
+}
+
+// renderFavoritesBar draws the favorites as numbered chips colored by
+// their session's live status, the one in the log panel underlined.
+func (m Model) renderFavoritesBar() string {
+	chips := []string{dimStyle.Render(m.deco("★", "favorites"))}
+	for i, k := range m.state.Favorites {
+		name, status := k, "gone"
+		s, listed := m.sessionByKey(k)
+		if listed {
+			name, status = sessionDisplayName(s), s.EffectiveStatus()
+		}
+		if len([]rune(name)) > favoriteLabelWidth {
+			name = string([]rune(name)[:favoriteLabelWidth-1]) + "…"
+		}
+		chip := fmt.Sprintf("%d %s", i+1, name)
+		if m.cfg.A11y {
+			chips = append(chips, fmt.Sprintf("%s (%s)", chip, status))
+			continue
+		}
+		style := statusGlyphStyle(status)
+		if !listed {
+			style = dimStyle.Strikethrough(true)
+		}
+		if m.selectedLogTab == tabSessions && m.selectedLogID == k {
+			style = style.Underline(true)
+		}
+		chips = append(chips, style.Render(chip))
+	}
+	sep := "  "
+	if m.cfg.A11y {
+		sep = " | "
+	}
+	return lipgloss.NewStyle().MaxWidth(max(m.width, 1)).Render(" " + strings.Join(chips, sep))
+}
@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// filePreview is the popup over the log panel showing the current contents
+// of a file the agent wrote, cycling through the files written in the log.
+type filePreview struct {
+	paths     []string // written files, oldest first
+	i         int      // the one shown
+	content   string   // "" while loading
+	truncated bool
+	err       error
+	scroll    int
+}
+
+// filePreviewMsg carries the contents of a previewed file.
+type filePreviewMsg struct {
+	path      string
+	content   string
+	truncated bool
+	err       error
+}
+
+// openFilePreview shows the most recent file written in the open log.
+func (m *Model) openFilePreview() tea.Cmd {
+	if m.logView != "" || m.diffView || len(m.cachedMessages) == 0 {
+		m.setStatus("preview: open a session or run that wrote files")
+		return nil
+	}
+	paths := data.CollectOutputs(m.cachedMessages).Files
+	if len(paths) == 0 {
+		m.setStatus("preview: no files written in the loaded messages")
+		return nil
+	}
+	m.preview = &filePreview{paths: paths, i: len(paths) - 1}
+	return m.loadPreview()
+}
+
+// previewPath resolves the shown file's path: paths relative to the agent's
+// workspace are joined to it when the workspace is known.
+func (m Model) previewPath() string {
+	p := m.preview.paths[m.preview.i]
+	if !filepath.IsAbs(p) && !strings.HasPrefix(p, "~") && m.workspace != nil && m.workspaceFor == m.selectedLogID {
+		return filepath.Join(m.workspace.Path, p)
+	}
+	return p
+}
+
+// loadPreview reads the shown file.
+func (m *Model) loadPreview() tea.Cmd {
+	pv := m.preview
+	pv.content, pv.truncated, pv.err, pv.scroll = "", false, nil, 0
+	path := m.previewPath()
+	client := m.client
+	return func() tea.Msg {
+		content, truncated, err := client.ReadFilePreview(path)
+		return filePreviewMsg{path: path, content: content, truncated: truncated, err: err}
+	}
+}
+
+// handleFilePreview fills the popup, unless it has since moved to another
+// file or closed.
+func (m *Model) handleFilePreview(msg filePreviewMsg) {
+	if m.preview == nil || m.previewPath() != msg.path {
+		return
+	}
+	m.preview.content, m.preview.truncated, m.preview.err = msg.content, msg.truncated, msg.err
+	if msg.err == nil && msg.content == "" {
+		m.preview.content = "(empty file)"
+	}
+}
+
+// handlePreviewKey handles keys while the popup is open: ←/→ step through
+// the written files, ↑/↓ and pgup/pgdn scroll, r rereads, esc or W closes.
+func (m *Model) handlePreviewKey(msg tea.KeyMsg) tea.Cmd {
+	pv := m.preview
+	page := max(1, m.logViewHeight()-6)
+	switch msg.String() {
+	case "esc", "W", "q":
+		m.preview = nil
+	case "left", "h":
+		if pv.i > 0 {
+			pv.i--
+			return m.loadPreview()
+		}
+	case "right", "l":
+		if pv.i < len(pv.paths)-1 {
+			pv.i++
+			return m.loadPreview()
+		}
+	case "up", "k":
+		pv.scroll = max(0, pv.scroll-1)
+	case "down", "j":
+		pv.scroll = min(pv.scroll+1, strings.Count(pv.content, "\n"))
+	case "pgup", "ctrl+u":
+		pv.scroll = max(0, pv.scroll-page)
+	case "pgdown", "ctrl+d":
+		pv.scroll = min(pv.scroll+page, strings.Count(pv.content, "\n"))
+	case "r":
+		return m.loadPreview()
+	}
+	return nil
+}
+
+// renderFilePreview draws the popup in place of the log panel.
+func (m Model) renderFilePreview(width, height int) string {
+	pv := m.preview
+	var b strings.Builder
+	title := m.deco("📄", fmt.Sprintf("Preview %d/%d: ", pv.i+1, len(pv.paths))) + shortenHome(m.previewPath())
+	b.WriteString(titleStyle.Render(clipLine(title, width)) + "\n")
+	b.WriteString(dimStyle.Render("current contents, not as written  ←/→:file  ↑/↓:scroll  r:reload  esc:back to log") + "\n")
+
+	viewH := max(1, height-3)
+	var lines []string
+	switch {
+	case pv.err != nil:
+		lines = []string{statusFailed.Render("✗ " + pv.err.Error())}
+	case pv.content == "":
+		lines = []string{"Loading..."}
+	default:
+		lines = strings.Split(strings.TrimRight(pv.content, "\n"), "\n")
+		if pv.truncated {
+			lines = append(lines, dimStyle.Render(fmt.Sprintf("… (showing the first %s)", formatBytes(data.PreviewMaxBytes))))
+		}
+	}
+	scroll := min(pv.scroll, max(0, len(lines)-viewH))
+	gutter := len(fmt.Sprint(len(lines)))
+	for i := scroll; i < len(lines) && i < scroll+viewH; i++ {
+		line := strings.ReplaceAll(lines[i], "\t", "    ")
+		if pv.err == nil && pv.content != "" {
+			line = dimStyle.Render(fmt.Sprintf("%*d ", gutter, i+1)) + clipLine(line, width-gutter-1)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// clipLine cuts s to width runes, marking the cut with an ellipsis.
+func clipLine(s string, width int) string {
+	if r := []rune(s); width > 0 && len(r) > width {
+		return string(r[:width-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,268 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// filterQuery is a parsed search filter. Free-text terms and structured
+// operators are ANDed together, e.g. `status:failed model:opus age>1h docker`.
+//
+//	field:value   status, model, channel, kind, label, project, note (substring match)
+//	age>1h        also age<, age>=, age<= with s/m/h/d/w units
+//	on:tue        on a day: a date (2026-10-13), weekday, today, or yesterday
+//	after:<when>  at or after a day or time (2026-10-13t18:00); also before:
+//	-term         negates a term or field:value
+type filterQuery struct {
+	conds []filterCond
+}
+
+type filterCond struct {
+	field  string // "" for free text, "age" for age comparisons
+	value  string // lowercased
+	op     string // age comparison operator
+	age    time.Duration
+	from   time.Time // start of the day or time for on, after, and before
+	to     time.Time // end of the day for on
+	negate bool
+}
+
+// filterItem is the searchable view of one list row.
+type filterItem struct {
+	text   []string // fields searched by free text
+	fields map[string]string
+	age    time.Duration
+	hasAge bool
+}
+
+func parseFilter(s string) filterQuery {
+	var q filterQuery
+	for _, tok := range strings.Fields(strings.ToLower(s)) {
+		var c filterCond
+		if strings.HasPrefix(tok, "-") && len(tok) > 1 {
+			c.negate = true
+			tok = tok[1:]
+		}
+		if strings.HasPrefix(tok, "age") {
+			rest := tok[3:]
+			for _, op := range []string{">=", "<=", ">", "<"} {
+				if strings.HasPrefix(rest, op) {
+					if d, ok := parseAge(rest[len(op):]); ok {
+						c.field, c.op, c.age = "age", op, d
+					}
+					break
+				}
+			}
+			if c.field == "age" {
+				q.conds = append(q.conds, c)
+				continue
+			}
+		}
+		if field, value, ok := strings.Cut(tok, ":"); ok && value != "" {
+			switch field {
+			case "on", "after", "before":
+				if from, to, ok := parseWhen(value, time.Now()); ok {
+					c.field, c.from, c.to = field, from, to
+					q.conds = append(q.conds, c)
+					continue
+				}
+			case "status", "model", "channel", "kind", "label", "project", "note":
+				c.field, c.value = field, value
+				q.conds = append(q.conds, c)
+				continue
+			}
+		}
+		c.value = tok
+		q.conds = append(q.conds, c)
+	}
+	return q
+}
+
+// parseAge parses durations like "90s", "30m", "1h", "2d", "1w".
+func parseAge(s string) (time.Duration, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s[:len(s)-1], 64)
+	if err != nil {
+		return 0, false
+	}
+	var unit time.Duration
+	switch s[len(s)-1] {
+	case 's':
+		unit = time.Second
+	case 'm':
+		unit = time.Minute
+	case 'h':
+		unit = time.Hour
+	case 'd':
+		unit = 24 * time.Hour
+	case 'w':
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, false
+	}
+	return time.Duration(n * float64(unit)), true
+}
+
+// parseWhen parses a day or time for the on, after, and before operators,
+// returning when it starts and when its day ends. It understands dates
+// (2026-10-13), dates with a time (2026-10-13t18:00), weekday names for
+// the most recent such day, today, and yesterday.
+func parseWhen(s string, now time.Time) (from, to time.Time, ok bool) {
+	midnight := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	}
+	if t, err := time.ParseInLocation("2006-01-02t15:04", s, now.Location()); err == nil {
+		return t, midnight(t).AddDate(0, 0, 1), true
+	}
+	if t, err := time.ParseInLocation("2006-01-02", s, now.Location()); err == nil {
+		return t, t.AddDate(0, 0, 1), true
+	}
+	day := midnight(now)
+	switch s {
+	case "today":
+		return day, day.AddDate(0, 0, 1), true
+	case "yesterday":
+		return day.AddDate(0, 0, -1), day, true
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if s == name || s == name[:3] {
+			back := (int(now.Weekday()) - int(wd) + 7) % 7
+			from = day.AddDate(0, 0, -back)
+			return from, from.AddDate(0, 0, 1), true
+		}
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+func (q filterQuery) matches(item filterItem) bool {
+	for _, c := range q.conds {
+		if c.matches(item) == c.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func (c filterCond) matches(item filterItem) bool {
+	switch c.field {
+	case "":
+		for _, t := range item.text {
+			if strings.Contains(strings.ToLower(t), c.value) {
+				return true
+			}
+		}
+		return false
+	case "age":
+		if !item.hasAge {
+			return false
+		}
+		switch c.op {
+		case ">":
+			return item.age > c.age
+		case ">=":
+			return item.age >= c.age
+		case "<":
+			return item.age < c.age
+		default:
+			return item.age <= c.age
+		}
+	case "on", "after", "before":
+		if !item.hasAge {
+			return false
+		}
+		at := time.Now().Add(-item.age)
+		switch c.field {
+		case "on":
+			return !at.Before(c.from) && at.Before(c.to)
+		case "after":
+			return !at.Before(c.from)
+		default:
+			return at.Before(c.from)
+		}
+	default:
+		v, ok := item.fields[c.field]
+		return ok && strings.Contains(strings.ToLower(v), c.value)
+	}
+}
+
+// sessionFilterItem builds the searchable view of a session; note is the
+// operator note attached to it, if any.
+func sessionFilterItem(s data.Session, note string) filterItem {
+	item := filterItem{
+		text: []string{s.Key, s.Model, s.Kind, s.DisplayName, s.Label, s.Channel, note},
+		fields: map[string]string{
+			"status":  s.EffectiveStatus(),
+			"model":   s.Model + " " + data.ModelAlias(s.Model),
+			"channel": s.Channel,
+			"kind":    s.Kind,
+			"label":   sessionDisplayName(s),
+			"project": s.Project,
+			"note":    note,
+		},
+	}
+	if s.AgeMs > 0 {
+		item.age, item.hasAge = time.Duration(s.AgeMs)*time.Millisecond, true
+	} else if s.UpdatedAt > 0 {
+		item.age, item.hasAge = time.Since(time.UnixMilli(s.UpdatedAt)), true
+	}
+	return item
+}
+
+func processFilterItem(p data.Process) filterItem {
+	item := filterItem{
+		text: []string{p.SessionName, p.Command},
+		fields: map[string]string{
+			"status": p.Status,
+			"label":  p.SessionName,
+		},
+	}
+	item.age, item.hasAge = parseRuntime(p.Runtime)
+	return item
+}
+
+func archivedFilterItem(a data.ArchivedRun) filterItem {
+	return filterItem{
+		text: []string{a.Label, a.SessionID, a.Note},
+		fields: map[string]string{
+			"status": "archived " + a.Outcome,
+			"label":  a.Label,
+		},
+		age:    time.Since(time.UnixMilli(a.ModifiedAt)),
+		hasAge: true,
+	}
+}
+
+// parseRuntime understands ps etime ("[[dd-]hh:]mm:ss") and short
+// durations like "5m" as reported in the process list file.
+func parseRuntime(s string) (time.Duration, bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, false
+	}
+	if d, ok := parseAge(s); ok {
+		return d, true
+	}
+	var days int
+	if dd, rest, ok := strings.Cut(s, "-"); ok {
+		n, err := strconv.Atoi(dd)
+		if err != nil {
+			return 0, false
+		}
+		days, s = n, rest
+	}
+	var total time.Duration
+	for _, part := range strings.Split(s, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, false
+		}
+		total = total*60 + time.Duration(n)
+	}
+	return total*time.Second + time.Duration(days)*24*time.Hour, true
+}
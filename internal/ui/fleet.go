@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fleetHeaderLines is the height of the fleet summary above the panels.
+const fleetHeaderLines = 1
+
+// headerHeight is the height of what sits above the panels.
+func (m Model) headerHeight() int {
+	return m.bannerHeight() + m.favoritesHeight() + fleetHeaderLines
+}
+
+// renderHeader is what sits above the panels: the reconnect banner while
+// the gateway is unreachable, the favorites bar if any, and the fleet
+// summary.
+func (m Model) renderHeader() string {
+	var lines []string
+	if m.bannerHeight() > 0 {
+		lines = append(lines, m.renderReconnectBanner())
+	}
+	if m.favoritesHeight() > 0 {
+		lines = append(lines, m.renderFavoritesBar())
+	}
+	return strings.Join(append(lines, m.renderFleetSummary()), "\n")
+}
+
+// fleetStatuses orders the session counts in the fleet summary.
+var fleetStatuses = []string{"running", "idle", "failed", "completed"}
+
+// renderFleetSummary is the one-line overview above the panels: sessions
+// by status, active processes, tokens used today, and gateway latency.
+func (m Model) renderFleetSummary() string {
+	counts := make(map[string]int)
+	for _, s := range m.sessions {
+		counts[s.EffectiveStatus()]++
+	}
+	var statuses []string
+	for _, st := range fleetStatuses {
+		if n := counts[st]; n > 0 {
+			statuses = append(statuses, statusStyle(st).Render(fmt.Sprintf("%d %s", n, st)))
+		}
+	}
+	sessions := fmt.Sprintf("%d sessions", len(m.sessions))
+	if len(statuses) > 0 {
+		sessions += " (" + strings.Join(statuses, ", ") + ")"
+	}
+
+	active := 0
+	for _, p := range m.processes {
+		if p.Status == "running" || p.Status == "active" {
+			active++
+		}
+	}
+
+	parts := []string{
+		sessions,
+		fmt.Sprintf("%d processes active", active),
+		formatTokens(m.tokensToday()) + " tokens today",
+		"gateway " + m.gatewayLatency(),
+	}
+	sep := dimStyle.Render(" │ ")
+	if m.cfg.A11y {
+		sep = " | "
+	}
+	return lipgloss.NewStyle().MaxWidth(max(m.width, 1)).Render(" " + strings.Join(parts, sep))
+}
+
+// tokensToday adds up the tokens of sessions active since midnight and of
+// archived runs that ended today. Session totals include any earlier use,
+// so this is an upper bound for long-lived sessions.
+func (m Model) tokensToday() int {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).UnixMilli()
+	total := 0
+	for _, s := range m.sessions {
+		if s.UpdatedAt >= midnight {
+			total += s.TotalTokens
+		}
+	}
+	if st := m.archiveStats; st != nil && len(st.Days) > 0 {
+		if last := st.Days[len(st.Days)-1]; last.Day == now.Format("2006-01-02") {
+			total += last.Tokens
+		}
+	}
+	return total
+}
+
+// gatewayLatency renders the gateway's recent p95 latency, or the last
+// heartbeat's when no calls have been graded yet.
+func (m Model) gatewayLatency() string {
+	switch {
+	case m.healthStats.Calls > 0:
+		return "p95 " + m.healthStats.P95.Round(time.Millisecond).String()
+	case m.health != nil && m.health.OK:
+		return fmt.Sprintf("%dms", m.health.DurationMs)
+	case m.health != nil:
+		return statusFailed.Render("down")
+	}
+	return dimStyle.Render("…")
+}
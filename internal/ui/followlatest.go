@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// followSlack is how much more recent another session's output must be
+// before follow-latest switches to it, as activity derived from ageMs drifts
+// between polls.
+const followSlack = 5 * time.Second
+
+// toggleFollowLatest turns follow-latest mode on or off. While on, the log panel
+// switches to whichever session most recently produced output.
+func (m *Model) toggleFollowLatest() tea.Cmd {
+	m.state.FollowLatest = !m.state.FollowLatest
+	m.state.Save()
+	if !m.state.FollowLatest {
+		m.setStatus("follow latest off")
+		return nil
+	}
+	m.setStatus("follow latest: the log panel tracks the session with the latest output")
+	return m.followLatest()
+}
+
+// latestSession returns the listed session with the most recent activity.
+func (m Model) latestSession(now time.Time) (data.Session, bool) {
+	var latest data.Session
+	var at int64
+	for _, s := range m.visibleSessions() {
+		if a := lastActivity(s, now); a > at {
+			latest, at = s, a
+		}
+	}
+	return latest, at > 0
+}
+
+// followLatest moves the selection and the log panel to the session with
+// the latest output when follow-latest is on, whichever tab is showing. It
+// leaves the panel alone while a prompt is open or it shows a view such as
+// a diff, search results, or a file preview.
+func (m *Model) followLatest() tea.Cmd {
+	if !m.state.FollowLatest || m.logView != "" || m.diffView || m.preview != nil ||
+		m.pending != nil || m.panicking || m.confirming || m.busySend != nil || m.typing() {
+		return nil
+	}
+	now := time.Now()
+	latest, ok := m.latestSession(now)
+	if !ok || (m.selectedLogTab == tabSessions && m.selectedLogID == latest.Key) {
+		return nil
+	}
+	if m.selectedLogTab == tabSessions {
+		if cur, ok := m.sessionByKey(m.selectedLogID); ok && lastActivity(latest, now) < lastActivity(cur, now)+followSlack.Milliseconds() {
+			return nil
+		}
+	}
+	m.activeTab = tabSessions
+	for i, s := range m.filteredSessions() {
+		if s.Key == latest.Key {
+			m.sessionCursor = i
+		}
+	}
+	m.logTrail = nil
+	return m.openLog(latest.Key, tabSessions)
+}
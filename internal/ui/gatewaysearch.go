@@ -0,0 +1,243 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// historySearchLimit caps the matches a gateway history search returns.
+const historySearchLimit = 100
+
+// historySearchMsg carries the matches of a gateway history search.
+type historySearchMsg struct {
+	query string
+	hits  []data.SearchHit
+	err   error
+}
+
+// logJump is a search match to scroll to once its log has loaded.
+type logJump struct {
+	snippet string
+	query   string
+	nth     int // which occurrence of query, when the snippet isn't found
+}
+
+// openHistorySearch asks for a query to search all session history with on
+// the gateway.
+func (m *Model) openHistorySearch() tea.Cmd {
+	m.historySearching = true
+	m.historySearchInput.SetValue(m.searchQuery)
+	m.historySearchInput.CursorEnd()
+	m.historySearchInput.Focus()
+	return textinput.Blink
+}
+
+// handleHistorySearchPrompt handles keys while the search prompt is open.
+func (m *Model) handleHistorySearchPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.historySearching = false
+		return nil
+	case key.Matches(msg, keys.Enter):
+		m.historySearching = false
+		query := strings.TrimSpace(m.historySearchInput.Value())
+		if query == "" {
+			return nil
+		}
+		m.searchQuery = query
+		m.setStatus(m.deco("🔎", "searching history on the gateway…"))
+		client := m.client
+		return func() tea.Msg {
+			hits, err := client.SearchHistory(query, historySearchLimit)
+			return historySearchMsg{query: query, hits: hits, err: err}
+		}
+	}
+	var cmd tea.Cmd
+	m.historySearchInput, cmd = m.historySearchInput.Update(msg)
+	return cmd
+}
+
+// handleHistorySearch shows search results in the log panel, grouped by
+// session.
+func (m *Model) handleHistorySearch(msg historySearchMsg) {
+	if msg.err != nil {
+		if errors.Is(msg.err, data.ErrSearchUnsupported) {
+			m.setStatus("history search: " + msg.err.Error() + "; / filters the loaded lists instead")
+			return
+		}
+		m.setStatus("history search: " + msg.err.Error())
+		m.noteErr(msg.err)
+		return
+	}
+	m.searchHits = msg.hits
+	m.showLogView(m.searchTitle(), m.renderSearchResults(msg.query, msg.hits))
+	m.activePanel = panelLogs
+	m.setStatus(fmt.Sprintf("%d matches for %q", len(msg.hits), msg.query))
+}
+
+// searchTitle is the log view title of the search results.
+func (m Model) searchTitle() string {
+	return "Search: " + m.searchQuery
+}
+
+// renderSearchResults lists hits under a header per session. Each hit is a
+// line starting with "  #", which is how the panel finds them again.
+func (m Model) renderSearchResults(query string, hits []data.SearchHit) string {
+	if len(hits) == 0 {
+		return fmt.Sprintf("No turns match %q.\n", query)
+	}
+	counts := make(map[string]int)
+	for _, h := range hits {
+		counts[h.SessionKey]++
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d matches for %q in %d sessions\n", len(hits), query, len(counts))
+	if len(hits) == historySearchLimit {
+		fmt.Fprintf(&b, "(showing the first %d; narrow the query for more)\n", historySearchLimit)
+	}
+	prev := ""
+	for _, h := range hits {
+		if h.SessionKey != prev {
+			prev = h.SessionKey
+			name := h.Label
+			if s, ok := m.sessionByKey(h.SessionKey); ok {
+				name = sessionDisplayName(s)
+			}
+			if name == "" {
+				name = h.SessionKey
+			}
+			fmt.Fprintf(&b, "\n─── %s — %d matches\n", name, counts[h.SessionKey])
+		}
+		when := ""
+		if h.Timestamp > 0 {
+			when = " " + time.UnixMilli(h.Timestamp).Format("Jan 02 15:04")
+		}
+		fmt.Fprintf(&b, "  #%d %s%s  %s\n", h.Index, h.Role, when, h.Snippet)
+	}
+	return b.String()
+}
+
+// activeSearchHit returns the first search match visible in the log panel,
+// or -1 when the panel doesn't show search results or none is on screen.
+func (m Model) activeSearchHit(width, viewH int) int {
+	if m.logView == "" || m.logView != m.searchTitle() || len(m.searchHits) == 0 {
+		return -1
+	}
+	hit, n := -1, 0
+	m.scanLogLines(width, viewH, func(line string, _ int, visible bool) bool {
+		if !strings.HasPrefix(line, "  #") {
+			return true
+		}
+		if visible {
+			hit = n
+			return false
+		}
+		n++
+		return true
+	})
+	if hit >= len(m.searchHits) {
+		return -1
+	}
+	return hit
+}
+
+// followSearchHit opens the session of the search match highlighted in the
+// log panel and scrolls to the matching turn once it has loaded. ok is
+// false when no match is on screen.
+func (m *Model) followSearchHit() (tea.Cmd, bool) {
+	i := m.activeSearchHit(m.logWidth(), max(1, m.logViewHeight()-3-m.logHeaderExtra()))
+	if i < 0 {
+		return nil, false
+	}
+	h := m.searchHits[i]
+	ref, ok := m.resolveSpawnLink(h.SessionKey)
+	if !ok && h.SessionID != "" {
+		ref, ok = m.resolveSpawnLink(h.SessionID)
+	}
+	if !ok {
+		m.setStatus("session " + h.SessionKey + " not found among sessions or history")
+		return nil, true
+	}
+	nth := 0
+	for _, other := range m.searchHits[:i] {
+		if other.SessionKey == h.SessionKey {
+			nth++
+		}
+	}
+	m.logJump = &logJump{snippet: h.Snippet, query: m.searchQuery, nth: nth}
+	return m.openLog(ref.id, ref.tab), true
+}
+
+// applyLogJump scrolls the freshly loaded log to the pending search match:
+// the line holding the longest piece of its snippet, or else the nth line
+// that mentions the query.
+func (m *Model) applyLogJump() {
+	j := m.logJump
+	if j == nil || m.logContent == "" || m.logContent == "Loading..." {
+		return
+	}
+	m.logJump = nil
+	lines := strings.Split(m.logContent, "\n")
+	target := -1
+	if piece := snippetPiece(j.snippet); len(piece) >= 8 {
+		for i, line := range lines {
+			if strings.Contains(strings.ToLower(line), piece) {
+				target = i
+				break
+			}
+		}
+	}
+	if target < 0 {
+		q, n := strings.ToLower(j.query), 0
+		for i, line := range lines {
+			if !strings.Contains(strings.ToLower(line), q) {
+				continue
+			}
+			target = i
+			if n == j.nth {
+				break
+			}
+			n++
+		}
+	}
+	if target < 0 {
+		m.setStatus("the match is older than the loaded messages; scroll up to load more")
+		return
+	}
+	w := m.logWidth()
+	row := 0
+	for _, line := range lines[:target] {
+		row++
+		if w > 0 && len(line) > w {
+			row += (len(line) - 1) / w
+		}
+	}
+	m.logFollow = false
+	m.logScrollPos = min(row, m.maxLogScroll(w))
+	m.setStatus("jumped to the match")
+}
+
+// snippetPiece returns the longest part of a search snippet between
+// ellipses, lowercased and cut to a length likely to sit on one log line.
+func snippetPiece(snippet string) string {
+	best := ""
+	for _, part := range strings.FieldsFunc(snippet, func(r rune) bool { return r == '…' }) {
+		for _, p := range strings.Split(part, "...") {
+			if p = strings.TrimSpace(p); len(p) > len(best) {
+				best = p
+			}
+		}
+	}
+	if r := []rune(best); len(r) > 40 {
+		best = string(r[:40])
+	}
+	return strings.ToLower(best)
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// asciiStatusGlyphs are the session status indicators in the ASCII set,
+// colored by statusGlyphStyle.
+var asciiStatusGlyphs = map[string]string{
+	"running":   "*",
+	"completed": "+",
+	"failed":    "x",
+	"idle":      "-",
+}
+
+// statusGlyphStyle returns the color of a session status indicator,
+// matching the emoji it stands in for.
+func statusGlyphStyle(status string) lipgloss.Style {
+	switch status {
+	case "running":
+		return statusThinking
+	case "completed":
+		return statusRunning
+	case "failed":
+		return statusFailed
+	}
+	return statusIdle
+}
+
+// asciiGlyphs reports whether indicators are drawn in colored ASCII rather
+// than emoji.
+func (m Model) asciiGlyphs() bool {
+	return m.cfg.Glyphs == config.GlyphsASCII
+}
+
+// sessionGlyph returns the indicator for a session status, two cells wide
+// like the emoji: the configured statusGlyphs entry, the ASCII glyph, or
+// the emoji.
+func (m Model) sessionGlyph(status string) string {
+	glyph, themed := m.cfg.StatusGlyphs[status]
+	if !themed && !m.asciiGlyphs() {
+		return sessionStatusEmoji(status)
+	}
+	if !themed {
+		glyph = asciiStatusGlyphs["idle"]
+		if g, ok := asciiStatusGlyphs[status]; ok {
+			glyph = g
+		}
+	}
+	if pad := 2 - lipgloss.Width(glyph); pad > 0 {
+		glyph += "  "[:pad]
+	}
+	return statusGlyphStyle(status).Render(glyph)
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// healthViewTitle is the log view title of the gateway health report.
+const healthViewTitle = "Gateway health"
+
+// gatewayToolsMsg carries the tools an MCP gateway lists, for the health
+// report.
+type gatewayToolsMsg struct {
+	tools []string
+	err   error
+}
+
+// showHealth opens the gateway health report. For MCP gateways the tools
+// the server offers are listed below it once they arrive.
+func (m *Model) showHealth() tea.Cmd {
+	m.showLogView(healthViewTitle, m.client.HealthStats().Report()+m.scopeReport())
+	if m.cfg.Transport != config.TransportMCP {
+		return nil
+	}
+	client := m.client
+	return func() tea.Msg {
+		tools, err := client.ListTools()
+		return gatewayToolsMsg{tools, err}
+	}
+}
+
+// handleGatewayTools appends the MCP tool list to an open health report.
+func (m *Model) handleGatewayTools(msg gatewayToolsMsg) {
+	if m.logView != healthViewTitle {
+		return
+	}
+	section := fmt.Sprintf("\nMCP tools (%d):\n  %s\n", len(msg.tools), strings.Join(msg.tools, "\n  "))
+	if msg.err != nil {
+		section = "\nMCP tools: " + msg.err.Error() + "\n"
+	}
+	m.refreshLogView(m.client.HealthStats().Report() + m.scopeReport() + section)
+}
+
+// healthStyle colors the status dot for a health level.
+func healthStyle(level data.HealthLevel) lipgloss.Style {
+	switch level {
+	case data.HealthHealthy:
+		return statusRunning
+	case data.HealthDegraded:
+		return statusThinking
+	case data.HealthSlow:
+		return lipgloss.NewStyle().Foreground(colorOrange)
+	case data.HealthDown:
+		return statusFailed
+	default:
+		return statusIdle
+	}
+}
+
+// healthIndicator renders the gateway level with its p95 latency and error
+// rate; `H` shows the full numbers.
+func (m Model) healthIndicator() string {
+	s := m.healthStats
+	level := s.Level
+	if level == data.HealthUnknown && m.health != nil && !m.health.OK {
+		level = data.HealthDown
+	}
+
+	st := healthStyle(level).Render("● " + level.String())
+	if m.cfg.A11y {
+		st = "gateway " + level.String()
+	}
+	detail := fmt.Sprintf("%dms", m.health.DurationMs)
+	if s.Calls > 0 {
+		detail = "p95 " + s.P95.Round(time.Millisecond).String()
+		if s.Errors > 0 {
+			detail += fmt.Sprintf(" %.0f%% err", s.ErrorRate*100)
+		}
+	}
+	return st + " " + dimStyle.Render(detail)
+}
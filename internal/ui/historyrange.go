@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// historyRanges are the History tab time ranges cycled with `r`. Other
+// ranges are set with the on:, after:, and before: filter operators.
+var historyRanges = []string{"all", "today", "24h", "week"}
+
+// nextHistoryRange returns the range after cur.
+func nextHistoryRange(cur string) string {
+	for i, r := range historyRanges {
+		if r == cur {
+			return historyRanges[(i+1)%len(historyRanges)]
+		}
+	}
+	return historyRanges[1]
+}
+
+// historyRangeStart returns when a range begins: midnight for today, a day
+// ago for 24h, and Monday's midnight for week. It is zero for all.
+func historyRangeStart(r string, now time.Time) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch r {
+	case "today":
+		return midnight
+	case "24h":
+		return now.Add(-24 * time.Hour)
+	case "week":
+		return midnight.AddDate(0, 0, -(int(now.Weekday())+6)%7)
+	}
+	return time.Time{}
+}
+
+// inHistoryRange returns the runs archived since the range began.
+func inHistoryRange(runs []data.ArchivedRun, r string, now time.Time) []data.ArchivedRun {
+	start := historyRangeStart(r, now)
+	if start.IsZero() {
+		return runs
+	}
+	var out []data.ArchivedRun
+	for _, run := range runs {
+		if run.ModifiedAt >= start.UnixMilli() {
+			out = append(out, run)
+		}
+	}
+	return out
+}
+
+// historyTime formats when a run was archived: its age, or with absolute
+// dates on, the time of day for today, the weekday within the last week,
+// and the date before that.
+func historyTime(modifiedAt int64, absolute bool, now time.Time) string {
+	t := time.UnixMilli(modifiedAt)
+	if !absolute {
+		return formatDuration(now.Sub(t))
+	}
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch {
+	case !t.Before(midnight):
+		return t.Format("15:04")
+	case !t.Before(midnight.AddDate(0, 0, -6)):
+		return t.Format("Mon 15:04")
+	default:
+		return t.Format("Jan 02 15:04")
+	}
+}
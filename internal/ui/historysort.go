@@ -0,0 +1,51 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// historySorts are the History tab orders cycled with `o`.
+var historySorts = []string{"newest", "oldest", "largest", "label"}
+
+// nextHistorySort returns the order after cur.
+func nextHistorySort(cur string) string {
+	for i, s := range historySorts {
+		if s == cur {
+			return historySorts[(i+1)%len(historySorts)]
+		}
+	}
+	return historySorts[1]
+}
+
+// resortArchived puts the archive in the History tab's order. It is called
+// when either changes, so the list isn't sorted again on every render.
+func (m *Model) resortArchived() {
+	m.sortedArchived = sortArchived(m.archived, m.state.HistorySort)
+}
+
+// sortArchived returns runs in the given order. Runs arrive newest first.
+func sortArchived(runs []data.ArchivedRun, order string) []data.ArchivedRun {
+	if order == "" || order == "newest" {
+		return runs
+	}
+	out := append([]data.ArchivedRun(nil), runs...)
+	switch order {
+	case "oldest":
+		sort.SliceStable(out, func(i, j int) bool { return out[i].ModifiedAt < out[j].ModifiedAt })
+	case "largest":
+		sort.SliceStable(out, func(i, j int) bool { return out[i].Size > out[j].Size })
+	case "label":
+		// Unlabeled runs sort last
+		sort.SliceStable(out, func(i, j int) bool {
+			a, b := strings.ToLower(out[i].Label), strings.ToLower(out[j].Label)
+			if (a == "") != (b == "") {
+				return b == ""
+			}
+			return a < b
+		})
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// ignoredView titles the list of hidden processes in the log panel.
+const ignoredView = "Hidden processes"
+
+// ignoredProcess reports whether p was found by scanning the OS and matches
+// a process marked as not an agent. Processes the agent lists are kept.
+func (m Model) ignoredProcess(p data.Process) bool {
+	if !strings.HasPrefix(p.SessionName, "pid:") || len(m.state.IgnoredProcesses) == 0 {
+		return false
+	}
+	fp := data.ProcessFingerprint(p.Command)
+	for _, ignored := range m.state.IgnoredProcesses {
+		if fp == ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// dropIgnored leaves the processes marked as not agents out of procs.
+func (m Model) dropIgnored(procs []data.Process) []data.Process {
+	if len(m.state.IgnoredProcesses) == 0 {
+		return procs
+	}
+	var kept []data.Process
+	for _, p := range procs {
+		if !m.ignoredProcess(p) {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+// ignoreProcess asks to mark the scanned process under the cursor as not
+// an agent, hiding it and any later process with the same fingerprint.
+func (m *Model) ignoreProcess() {
+	procs := m.filteredProcesses()
+	if m.activeTab != tabProcesses || m.processCursor >= len(procs) {
+		m.setStatus("not an agent: select a process")
+		return
+	}
+	p := procs[m.processCursor]
+	if !strings.HasPrefix(p.SessionName, "pid:") {
+		m.setStatus("only processes found by scanning the OS can be hidden; the agent lists this one")
+		return
+	}
+	fp := data.ProcessFingerprint(p.Command)
+	if fp == "" {
+		m.setStatus("no command line to recognize this process by")
+		return
+	}
+	m.pending = &confirmation{
+		prompt: fmt.Sprintf("Not an agent: hide processes like %q from now on?", clipLine(fp, 60)),
+		run: func(m *Model) tea.Cmd {
+			m.state.IgnoredProcesses = append(m.state.IgnoredProcesses, fp)
+			m.state.Save()
+			m.processes = m.dropIgnored(m.processes)
+			if n := len(m.filteredProcesses()); m.processCursor >= n {
+				m.processCursor = max(0, n-1)
+			}
+			m.setStatus("hidden; :ignored lists hidden processes, :unignore <n> brings one back")
+			return nil
+		},
+	}
+}
+
+// showIgnored lists the fingerprints of processes marked as not agents.
+func (m *Model) showIgnored() {
+	if len(m.state.IgnoredProcesses) == 0 {
+		m.setStatus("no processes are hidden; X on a scanned process hides it")
+		return
+	}
+	var b strings.Builder
+	b.WriteString("Scanned processes marked as not agents. :unignore <n> brings one back, :unignore all clears the list.\n\n")
+	for i, fp := range m.state.IgnoredProcesses {
+		fmt.Fprintf(&b, "%3d  %s\n", i+1, fp)
+	}
+	m.showLogView(ignoredView, b.String())
+}
+
+// unignore handles ":unignore <n|all>", forgetting hidden processes so
+// they show again from the next refresh.
+func (m *Model) unignore(arg string) tea.Cmd {
+	ignored := m.state.IgnoredProcesses
+	if arg == "all" {
+		m.state.IgnoredProcesses = nil
+	} else {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < 1 || n > len(ignored) {
+			m.setStatus("usage: :unignore <n|all>, numbered as :ignored lists them")
+			return nil
+		}
+		m.state.IgnoredProcesses = append(append([]string{}, ignored[:n-1]...), ignored[n:]...)
+	}
+	m.state.Save()
+	if m.logView == ignoredView {
+		if len(m.state.IgnoredProcesses) > 0 {
+			m.showIgnored()
+		} else {
+			m.showLogView(ignoredView, "No processes are hidden.\n")
+		}
+	}
+	m.setStatus(fmt.Sprintf("%d hidden processes left", len(m.state.IgnoredProcesses)))
+	return m.fetchProcesses
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"regexp"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// imageActionMsg reports the outcome of opening or copying an image path.
+type imageActionMsg struct {
+	status string
+	err    error
+}
+
+// compileImagePattern compiles the configured image path pattern, falling
+// back to the built-in one (Config.Validate rejects invalid patterns).
+func compileImagePattern(pattern string) *regexp.Regexp {
+	if pattern != "" {
+		if re, err := regexp.Compile(pattern); err == nil {
+			return re
+		}
+	}
+	return regexp.MustCompile(data.DefaultImagePathPattern)
+}
+
+// latestImage returns the most recent image path found in the open log.
+func (m Model) latestImage() (string, bool) {
+	if len(m.imagePaths) == 0 || m.logView != "" || m.diffView {
+		return "", false
+	}
+	return m.imagePaths[len(m.imagePaths)-1], true
+}
+
+// imageLine renders the latest image path in the open log with its
+// actions, or "" when there is none.
+func (m Model) imageLine() string {
+	path, ok := m.latestImage()
+	if !ok {
+		return ""
+	}
+	return dimStyle.Render(m.deco("🖼", "Image: ")) + shortenHome(path) + dimStyle.Render("  O:open  Y:copy path")
+}
+
+// openImage launches the latest image path with the desktop's viewer.
+func (m Model) openImage() tea.Cmd {
+	path, ok := m.latestImage()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := data.OpenFile(path); err != nil {
+			return imageActionMsg{err: err}
+		}
+		return imageActionMsg{status: "opened " + shortenHome(path)}
+	}
+}
+
+// copyImagePath puts the latest image path on the clipboard.
+func (m Model) copyImagePath() tea.Cmd {
+	path, ok := m.latestImage()
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := data.CopyToClipboard(path); err != nil {
+			return imageActionMsg{err: err}
+		}
+		return imageActionMsg{status: "copied " + shortenHome(path)}
+	}
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openJump shows the ":" prompt, for jumping to a list item by number or
+// typing a command (see runCommandLine).
+func (m *Model) openJump() tea.Cmd {
+	m.jumping = true
+	m.jumpFrom = m.currentCursor()
+	m.jumpInput.SetValue("")
+	m.jumpInput.Focus()
+	return textinput.Blink
+}
+
+// handleJump handles keys while the ":" prompt is open. The cursor follows
+// a number as it is typed; Enter keeps the selection or runs the command,
+// and Esc puts the cursor back where it was.
+func (m *Model) handleJump(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.jumping = false
+		m.setCursor(m.jumpFrom)
+		return nil
+	case key.Matches(msg, keys.Enter):
+		m.jumping = false
+		value := strings.TrimSpace(m.jumpInput.Value())
+		if value == "" {
+			return nil
+		}
+		if strings.Trim(value, "0123456789") != "" {
+			m.setCursor(m.jumpFrom)
+			return m.runCommandLine(value)
+		}
+		if n, ok := m.jumpTarget(); !ok {
+			m.setStatus("no item " + value)
+		} else {
+			m.setCursor(n - 1)
+			m.activePanel = panelList
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	m.jumpInput, cmd = m.jumpInput.Update(msg)
+	if n, ok := m.jumpTarget(); ok {
+		m.setCursor(n - 1)
+	}
+	return cmd
+}
+
+// jumpTarget returns the item number typed into the jump prompt, if it is
+// on the current list.
+func (m Model) jumpTarget() (int, bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(m.jumpInput.Value()))
+	if err != nil || n < 1 || n > m.filteredListLen() {
+		return 0, false
+	}
+	return n, true
+}
+
+// indexColumn renders the 1-based number of row i in an n-row list, padded
+// to the widest number.
+func indexColumn(i, n int) string {
+	width := len(strconv.Itoa(n))
+	return dimStyle.Render(strings.Repeat(" ", width-len(strconv.Itoa(i+1))) + strconv.Itoa(i+1))
+}
@@ -18,6 +18,7 @@ type keyMap struct {
 	Tab1     key.Binding
 	Tab2     key.Binding
 	Tab3     key.Binding
+	Tab4     key.Binding
 	ConfirmY key.Binding
 	ConfirmN key.Binding
 	Escape   key.Binding
@@ -25,6 +26,51 @@ type keyMap struct {
 	Verbose  key.Binding
 	SourceFilter key.Binding
 	Spawn    key.Binding
+	Purge    key.Binding
+	Diff     key.Binding
+	Retry    key.Binding
+	ErrorDetail key.Binding
+	BulkSpawn key.Binding
+	Panic    key.Binding
+	Export   key.Binding
+	ProcessInfo key.Binding
+	SortHistory key.Binding
+	HistoryRange key.Binding
+	HistoryDates key.Binding
+	Health      key.Binding
+	Project     key.Binding
+	MainWidget  key.Binding
+	Analytics   key.Binding
+	Note        key.Binding
+	Back        key.Binding
+	OpenImage   key.Binding
+	CopyPath    key.Binding
+	Pin         key.Binding
+	Unpin       key.Binding
+	Trace       key.Binding
+	Scheduled   key.Binding
+	Thinking    key.Binding
+	Jump        key.Binding
+	Outputs     key.Binding
+	Compact     key.Binding
+	Run         key.Binding
+	RestartPolicy key.Binding
+	RawLog      key.Binding
+	Archive     key.Binding
+	Compare     key.Binding
+	HistorySearch key.Binding
+	SplitLeft   key.Binding
+	SplitRight  key.Binding
+	TokenSplit  key.Binding
+	FollowLatest key.Binding
+	Preview     key.Binding
+	Lock        key.Binding
+	Tools       key.Binding
+	Favorite    key.Binding
+	CopyLogPath key.Binding
+	NotAgent    key.Binding
+	MessageLast key.Binding
+	Timeline    key.Binding
 }
 
 var keys = keyMap{
@@ -62,7 +108,7 @@ var keys = keyMap{
 	),
 	Kill: key.NewBinding(
 		key.WithKeys("x"),
-		key.WithHelp("x", "kill"),
+		key.WithHelp("x", "kill process/abort session"),
 	),
 	Quit: key.NewBinding(
 		key.WithKeys("q", "ctrl+c"),
@@ -88,6 +134,10 @@ var keys = keyMap{
 		key.WithKeys("3"),
 		key.WithHelp("3", "history"),
 	),
+	Tab4: key.NewBinding(
+		key.WithKeys("4"),
+		key.WithHelp("4", "activity"),
+	),
 	ConfirmY: key.NewBinding(
 		key.WithKeys("y"),
 		key.WithHelp("y", "confirm"),
@@ -116,4 +166,185 @@ var keys = keyMap{
 		key.WithKeys("s"),
 		key.WithHelp("s", "spawn"),
 	),
+	Purge: key.NewBinding(
+		key.WithKeys("P"),
+		key.WithHelp("P", "purge history"),
+	),
+	Diff: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "workspace diff"),
+	),
+	Retry: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "retry failed fetch"),
+	),
+	ErrorDetail: key.NewBinding(
+		key.WithKeys("E"),
+		key.WithHelp("E", "error details"),
+	),
+	BulkSpawn: key.NewBinding(
+		key.WithKeys("B"),
+		key.WithHelp("B", "bulk spawn"),
+	),
+	Panic: key.NewBinding(
+		key.WithKeys("ctrl+k"),
+		key.WithHelp("ctrl+k ×2", "emergency stop"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "export log view / sessions CSV"),
+	),
+	ProcessInfo: key.NewBinding(
+		key.WithKeys("i"),
+		key.WithHelp("i", "process details"),
+	),
+	SortHistory: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "sort history"),
+	),
+	HistoryRange: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "history time range"),
+	),
+	HistoryDates: key.NewBinding(
+		key.WithKeys("D"),
+		key.WithHelp("D", "toggle history dates"),
+	),
+	Health: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "gateway health"),
+	),
+	Project: key.NewBinding(
+		key.WithKeys("g"),
+		key.WithHelp("g", "project view"),
+	),
+	MainWidget: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "main session widget"),
+	),
+	Analytics: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "archive analytics"),
+	),
+	Note: key.NewBinding(
+		key.WithKeys("N"),
+		key.WithHelp("N", "session note, run label/note"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("backspace"),
+		key.WithHelp("⌫", "back to parent transcript"),
+	),
+	OpenImage: key.NewBinding(
+		key.WithKeys("O"),
+		key.WithHelp("O", "open image"),
+	),
+	CopyPath: key.NewBinding(
+		key.WithKeys("Y"),
+		key.WithHelp("Y", "copy image path"),
+	),
+	Pin: key.NewBinding(
+		key.WithKeys("p"),
+		key.WithHelp("p", "pin tool output"),
+	),
+	Unpin: key.NewBinding(
+		key.WithKeys("u"),
+		key.WithHelp("u", "clear pin"),
+	),
+	Trace: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "gateway request trace"),
+	),
+	Scheduled: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "scheduled sends"),
+	),
+	Thinking: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "expand thinking"),
+	),
+	Jump: key.NewBinding(
+		key.WithKeys(":"),
+		key.WithHelp(":", "jump to number"),
+	),
+	Outputs: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "run outputs"),
+	),
+	Compact: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "compact context"),
+	),
+	Run: key.NewBinding(
+		key.WithKeys("!"),
+		key.WithHelp("!", "run command in workspace"),
+	),
+	RestartPolicy: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "cycle restart policy"),
+	),
+	RawLog: key.NewBinding(
+		key.WithKeys("V"),
+		key.WithHelp("V", "toggle raw transcript"),
+	),
+	Archive: key.NewBinding(
+		key.WithKeys("z"),
+		key.WithHelp("z", "archive session"),
+	),
+	Compare: key.NewBinding(
+		key.WithKeys("K"),
+		key.WithHelp("K", "compare spawn matrix answers"),
+	),
+	HistorySearch: key.NewBinding(
+		key.WithKeys("S"),
+		key.WithHelp("S", "search history on gateway"),
+	),
+	SplitLeft: key.NewBinding(
+		key.WithKeys("ctrl+left"),
+		key.WithHelp("ctrl+←", "narrow list panel"),
+	),
+	SplitRight: key.NewBinding(
+		key.WithKeys("ctrl+right"),
+		key.WithHelp("ctrl+→", "widen list panel"),
+	),
+	TokenSplit: key.NewBinding(
+		key.WithKeys("I"),
+		key.WithHelp("I", "toggle input/output tokens"),
+	),
+	FollowLatest: key.NewBinding(
+		key.WithKeys("F"),
+		key.WithHelp("F", "follow latest session"),
+	),
+	Preview: key.NewBinding(
+		key.WithKeys("W"),
+		key.WithHelp("W", "preview written file"),
+	),
+	Lock: key.NewBinding(
+		key.WithKeys("b"),
+		key.WithHelp("b", "claim/release session"),
+	),
+	Tools: key.NewBinding(
+		key.WithKeys("G"),
+		key.WithHelp("G", "gateway tool catalog"),
+	),
+	Favorite: key.NewBinding(
+		key.WithKeys("*"),
+		key.WithHelp("*", "favorite session"),
+	),
+	CopyLogPath: key.NewBinding(
+		key.WithKeys("ctrl+y"),
+		key.WithHelp("ctrl+y", "copy transcript path"),
+	),
+	NotAgent: key.NewBinding(
+		key.WithKeys("X"),
+		key.WithHelp("X", "hide process: not an agent"),
+	),
+	// ctrl+m can't be told apart from Enter in a terminal
+	MessageLast: key.NewBinding(
+		key.WithKeys("alt+m"),
+		key.WithHelp("alt+m", "message last target again"),
+	),
+	Timeline: key.NewBinding(
+		key.WithKeys("U"),
+		key.WithHelp("U", "timeline across sessions"),
+	),
 }
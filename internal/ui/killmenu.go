@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// killDoneMsg reports the outcome of signaling a process.
+type killDoneMsg struct {
+	name   string
+	signal string
+	err    error
+}
+
+// defaultKillSignal picks the menu's initial signal for p: the killSignals
+// entry whose key is the longest match in the process name or command,
+// otherwise TERM.
+func (m Model) defaultKillSignal(p data.Process) int {
+	target := strings.ToLower(p.SessionName + " " + p.Command)
+	best, bestLen := "TERM", 0
+	for match, sig := range m.cfg.KillSignals {
+		if len(match) > bestLen && strings.Contains(target, strings.ToLower(match)) {
+			best, bestLen = strings.TrimPrefix(strings.ToUpper(sig), "SIG"), len(match)
+		}
+	}
+	for i, s := range data.KillSignals {
+		if s == best {
+			return i
+		}
+	}
+	return 0
+}
+
+// openKillMenu asks which signal to send to the selected process, or sends
+// the default signal at once when the confirm policy needs no confirmation.
+func (m *Model) openKillMenu() tea.Cmd {
+	pp := m.filteredProcesses()
+	if m.processCursor >= len(pp) {
+		return nil
+	}
+	p := pp[m.processCursor]
+	if m.cfg.ConfirmLevel(config.ActionKill) == config.ConfirmNone {
+		return killProcess(m.client, p, data.KillSignals[m.defaultKillSignal(p)], false)
+	}
+	m.confirming = true
+	m.killTarget = p
+	m.killSignal = m.defaultKillSignal(p)
+	m.killChildren = false
+	return nil
+}
+
+// handleKillMenu handles keys while the kill menu is open: left/right pick
+// the signal, c toggles children, y or enter sends, n or esc cancels.
+func (m *Model) handleKillMenu(msg tea.KeyMsg) tea.Cmd {
+	n := len(data.KillSignals)
+	switch msg.String() {
+	case "left", "h", "shift+tab":
+		m.killSignal = (m.killSignal + n - 1) % n
+	case "right", "l", "tab":
+		m.killSignal = (m.killSignal + 1) % n
+	case "c":
+		m.killChildren = !m.killChildren
+	case "y", "enter":
+		m.confirming = false
+		kill := killProcess(m.client, m.killTarget, data.KillSignals[m.killSignal], m.killChildren)
+		if m.requiresTyping(config.ActionKill) {
+			prompt := fmt.Sprintf("Send SIG%s to %s?", data.KillSignals[m.killSignal], m.killTarget.SessionName)
+			return m.confirmTyped(prompt, m.killTarget.SessionName, runs(kill))
+		}
+		return kill
+	case "n", "esc":
+		m.confirming = false
+	}
+	return nil
+}
+
+// abortSelected aborts the current run of the session under the cursor.
+func (m *Model) abortSelected() tea.Cmd {
+	ss := m.filteredSessions()
+	if m.sessionCursor >= len(ss) {
+		return nil
+	}
+	s := ss[m.sessionCursor]
+	return m.abortSession(s.Key, sessionDisplayName(s))
+}
+
+// abortSession aborts the current run of session key, named target, through
+// the gateway's sessions_abort tool, as the abort confirmation policy says.
+func (m *Model) abortSession(key, target string) tea.Cmd {
+	if !m.can(data.PermAbort) {
+		return nil
+	}
+	client := m.client
+	return m.confirmAction(config.ActionAbort, "Abort "+target+"?", target, runs(func() tea.Msg {
+		err := client.AbortSession(key)
+		if data.IsForbidden(err) {
+			return deniedMsg{data.PermAbort, err}
+		}
+		if err != nil {
+			return errMsg{err: fmt.Errorf("abort %s: %w", target, err)}
+		}
+		return slashDoneMsg{status: "aborted " + target}
+	}))
+}
+
+// killProcess sends signal to p, and to its descendants with children set.
+func killProcess(client *data.Client, p data.Process, signal string, children bool) tea.Cmd {
+	return func() tea.Msg {
+		return killDoneMsg{name: p.SessionName, signal: signal, err: client.SignalProcess(p, signal, children)}
+	}
+}
+
+// renderKillMenu shows the signal choice for the status bar.
+func (m Model) renderKillMenu() string {
+	var sigs []string
+	for i, s := range data.KillSignals {
+		if i == m.killSignal {
+			if m.cfg.A11y {
+				s = "[" + s + "]"
+			}
+			sigs = append(sigs, selectedStyle.Render(s))
+		} else {
+			sigs = append(sigs, dimStyle.Render(s))
+		}
+	}
+	children := "off"
+	if m.killChildren {
+		children = "on"
+	}
+	return statusThinking.Render(fmt.Sprintf("Kill %s with", m.killTarget.SessionName)) + " " +
+		strings.Join(sigs, " ") +
+		dimStyle.Render(fmt.Sprintf("  ←/→:signal  c:children(%s)  y/↵:send  n/esc:cancel", children))
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// locksMsg carries the session locks found in the lock directory.
+type locksMsg struct {
+	locks map[string]data.SessionLock
+	err   error
+}
+
+// fetchLocks reads the session locks, renewing the ones this Commander
+// holds before they expire. The held keys are copied here, since Update
+// changes heldLocks while the command runs.
+func (m Model) fetchLocks() tea.Cmd {
+	dir, operator := m.cfg.LockDir, m.cfg.Operator
+	held := make([]string, 0, len(m.heldLocks))
+	for key := range m.heldLocks {
+		held = append(held, key)
+	}
+	return func() tea.Msg {
+		locks, err := data.ReadLocks(dir)
+		if err != nil {
+			return locksMsg{err: err}
+		}
+		for _, key := range held {
+			l, ok := locks[key]
+			if ok && l.Operator != operator {
+				continue // taken over
+			}
+			if !ok || time.Since(time.UnixMilli(l.At)) > data.LockTTL/2 {
+				if l, err := data.ClaimLock(dir, key, operator, false); err == nil {
+					locks[key] = l
+				}
+			}
+		}
+		return locksMsg{locks: locks}
+	}
+}
+
+// handleLocks records the session locks. Locks this Commander held that
+// another operator has taken over are dropped.
+func (m *Model) handleLocks(msg locksMsg) {
+	if msg.err != nil {
+		return // keep the last known locks
+	}
+	m.locks = msg.locks
+	for key := range m.heldLocks {
+		if l, ok := m.locks[key]; !ok || l.Operator != m.cfg.Operator {
+			delete(m.heldLocks, key)
+		}
+	}
+}
+
+// lockedByOther returns the lock another operator holds on session key.
+func (m Model) lockedByOther(key string) (data.SessionLock, bool) {
+	l, ok := m.locks[key]
+	if !ok || l.Operator == m.cfg.Operator || l.Expired(time.Now()) {
+		return data.SessionLock{}, false
+	}
+	return l, true
+}
+
+// toggleLock claims the selected session, or releases it when this
+// Commander holds it. A session another operator holds is taken over after
+// a y/n confirmation.
+func (m *Model) toggleLock() tea.Cmd {
+	ss := m.filteredSessions()
+	if m.activeTab != tabSessions || m.sessionCursor >= len(ss) {
+		return nil
+	}
+	s := ss[m.sessionCursor]
+	name := sessionDisplayName(s)
+	if m.heldLocks[s.Key] {
+		if err := data.ReleaseLock(m.cfg.LockDir, s.Key, m.cfg.Operator); err != nil {
+			m.setStatus("release " + name + ": " + err.Error())
+			return nil
+		}
+		delete(m.heldLocks, s.Key)
+		delete(m.locks, s.Key)
+		m.setStatus("released " + name)
+		return nil
+	}
+	claim := func(force bool) func(*Model) tea.Cmd {
+		return func(m *Model) tea.Cmd {
+			l, err := data.ClaimLock(m.cfg.LockDir, s.Key, m.cfg.Operator, force)
+			var locked *data.LockedError
+			switch {
+			case errors.As(err, &locked):
+				m.locks[s.Key] = locked.Lock
+				m.setStatus(fmt.Sprintf("%s is being handled by %s", name, locked.Lock.Operator))
+			case err != nil:
+				m.setStatus("claim " + name + ": " + err.Error())
+			default:
+				m.locks[s.Key] = l
+				m.heldLocks[s.Key] = true
+				m.setStatus(m.deco("🔒", "you are handling "+name))
+			}
+			return nil
+		}
+	}
+	if l, ok := m.lockedByOther(s.Key); ok {
+		m.pending = &confirmation{
+			prompt: fmt.Sprintf("%s is being handled by %s (for %s); take it over?", name, l.Operator, formatDuration(time.Since(time.UnixMilli(l.At)))),
+			run:    claim(true),
+		}
+		return nil
+	}
+	return claim(false)(m)
+}
+
+// openComposer starts a message to s, first asking when another operator
+// is handling it.
+func (m *Model) openComposer(s data.Session, text string) tea.Cmd {
+	open := func(m *Model) tea.Cmd {
+		m.msgTarget = s.SessionID
+		m.msgTargetKey = s.Key
+		m.msgTargetName = sessionDisplayName(s)
+		m.msgTargetChannel = s.Channel
+		m.messaging = true
+		m.completions = nil
+		m.resetRecall()
+		if text != "" {
+			m.msgInput.SetValue(text)
+			m.msgInput.CursorEnd()
+		}
+		m.msgInput.Focus()
+		return textinput.Blink
+	}
+	if l, ok := m.lockedByOther(s.Key); ok {
+		m.pending = &confirmation{
+			prompt: fmt.Sprintf("%s is being handled by %s; message it anyway?", sessionDisplayName(s), l.Operator),
+			run:    open,
+		}
+		return nil
+	}
+	return open(m)
+}
+
+// lockColumn is the session row's badge naming who handles it, or "".
+func (m Model) lockColumn(s data.Session) string {
+	l, ok := m.locks[s.Key]
+	if !ok {
+		return ""
+	}
+	who := l.Operator
+	if who == m.cfg.Operator {
+		who = "you"
+	}
+	if m.cfg.A11y {
+		return "LOCKED " + who
+	}
+	return accentStyle.Render(m.deco("🔒", who))
+}
+
+// releaseLocks gives up the locks this Commander holds.
+func (m Model) releaseLocks() {
+	for key := range m.heldLocks {
+		data.ReleaseLock(m.cfg.LockDir, key, m.cfg.Operator)
+	}
+}
@@ -0,0 +1,371 @@
+package ui
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// logSpill is the older part of a log too large to keep in memory, written
+// to a temp file. Scrolling past the top of the log panel reads it back a
+// chunk at a time.
+type logSpill struct {
+	path  string
+	size  int64 // bytes of older content in the file
+	shown int64 // bytes of it read back into the log panel
+
+	// last is the newest session message on disk when the spill holds whole
+	// messages; fetches then format and keep only the ones after it. nil for
+	// a spill of plain content, such as a process log.
+	last *data.HistoryMessage
+	sum  [sha256.Size]byte // of the file's content, for a spill of plain content
+}
+
+// spillCut is how a fetch split a session's messages: the older ones go to
+// disk formatted, and only the newer ones are kept and shown in memory.
+type spillCut struct {
+	after *data.HistoryMessage // newest message on disk when the fetch started; nil to start the spill over
+	last  data.HistoryMessage  // newest message on disk with older written
+	older string               // formatting of the messages after after up to last
+}
+
+// spilledThrough returns the newest session message on disk, or nil when
+// the open log has no spill of whole messages.
+func (m Model) spilledThrough() *data.HistoryMessage {
+	if m.logSpill == nil || m.logSpill.last == nil {
+		return nil
+	}
+	last := *m.logSpill.last
+	return &last
+}
+
+// unspilled returns the index of the first message in msgs after after, the
+// newest message on disk. ok is false when after isn't among them, such as
+// once the fetch window has moved past it.
+func unspilled(msgs []data.HistoryMessage, after *data.HistoryMessage) (start int, ok bool) {
+	if after == nil {
+		return 0, false
+	}
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if sameHistoryMessage(msgs[i], *after) {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// sameHistoryMessage reports whether a and b are the same message of a
+// session's history.
+func sameHistoryMessage(a, b data.HistoryMessage) bool {
+	return a.Role == b.Role && a.Timestamp == b.Timestamp && a.ToolName == b.ToolName && a.Text == b.Text
+}
+
+// splitForSpill keeps the newest of msgs, whose text fits in limit bytes,
+// and formats the older ones from start on to be spilled. msgs before start
+// are already on disk, after being the newest of them; with after nil the
+// spill starts over from msgs[0]. cut is nil when everything fits and
+// nothing is on disk. A split never separates tool calls from their
+// results, which are formatted together.
+func splitForSpill(msgs []data.HistoryMessage, start int, after *data.HistoryMessage, limit int64, format func([]data.HistoryMessage) string) (kept []data.HistoryMessage, cut *spillCut) {
+	if after == nil {
+		start = 0
+	}
+	if limit <= 0 || start >= len(msgs) {
+		if after == nil {
+			return msgs, nil
+		}
+		return msgs[start:], &spillCut{after: after, last: *after}
+	}
+	k, size := len(msgs)-1, int64(0) // the newest message is always kept
+	for ; k > start; k-- {
+		size += int64(len(msgs[k].Text) + len(msgs[k].Thinking) + len(msgs[k].ToolArgs))
+		if size > limit {
+			break
+		}
+	}
+	for k > start && isToolMessage(msgs[k].Role) {
+		k-- // back to the message that made the calls
+	}
+	if k <= start {
+		if after == nil {
+			return msgs, nil
+		}
+		return msgs[start:], &spillCut{after: after, last: *after}
+	}
+	return msgs[k:], &spillCut{after: after, last: msgs[k-1], older: format(msgs[start:k])}
+}
+
+// spilledQuery returns the query of a log fetched as cut and content. It is
+// the first user message, which for a spill that only grew is on disk and
+// stays query, the one known before the fetch.
+func spilledQuery(query string, cut *spillCut, content string) string {
+	if cut != nil && cut.after != nil {
+		return query
+	}
+	if cut != nil {
+		if q := extractQuery(cut.older); q != "" {
+			return q
+		}
+	}
+	return extractQuery(content)
+}
+
+// isToolMessage reports whether a history message with role is a tool call
+// or result, which summary mode formats together with its neighbours.
+func isToolMessage(role string) bool {
+	return role == "toolUse" || role == "toolResult" || role == "tool"
+}
+
+// formatSpilled formats messages on their way to the spill as the log panel
+// shows them.
+func (m Model) formatSpilled(msgs []data.HistoryMessage) string {
+	return compressLogContent(cleanLogContent(data.FormatHistory(m.filterMessagesBySource(msgs), m.verboseLevel, m.showThinking)))
+}
+
+// splitCached splits msgs, the cached messages of the open log with new ones
+// merged in, as a fetch would. They follow the spill, if any.
+func (m Model) splitCached(msgs []data.HistoryMessage) ([]data.HistoryMessage, *spillCut) {
+	return splitForSpill(msgs, 0, m.spilledThrough(), m.cfg.LogMemory, m.formatSpilled)
+}
+
+// reformatLog shows the cached messages again after a change to how they
+// are formatted. Messages on disk were formatted the old way, so a log with
+// a spill of them is fetched again instead.
+func (m *Model) reformatLog() tea.Cmd {
+	if len(m.cachedMessages) == 0 || m.selectedLogTab == tabProcesses || m.diffView {
+		return nil
+	}
+	if m.spilledThrough() != nil {
+		m.dropSpill()
+		m.logContentHash = ""
+		return m.fetchLogs(m.selectedLogID)
+	}
+	filtered := m.filterMessagesBySource(m.cachedMessages)
+	m.logContent = compressLogContent(data.FormatHistory(filtered, m.verboseLevel, m.showThinking))
+	if m.logFollow {
+		m.logScrollPos = m.maxLogScroll(m.logWidth())
+	} else {
+		m.clampLogScroll(m.logWidth())
+	}
+	return nil
+}
+
+// spillContinues reports whether cut was made against the open log's spill
+// as it is now. A fetch that raced another one moving messages to disk
+// isn't, and is dropped; the next one is.
+func (m Model) spillContinues(cut *spillCut) bool {
+	if cut.after == nil {
+		return true
+	}
+	last := m.spilledThrough()
+	return last != nil && sameHistoryMessage(*last, *cut.after)
+}
+
+// capLogContent returns content to show in the log panel. For a session log
+// split by a fetch, content is what follows the spilled messages, and cut's
+// older messages are appended to the spill file. Other content is cut to the
+// configured memory cap plus whatever has been read back from the spill,
+// writing the older part to the spill file; content under the cap drops any
+// spill. Following the log puts read-back content back on disk.
+func (m *Model) capLogContent(content string, cut *spillCut) string {
+	if cut != nil {
+		return m.spillMessages(content, cut)
+	}
+	limit := m.cfg.LogMemory
+	if limit <= 0 || int64(len(content)) <= limit {
+		m.dropSpill()
+		return content
+	}
+	if m.logSpill == nil || m.logSpill.last != nil {
+		m.dropSpill()
+		m.logSpill = &logSpill{}
+	}
+	sp := m.logSpill
+	at := lineStart(content, len(content)-int(limit))
+	if at >= len(content) {
+		return content
+	}
+	// The spill so far is usually the start of content, which only grew
+	grown := int64(at) - sp.size
+	if sp.size > 0 && grown >= 0 && sha256.Sum256([]byte(content[:sp.size])) == sp.sum {
+		if err := m.appendSpill(content[sp.size:at], false); err != nil {
+			return content
+		}
+		sp.shown += grown // what was on screen stays there
+	} else {
+		if err := m.appendSpill(content[:at], true); err != nil {
+			return content
+		}
+		sp.shown = 0
+	}
+	sp.sum = sha256.Sum256([]byte(content[:at]))
+	if m.logFollow {
+		sp.shown = 0
+	}
+	return m.spillMarker() + content[sp.size-sp.shown:]
+}
+
+// spillMessages appends the messages a fetch split off to the spill and
+// returns content, the formatting of the ones kept, behind what has been
+// read back. Not following the log, the spilled messages stay on screen as
+// read back so the view doesn't move, and a spill started over keeps as
+// much read back as before.
+func (m *Model) spillMessages(content string, cut *spillCut) string {
+	var readBack string
+	var shown int64
+	if m.logSpill != nil && !m.logFollow {
+		shown = m.logSpill.shown
+	}
+	if m.logSpill == nil || cut.after == nil {
+		m.dropSpill()
+		m.logSpill = &logSpill{}
+	} else if rest := strings.TrimPrefix(m.logContent, m.spillMarker()); int64(len(rest)) >= shown {
+		readBack = rest[:shown]
+	}
+	sp := m.logSpill
+	if cut.older != "" {
+		if err := m.appendSpill(cut.older, cut.after == nil); err != nil {
+			// Shown in full rather than lost
+			m.dropSpill()
+			return readBack + cut.older + content
+		}
+	}
+	last := cut.last
+	sp.last = &last
+	switch {
+	case m.logFollow:
+		readBack = ""
+	case cut.after == nil:
+		readBack = cut.older[lineStart(cut.older, len(cut.older)-int(shown)):]
+	default:
+		readBack += cut.older
+	}
+	sp.shown = int64(len(readBack))
+	return m.spillMarker() + readBack + content
+}
+
+// appendSpill writes content to the end of the open log's spill file, or in
+// place of what it holds when fresh is set, creating the file if need be.
+// Failing, it says why in the status bar.
+func (m *Model) appendSpill(content string, fresh bool) error {
+	sp := m.logSpill
+	err := func() error {
+		if sp.path == "" {
+			f, err := os.CreateTemp("", "openclaw-commander-log-*.txt")
+			if err != nil {
+				return err
+			}
+			f.Close()
+			sp.path = f.Name()
+		}
+		flag := os.O_WRONLY | os.O_APPEND
+		if fresh {
+			flag |= os.O_TRUNC
+		}
+		f, err := os.OpenFile(sp.path, flag, 0o600)
+		if err != nil {
+			return err
+		}
+		if _, err := f.WriteString(content); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}()
+	if err != nil {
+		m.setStatus("log spill: " + err.Error())
+		return err
+	}
+	if fresh {
+		sp.size = 0
+	}
+	sp.size += int64(len(content))
+	return nil
+}
+
+// lineStart returns the offset of the first line starting at or after i.
+func lineStart(s string, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	nl := strings.IndexByte(s[i-1:], '\n')
+	if nl < 0 {
+		return len(s)
+	}
+	return i + nl
+}
+
+// spillMarker is the first line of a log with content spilled to disk.
+func (m Model) spillMarker() string {
+	left := m.logSpill.size - m.logSpill.shown
+	if left <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("⋯ %s of older output on disk; scroll up to read it\n", formatBytes(left))
+}
+
+// pageInSpill reads the next chunk of spilled content above the log
+// panel's top back into it, keeping the lines on screen in place. ok is
+// false when nothing is left on disk.
+func (m *Model) pageInSpill() (ok bool) {
+	sp := m.logSpill
+	if sp == nil || sp.shown >= sp.size {
+		return false
+	}
+	f, err := os.Open(sp.path)
+	if err != nil {
+		m.setStatus("log spill: " + err.Error())
+		return true
+	}
+	defer f.Close()
+	shown := sp.shown + m.cfg.LogMemory
+	if shown > sp.size {
+		shown = sp.size
+	}
+	buf := make([]byte, shown-sp.shown)
+	if _, err := f.ReadAt(buf, sp.size-shown); err != nil && err != io.EOF {
+		m.setStatus("log spill: " + err.Error())
+		return true
+	}
+	// Start on a whole line, leaving a partial one on disk
+	from := 0
+	if shown < sp.size {
+		if from = lineStart(string(buf), 1); from == len(buf) {
+			from = 0 // one long line; take what was read
+		}
+	}
+	w := m.logWidth()
+	distFromBottom := m.maxLogScroll(w) - m.logScrollPos
+	rest := strings.TrimPrefix(m.logContent, m.spillMarker())
+	sp.shown = shown - int64(from)
+	m.logContent = m.spillMarker() + string(buf[from:]) + rest
+	m.logContentHash = "" // Force re-wrap
+	m.logScrollPos = max(0, m.maxLogScroll(w)-distFromBottom)
+	m.logFollow = false
+	m.setStatus(fmt.Sprintf("read %s of older output back from disk", formatBytes(sp.shown)))
+	return true
+}
+
+// dropSpill removes the spill file of the open log, if any.
+func (m *Model) dropSpill() {
+	if m.logSpill == nil {
+		return
+	}
+	if m.logSpill.path != "" {
+		os.Remove(m.logSpill.path)
+	}
+	m.logSpill = nil
+}
+
+// Close releases what the Commander holds on disk for the session: the
+// spill file of the open log and its session locks.
+func (m Model) Close() {
+	m.dropSpill()
+	m.releaseLocks()
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// logStreamOpenedMsg carries a stream subscribed to a session's messages,
+// or why it couldn't be.
+type logStreamOpenedMsg struct {
+	key    string
+	stream *data.LogStream
+	err    error
+}
+
+// logDeltaMsg carries the messages a stream sent, or the error it ended
+// with.
+type logDeltaMsg struct {
+	stream   *data.LogStream
+	messages []data.HistoryMessage
+	err      error
+}
+
+// streamable reports whether the followed log can stream: a session log in
+// the normal view, on a gateway that has an event stream.
+func (m Model) streamable() bool {
+	return !m.logStreamOff && m.selectedLogID != "" && m.logFollow && !m.diffView && !m.rawLog &&
+		m.selectedLogTab == tabSessions
+}
+
+// syncLogStream opens a stream for the followed session, or closes the one
+// open for a session no longer followed. Until a stream opens, and for
+// logPollMax after one fails, the log is polled.
+func (m *Model) syncLogStream() tea.Cmd {
+	if m.logStream != nil && (!m.streamable() || m.logStream.SessionKey != m.selectedLogID) {
+		m.logStream.Close()
+		m.logStream = nil
+	}
+	if m.logStream != nil || !m.streamable() || m.logStreamOpening == m.selectedLogID || time.Now().Before(m.logStreamRetry) {
+		return nil
+	}
+	key := m.selectedLogID
+	m.logStreamOpening = key
+	client := m.client
+	return func() tea.Msg {
+		stream, err := client.OpenLogStream(key)
+		return logStreamOpenedMsg{key: key, stream: stream, err: err}
+	}
+}
+
+// handleLogStreamOpened starts reading an opened stream, unless the session
+// it is for is no longer followed.
+func (m *Model) handleLogStreamOpened(msg logStreamOpenedMsg) tea.Cmd {
+	if m.logStreamOpening == msg.key {
+		m.logStreamOpening = ""
+	}
+	if errors.Is(msg.err, data.ErrStreamUnsupported) {
+		m.logStreamOff = true
+		return nil
+	}
+	if msg.err != nil {
+		// Polled until the retry
+		m.logStreamRetry = time.Now().Add(logPollMax)
+		return nil
+	}
+	if !m.streamable() || msg.key != m.selectedLogID || m.logStream != nil {
+		msg.stream.Close()
+		return nil
+	}
+	m.logStream = msg.stream
+	return readLogStream(msg.stream)
+}
+
+// readLogStream waits for the next messages of stream.
+func readLogStream(stream *data.LogStream) tea.Cmd {
+	return func() tea.Msg {
+		msgs, err := stream.Next()
+		return logDeltaMsg{stream: stream, messages: msgs, err: err}
+	}
+}
+
+// handleLogDelta folds streamed messages into the followed log and shows it
+// as a fetch would have. A stream that ended hands the log back to polling.
+func (m Model) handleLogDelta(msg logDeltaMsg) (tea.Model, tea.Cmd) {
+	if msg.stream != m.logStream {
+		return m, nil // closed when the followed session changed
+	}
+	if msg.err != nil {
+		m.logStream.Close()
+		m.logStream = nil
+		m.logStreamRetry = time.Now().Add(logPollMax)
+		return m, m.fetchLogs(m.selectedLogID)
+	}
+	msgs := data.MergeStreamed(m.cachedMessages, msg.messages)
+	depth := m.logDepth
+	if depth <= 0 {
+		depth = m.defaultLogDepth(tabSessions)
+	}
+	complete := m.logComplete
+	if len(msgs) > depth && m.spilledThrough() == nil {
+		msgs = msgs[len(msgs)-depth:]
+		complete = false
+	}
+	msgs, cut := m.splitCached(msgs)
+	content := compressLogContent(cleanLogContent(data.FormatHistory(msgs, m.verboseLevel, m.showThinking)))
+	next, cmd := m.Update(logsMsg{content: content, query: spilledQuery(m.currentQuery, cut, content), messages: msgs, logTab: tabSessions, complete: complete, spill: cut})
+	return next, tea.Batch(cmd, readLogStream(msg.stream))
+}
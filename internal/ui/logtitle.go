@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// logKinds name what the log panel shows, by tab.
+var logKinds = map[int]string{
+	tabSessions:  "SESSION",
+	tabProcesses: "PROCESS",
+	tabHistory:   "HISTORY",
+}
+
+// logKindBadge marks the kind of log in the title, each in its own color.
+func (m Model) logKindBadge(tab int) string {
+	kind := logKinds[tab]
+	if m.cfg.A11y {
+		return "[" + strings.ToLower(kind) + "]"
+	}
+	color := colorAccent
+	switch tab {
+	case tabProcesses:
+		color = colorOrange
+	case tabHistory:
+		color = colorPurple
+	}
+	return lipgloss.NewStyle().Bold(true).Foreground(colorBg).Background(color).Padding(0, 1).Render(kind)
+}
+
+// logTitle describes the open log so a screenshot of the panel stands on
+// its own: what kind of log, whose, its model, and how it is doing. Logs
+// no longer listed fall back to their ID.
+func (m Model) logTitle() string {
+	if m.logView != "" {
+		return titleStyle.Render(m.logView)
+	}
+	if m.selectedLogID == "" {
+		return titleStyle.Render("Logs")
+	}
+	sep := dimStyle.Render(" · ")
+	var parts []string
+	switch m.selectedLogTab {
+	case tabSessions:
+		if s, ok := m.sessionByKey(m.selectedLogID); ok {
+			parts = append(parts, titleStyle.Render(sessionDisplayName(s)))
+			if s.Model != "" {
+				parts = append(parts, modelStyle(s.Model, m.cfg.ModelColors).Render(s.Model))
+			}
+			st := s.EffectiveStatus()
+			parts = append(parts, statusStyle(st).Render(st))
+		}
+	case tabProcesses:
+		for _, p := range m.processes {
+			if p.SessionName == m.selectedLogID {
+				parts = append(parts, titleStyle.Render(p.SessionName), statusStyle(p.Status).Render(p.Status))
+				if p.Runtime != "" {
+					parts = append(parts, dimStyle.Render(p.Runtime))
+				}
+				break
+			}
+		}
+	case tabHistory:
+		if run, ok := m.runByPath(m.selectedLogID); ok {
+			name := run.Label
+			if name == "" {
+				name = run.SessionID
+			}
+			parts = append(parts, titleStyle.Render(name))
+			if run.Format != "" && run.Format != "openclaw" {
+				parts = append(parts, dimStyle.Render(run.Format))
+			}
+			if run.Outcome != "" {
+				_, style := outcomeStyle(run.Outcome)
+				parts = append(parts, style.Render(run.Outcome))
+			}
+			parts = append(parts, dimStyle.Render("archived "+time.UnixMilli(run.ModifiedAt).Format("2006-01-02 15:04")))
+		}
+	}
+	if len(parts) == 0 {
+		return titleStyle.Render("Logs: " + m.selectedLogID)
+	}
+	return m.logKindBadge(m.selectedLogTab) + " " + strings.Join(parts, sep)
+}
+
+// runByPath finds an archived run by its transcript path.
+func (m Model) runByPath(path string) (data.ArchivedRun, bool) {
+	for _, run := range m.archived {
+		if run.Path == path {
+			return run, true
+		}
+	}
+	return data.ArchivedRun{}, false
+}
+
+// logPath is the file behind the open log: a session's or archived run's
+// transcript. Process logs have none.
+func (m Model) logPath() string {
+	switch m.selectedLogTab {
+	case tabSessions:
+		if s, ok := m.sessionByKey(m.selectedLogID); ok {
+			return data.TranscriptPath(s)
+		}
+	case tabHistory:
+		return m.selectedLogID
+	}
+	return ""
+}
+
+// copyLogPath copies the open log's transcript path to the clipboard.
+func (m *Model) copyLogPath() tea.Cmd {
+	path := m.logPath()
+	if m.logView != "" || path == "" {
+		m.setStatus("no transcript file behind this log")
+		return nil
+	}
+	return func() tea.Msg {
+		if err := data.CopyToClipboard(path); err != nil {
+			return imageActionMsg{err: err}
+		}
+		return imageActionMsg{status: "copied " + shortenHome(path)}
+	}
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// loopStopMsg reports the stop message sent to a looping session.
+type loopStopMsg struct {
+	name string
+	err  error
+}
+
+// checkLoop looks for a repeated tool call in the messages just loaded for
+// session key, remembering it for the session's badge. With the stop
+// policy on, a session is messaged once per loop found.
+func (m *Model) checkLoop(key string, msgs []data.HistoryMessage) tea.Cmd {
+	guard := m.cfg.LoopGuard
+	loop, ok := data.DetectLoop(msgs, guard.Window, guard.Repeats)
+	if !ok {
+		delete(m.loops, key)
+		return nil
+	}
+	prev, seen := m.loops[key]
+	m.loops[key] = loop
+	if seen && prev.Tool == loop.Tool && prev.Args == loop.Args {
+		return nil
+	}
+	s, ok := m.sessionByKey(key)
+	if !ok {
+		return nil
+	}
+	name := sessionDisplayName(s)
+	if !guard.Stop || s.EffectiveStatus() != "running" || !m.allowed(data.PermMessage) {
+		m.setStatus(m.deco("🔁", fmt.Sprintf("possible loop: %s called %s %d times with the same arguments", name, loop.Tool, loop.Count)))
+		return nil
+	}
+	client := m.client
+	text := guard.Message
+	return func() tea.Msg {
+		_, err := client.SendMessage(s.SessionID, text)
+		return loopStopMsg{name: name, err: err}
+	}
+}
+
+// handleLoopStop reports the stop message sent to a looping session.
+func (m *Model) handleLoopStop(msg loopStopMsg) {
+	if msg.err != nil {
+		m.noteDenied(data.PermMessage, msg.err)
+		m.setStatus(fmt.Sprintf("possible loop in %s; stop message failed: %v", msg.name, msg.err))
+		m.noteErr(msg.err)
+		return
+	}
+	m.setStatus(m.deco("🔁", "possible loop in "+msg.name+"; told it to stop"))
+}
+
+// loopColumn is the session row's badge for a possible loop, or "".
+func (m Model) loopColumn(s data.Session) string {
+	loop, ok := m.loops[s.Key]
+	if !ok {
+		return ""
+	}
+	if m.cfg.A11y {
+		return statusThinking.Render(fmt.Sprintf("LOOP %s x%d", loop.Tool, loop.Count))
+	}
+	return statusThinking.Render(m.deco("🔁", fmt.Sprintf("loop %s×%d", loop.Tool, loop.Count)))
+}
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// mainWidgetLines is the height of the main session widget.
+const mainWidgetLines = 3
+
+// mainWidgetMsg carries the main session's latest assistant message.
+type mainWidgetMsg struct {
+	key   string
+	model string
+	text  string
+	at    int64 // Unix ms, 0 if unknown
+}
+
+// mainSession returns the primary session, preferring the main agent's.
+func mainSession(sessions []data.Session) (data.Session, bool) {
+	for _, s := range spawnParentCandidates(sessions) {
+		if s.Kind == "main" || strings.HasSuffix(s.Key, ":main") {
+			return s, true
+		}
+	}
+	return data.Session{}, false
+}
+
+// fetchMainWidget loads the main session's latest assistant message. It does
+// nothing when the widget is hidden or there is no main session.
+func (m Model) fetchMainWidget() tea.Cmd {
+	if !m.state.MainWidget {
+		return nil
+	}
+	s, ok := mainSession(m.sessions)
+	if !ok {
+		return nil
+	}
+	client := m.client
+	return func() tea.Msg {
+		msgs, err := client.FetchSessionMessages(s.Key, 10, s.SessionID)
+		if err != nil {
+			// Keep showing the last message; the next refresh retries
+			return nil
+		}
+		for i := len(msgs) - 1; i >= 0; i-- {
+			if msgs[i].Role == "assistant" && strings.TrimSpace(msgs[i].Text) != "" {
+				return mainWidgetMsg{key: s.Key, model: msgs[i].Model, text: msgs[i].Text, at: msgs[i].Timestamp}
+			}
+		}
+		return mainWidgetMsg{key: s.Key}
+	}
+}
+
+// widgetHeight is the number of rows the main session widget takes.
+func (m Model) widgetHeight() int {
+	if !m.state.MainWidget {
+		return 0
+	}
+	return mainWidgetLines
+}
+
+// renderMainWidget shows the main session's latest assistant message,
+// squeezed onto mainWidgetLines rows.
+func (m Model) renderMainWidget() string {
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+
+	head := "main"
+	if msg := m.mainWidget; msg.model != "" {
+		head += " " + modelStyle(msg.model, m.cfg.ModelColors).Render(data.ModelAlias(msg.model))
+	}
+	if m.mainWidget.at > 0 {
+		head += dimStyle.Render(" " + formatDuration(time.Since(time.UnixMilli(m.mainWidget.at))))
+	}
+
+	text := strings.Join(strings.Fields(m.mainWidget.text), " ")
+	switch {
+	case m.mainWidget.key == "":
+		text = dimStyle.Render("waiting for the main session…")
+	case text == "":
+		text = dimStyle.Render("no assistant messages yet")
+	}
+
+	body := titleStyle.Render(m.deco("💬", head)) + "  " + text
+	return lipgloss.NewStyle().
+		Width(width).
+		Height(mainWidgetLines).
+		MaxHeight(mainWidgetLines).
+		Foreground(colorFg).
+		Padding(0, 1).
+		Render(body)
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// minimapWidth is the column the log panel reserves on its right edge for
+// the scroll minimap.
+const minimapWidth = 1
+
+// Minimap cells, in order of precedence: a failure or a user turn within
+// the lines a cell covers outranks the viewport thumb.
+const (
+	minimapTrack = "│"
+	minimapThumb = "█"
+	minimapUser  = "▸"
+	minimapError = "✗"
+)
+
+// renderMinimap returns one cell per row of a viewH-row log view showing
+// where the lines from start on sit within lines, with markers for errors
+// and user turns. It returns nil when everything fits on screen.
+func renderMinimap(lines []string, start, viewH int) []string {
+	total := len(lines)
+	if total <= viewH || viewH < 1 {
+		return nil
+	}
+	cells := make([]string, viewH)
+	for row := range cells {
+		from, to := row*total/viewH, (row+1)*total/viewH
+		cell := dimStyle.Render(minimapTrack)
+		if from < start+viewH && to > start {
+			cell = accentStyle.Render(minimapThumb)
+		}
+		user := false
+		for _, line := range lines[from:to] {
+			if isErrorLine(line) {
+				cell = statusFailed.Render(minimapError)
+				user = false
+				break
+			}
+			user = user || strings.HasPrefix(line, "─── USER")
+		}
+		if user {
+			cell = queryStyle.Render(minimapUser)
+		}
+		cells[row] = cell
+	}
+	return cells
+}
+
+// isErrorLine reports whether a log line is a failed tool call or reads
+// like an error from a process.
+func isErrorLine(line string) bool {
+	if strings.HasPrefix(line, " ✗ ") {
+		return true
+	}
+	trimmed := strings.TrimSpace(line)
+	for _, prefix := range []string{"Error", "ERROR", "error:", "panic:", "fatal:", "FATAL"} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// withMinimapCell pads a rendered log line to width and appends its
+// minimap cell.
+func withMinimapCell(rendered, raw string, width int, cell string) string {
+	return rendered + strings.Repeat(" ", max(0, width-lipgloss.Width(raw))) + cell
+}
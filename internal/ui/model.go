@@ -3,6 +3,10 @@ package ui
 import (
 	"crypto/sha256"
 	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +23,7 @@ const (
 	tabSessions  = 0
 	tabProcesses = 1
 	tabHistory   = 2
+	tabActivity  = 3
 
 	panelList = 0
 	panelLogs = 1
@@ -33,64 +38,228 @@ type tickHealthMsg struct{}
 // Data messages
 type sessionsMsg struct{ sessions []data.Session }
 type processesMsg struct{ processes []data.Process }
-type logsMsg struct{ content string; query string; messages []data.HistoryMessage; logTab int }
-type healthMsg struct{ health *data.GatewayHealth }
-type errMsg struct{ err error }
-type agentReplyMsg struct{ reply string }
+// logsMsg carries fetched log content. complete is set when the fetch
+// returned everything there is, so loading older entries is pointless.
+// procLog is a process log as fetched, for the process's capture buffer.
+// spill is set when the older messages of a session log go to disk, and
+// content and messages are only the newer ones.
+type logsMsg struct{ content string; query string; messages []data.HistoryMessage; logTab int; complete bool; procLog string; spill *spillCut }
+// healthMsg carries a heartbeat result and the graded stats that include
+// it. err is set when the heartbeat itself failed.
+type healthMsg struct {
+	health *data.GatewayHealth
+	stats  data.HealthStats
+	err    error
+}
+// errMsg reports a failed command. retry, when set, re-runs only the
+// command that failed. source names the poll that failed, if one did, so
+// that only its next success clears the error.
+type errMsg struct {
+	err    error
+	retry  tea.Cmd
+	source string
+}
+
+// Polls whose failures are kept until they succeed again
+const (
+	pollSessions  = "sessions"
+	pollProcesses = "processes"
+	pollLogs      = "logs"
+	pollHealth    = "health"
+)
+// agentReplyMsg carries a sent message and the agent's reply to it.
+type agentReplyMsg struct {
+	sessionID string
+	exchange  []data.HistoryMessage
+}
 type agentSendingMsg struct{}
-type spawnSuccessMsg struct{ result *data.SpawnResult }
+// spawnSuccessMsg reports a spawned session. A deadline given in the form
+// is recorded for its label only now, so a failed spawn leaves none behind.
+type spawnSuccessMsg struct {
+	result   *data.SpawnResult
+	label    string
+	deadline time.Duration
+}
 type modelListMsg struct{ models []data.ModelOption }
 type spawnField int
 const (
 	spawnFieldPrompt spawnField = iota
+	spawnFieldParent
 	spawnFieldModel
 	spawnFieldLabel
+	spawnFieldDeadline
+	spawnFieldEnv
 	spawnFieldCount // sentinel
 )
-type archivedMsg struct{ runs []data.ArchivedRun }
+// archiveProgressMsg carries a snapshot from the background archive scan.
+type archiveProgressMsg struct{ progress data.ArchiveProgress }
+// activityMsg carries recomputed sparklines. Unless full is set it covers
+// only the sessions that were running and is merged into the existing ones.
+type activityMsg struct {
+	activity map[string][]int
+	full     bool
+}
+type workspaceMsg struct {
+	key    string
+	status *data.WorkspaceStatus
+}
+type diffMsg struct{ content string }
+type panicDoneMsg struct {
+	aborted, killed int
+	errs            []error
+}
+type purgeDoneMsg struct {
+	removed int
+	err     error
+}
 
 // Model is the main Bubble Tea model.
 type Model struct {
 	width  int
 	height int
 
-	activeTab   int // 0=sessions, 1=processes
+	activeTab   int // tabSessions, tabProcesses, tabHistory, or tabActivity
 	activePanel int // 0=list, 1=logs
 
+	// startSession is the --session to open after the first session refresh
+	startSession string
+	// pickingTab is set until the start tab is picked from the first health
+	// check and process list; processesSeen once that list is in
+	pickingTab    bool
+	processesSeen bool
+
 	sessions  []data.Session
 	processes []data.Process
 	archived  []data.ArchivedRun
+	// sortedArchived is archived in the History tab's order
+	sortedArchived []data.ArchivedRun
+	// events is the activity feed, newest first
+	events []data.Event
 	health    *data.GatewayHealth
+	// healthStats grades recent gateway calls for the status bar
+	healthStats data.HealthStats
+	// scopes are the gateway token's scopes, once the gateway reports them
+	scopes      data.Scopes
+	scopesKnown bool
+	scopesErr   error
+	// denied are actions the gateway refused, with why
+	denied map[string]string
+
+	// Per-session activity buckets (keyed by session key) for sparklines
+	activity map[string][]int
 
 	sessionCursor int
 	processCursor int
 	historyCursor  int
+	historyRange   string // quick time range for the History tab, cycled with r
+	eventCursor   int
 	logContent    string
 	logFollow     bool
 	logScrollPos  int
 	selectedLogID  string
 	selectedLogTab int // which tab the selected log came from
+	logDepth       int  // messages or lines fetched for the selected log
+	logComplete    bool // the last fetch returned the whole log
 
 	// Current query display
 	currentQuery string
 
+	// Title of a generated view shown in the log panel instead of logs
+	logView string
+
+	// Tool output pinned above the log content
+	pinned *pinnedOutput
+
+	// Logs visited before following sub-agent links, most recent last
+	logTrail []logRef
+
+	// Process whose resource history is shown in the log panel
+	detailProcess string
+
+	// Candidates from the last ambiguous tab completion
+	completions []string
+
+	// Sent prompts and messages, oldest first, and recall position
+	prompts []string
+	recall  promptRecall
+
+	// UI preferences persisted between runs
+	state config.State
+
+	// Latest reply of the main session, for the pinned widget
+	mainWidget mainWidgetMsg
+
+	// Archive analytics from the background indexer; nil until first run
+	archiveStats *data.ArchiveStats
+	indexing     bool
+
+	// Background scan of archived transcripts for the History tab
+	archiveScan     <-chan data.ArchiveProgress // nil when no scan is running
+	archiveProgress data.ArchiveProgress
+	archiveLoaded   bool // a scan has finished, so m.archived is complete
+	archiveStale    bool // rescan when the running scan finishes
+
+	// Task labels already reported as past their deadline
+	overdueNotified map[string]bool
+
+	// Image paths found in the open log's tool output, most recent last
+	imagePattern *regexp.Regexp
+	imagePaths   []string
+
+	// Git status of the selected session's workspace
+	workspace    *data.WorkspaceStatus
+	workspaceFor string // session key the status belongs to
+	diffView     bool   // log panel shows the workspace diff instead of logs
+
 	// Search/filter
 	searching   bool
 	searchInput textinput.Model
 	filter      string
 
-	// Kill confirmation
-	confirming    bool
-	confirmTarget string
+	// Kill confirmation: the process and the signal menu's choices
+	confirming   bool
+	killTarget   data.Process
+	killSignal   int // index into data.KillSignals
+	killChildren bool
 
-	// Message input
-	messaging    bool
-	msgInput     textinput.Model
-	msgTarget    string // session ID to message
-	msgTargetName string // display name for the target
-	sending      bool   // true while waiting for agent reply
+	// Emergency stop: ctrl+k twice, then type "yes"
+	panicArmedAt time.Time
+	panicking    bool
+	panicInput   textinput.Model
+
+	// Pending confirmation of a destructive action, per the confirm policy
+	pending      *confirmation
+	confirmInput textinput.Model
+
+	// History retention
+	retentionChecked bool               // startup evaluation already ran
+	purgePlan        []data.ArchivedRun // runs awaiting purge confirmation
+	confirmingPurge  bool
 
+	// Message input
+	messaging        bool
+	msgInput         textinput.Model
+	msgTarget        string       // session ID to message
+	msgTargetKey     string       // session key, for slash command actions
+	msgTargetName    string       // display name for the target
+	msgTargetChannel string       // channel the target is bridged to
+	lastComposed     *composedMessage // last text sent from the composer, for alt+m
+	confirmingSend   bool         // previewing delivery to an external channel
+	busySend         *queuedSend  // asking what to do as the target runs a tool
+	queuedSends      []queuedSend // held back until their target's tool call ends
+	sending          bool         // true while waiting for agent reply
+
+	// Status-bar message; lastErr/lastRetry are set when it came from a
+	// failed command so it can be categorized and retried.
 	lastError string
+	lastErr   error
+	lastRetry tea.Cmd
+	// lastErrPoll is the poll behind the status-bar message, if any
+	lastErrPoll string
+	// detailErr is the last error, kept for E after the status bar moves
+	// on, with the failed request behind it
+	detailErr error
+	detailReq *data.TraceEntry
 
 	// Spawn agent form
 	spawning          bool
@@ -98,14 +267,88 @@ type Model struct {
 	spawnPrompt       textinput.Model
 	spawnModelCursor  int
 	spawnModelOptions []string
+	spawnModels       map[string]bool // models marked for a spawn matrix
+	matrix            *spawnMatrix    // the last spawn matrix, for comparing answers
 	spawnLabel        textinput.Model
+	spawnDeadline     textinput.Model
+	spawnEnv          textinput.Model // KEY=value pairs for the sub-agent's commands
 	spawnSpinning     bool
+	spawnErr          error // why the last spawn from the form failed
+	spawnParents      []data.Session // eligible parent sessions, main first
+	spawnParentCursor int
+
+	// Note editor for the selected session
+	editingNote    bool
+	noteInput      textinput.Model
+	runEdit        *runEdit // the archived run whose label and note are being edited
+	noteTarget     string // session ID the note belongs to
+	noteTargetName string
+
+	// Bulk spawn from a task list file
+	bulkPrompting bool
+	bulkInput     textinput.Model
+	bulk          *bulkSpawn
+
+	// Compactions in progress, by session key
+	compactions map[string]compaction
+
+	// ":" prompt for jumping to a list item by number or typing a command
+	jumping   bool
+	jumpInput textinput.Model
+	jumpFrom  int // cursor to restore on Esc
+
+	// "!" prompt and output of a command run in a session's workspace
+	runPrompting bool
+	runInput     textinput.Model
+	runChoice    int // index of the configured command last offered
+	runTarget    data.Session
+	runSeq       int // increments per run, to drop stale output
+	runTitle     string
+	runOutput    string
+
+	// "S" prompt and results of a gateway-side history search, and the
+	// match to scroll to once the log it opened has loaded
+	historySearching   bool
+	historySearchInput textinput.Model
+	searchQuery        string
+	searchHits         []data.SearchHit
+	logJump            *logJump
+
+	// Restart supervision: recent restarts by command, exited processes
+	// already restarted, and processes stopped with x, by name
+	restarts    map[string]*restartHistory
+	restarted   map[string]bool
+	userStopped map[string]bool
+
+	// Sessions already offered for auto-archiving this run, by key
+	archiveOffered map[string]bool
 
 	// Verbose level for tool display
 	verboseLevel data.VerboseLevel
 
+	// Show the raw transcript instead of the formatted one
+	rawLog bool
+
+	// Show reasoning blocks in full instead of a one-line summary
+	showThinking bool
+
 	// Cached messages for re-rendering with different verbose levels
 	cachedMessages []data.HistoryMessage
+	logSpill       *logSpill // older log content kept on disk, if any
+
+	// loops are possible tool-call loops found in sessions' loaded messages
+	loops map[string]data.ToolLoop
+	// procLogs are the captured output of followed processes, by process
+	procLogs map[string]*data.LogRing
+	// locks are the operators' claims on sessions; heldLocks are this
+	// Commander's, by session key
+	locks     map[string]data.SessionLock
+	heldLocks map[string]bool
+	preview        *filePreview
+	catalog        *toolCatalog // gateway tools and argument form, over the log panel
+	reconnect      *reconnect   // backoff while the gateway is unreachable
+	lastInput      time.Time    // last key press, for the privacy veil
+	veiled         bool         // transcript content hidden after PrivacyTimeout
 	cachedLogTab   int
 
 	// Source filter for channel separation (All/Signal/Matrix)
@@ -120,44 +363,140 @@ type Model struct {
 	// Content hash for stable change detection
 	logContentHash   string
 	lastLogFetch     time.Time
+	logIdle          int // consecutive log fetches that brought nothing new
+	stream           *streamRate // output rate of the followed session
+	logStream        *data.LogStream // live messages of the followed session; nil while polling
+	logStreamOpening string          // session key a stream is being opened for
+	logStreamOff     bool            // the gateway has no event stream
+	logStreamRetry   time.Time       // when to try streaming again after a failure
+
+	// Session list refreshes so far, for pacing idle sparkline updates
+	sessionsRefreshes int
 
+	// Gateway prompt, opened when the first health check fails
+	healthChecked bool
+	setup         *gatewaySetup
+
+	cfg    config.Config
 	client *data.Client
 }
 
 func NewModel(cfg config.Config) Model {
+	if cfg.NoColor {
+		applyNoColor()
+	}
+	data.SetToolStyles(cfg.Tools)
+
 	ti := textinput.New()
 	ti.Placeholder = "filter..."
 	ti.CharLimit = 64
 
 	mi := textinput.New()
 	mi.Placeholder = "message..."
-	mi.CharLimit = 1024
+	mi.CharLimit = 16 * 1024 // room for multi-KB pastes
 	mi.Width = 60
 
 	sp := textinput.New()
 	sp.Placeholder = "What should the agent do?"
-	sp.CharLimit = 2048
+	sp.CharLimit = 32 * 1024
 	sp.Width = 60
 
 	sl := textinput.New()
-	sl.Placeholder = "(optional) e.g. my-task"
+	sl.Placeholder = "(optional) derived from the prompt if empty"
 	sl.CharLimit = 128
 	sl.Width = 60
 
+	sd := textinput.New()
+	sd.Placeholder = "(optional) expected duration, e.g. 45m"
+	sd.CharLimit = 16
+	sd.Width = 40
+
+	se := textinput.New()
+	se.Placeholder = "(optional) KEY=value ..., masked; ctrl+t shows values"
+	se.CharLimit = 4096
+	se.Width = 60
+	se.EchoMode = textinput.EchoPassword
+	se.EchoCharacter = '•'
+
+	bi := textinput.New()
+	bi.Placeholder = "path to tasks.yaml or tasks.json"
+	bi.CharLimit = 512
+	bi.Width = 60
+
+	ni := textinput.New()
+	ni.Placeholder = "note, e.g. waiting on customer input (empty to remove)"
+	ni.CharLimit = 512
+	ni.Width = 60
+
+	ji := textinput.New()
+	ji.Placeholder = "item number or command"
+	ji.CharLimit = 1024
+	ji.Width = 60
+
+	hsi := textinput.New()
+	hsi.Placeholder = "words to find in any session"
+	hsi.CharLimit = 256
+	hsi.Width = 50
+
+	ri := textinput.New()
+	ri.Placeholder = "shell command"
+	ri.CharLimit = 1024
+	ri.Width = 60
+
+	pi := textinput.New()
+	pi.Placeholder = "type yes"
+	pi.CharLimit = 8
+	pi.Width = 10
+
+	ci := textinput.New()
+	ci.CharLimit = 128
+	ci.Width = 30
+
 	// Model options — populated dynamically from openclaw.json on spawn open
 	modelOptions := []string{
 		"(default)",
 	}
 
-	return Model{
+	var prompts []string
+	if cfg.PromptHistory {
+		prompts = config.LoadPromptHistory()
+	}
+
+	m := Model{
 		logFollow:         true,
 		searchInput:       ti,
 		msgInput:          mi,
 		spawnPrompt:       sp,
 		spawnModelOptions: modelOptions,
 		spawnLabel:        sl,
+		spawnDeadline:     sd,
+		spawnEnv:          se,
+		bulkInput:         bi,
+		noteInput:         ni,
+		jumpInput:         ji,
+		runInput:          ri,
+		historySearchInput: hsi,
+		panicInput:        pi,
+		confirmInput:      ci,
+		cfg:               cfg,
+		state:             config.LoadState(),
+		imagePattern:      compileImagePattern(cfg.ImagePathPattern),
+		prompts:           prompts,
+		recall:            promptRecall{index: -1},
 		client:            data.NewClient(cfg),
-	}
+		restarts:          map[string]*restartHistory{},
+		restarted:         map[string]bool{},
+		userStopped:       map[string]bool{},
+		archiveOffered:    map[string]bool{},
+		loops:             map[string]data.ToolLoop{},
+		denied:            map[string]string{},
+		procLogs:          map[string]*data.LogRing{},
+		locks:             map[string]data.SessionLock{},
+		heldLocks:         map[string]bool{},
+		lastInput:         time.Now(),
+	}
+	m.applyStartFlags()
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
@@ -165,9 +504,12 @@ func (m Model) Init() tea.Cmd {
 		m.fetchSessions,
 		m.fetchProcesses,
 		m.fetchHealth,
-		tickSessions(),
+		m.fetchScopes,
+		tickSessions(sessionsPollRecent),
 		tickProcesses(),
 		tickHealth(),
+		tickSchedule(),
+		m.tickPrivacy(),
 	)
 }
 
@@ -175,7 +517,7 @@ func (m Model) Init() tea.Cmd {
 func (m Model) fetchSessions() tea.Msg {
 	s, err := m.client.FetchSessions()
 	if err != nil {
-		return errMsg{fmt.Errorf("sessions: %w", err)}
+		return errMsg{fmt.Errorf("sessions: %w", err), m.fetchSessions, pollSessions}
 	}
 	return sessionsMsg{s}
 }
@@ -183,127 +525,256 @@ func (m Model) fetchSessions() tea.Msg {
 func (m Model) fetchProcesses() tea.Msg {
 	p, err := m.client.FetchProcesses()
 	if err != nil {
-		return errMsg{fmt.Errorf("processes: %w", err)}
+		return errMsg{fmt.Errorf("processes: %w", err), m.fetchProcesses, pollProcesses}
 	}
 	return processesMsg{p}
 }
 
-func (m Model) fetchArchived() tea.Msg {
-	runs, err := m.client.FetchArchivedRuns(m.sessions)
-	if err != nil {
-		return errMsg{fmt.Errorf("archived: %w", err)}
+// scanArchive starts a background scan of archived transcripts, or marks
+// the archive for another scan if one is already running.
+func (m *Model) scanArchive() tea.Cmd {
+	if m.archiveScan != nil {
+		m.archiveStale = true
+		return nil
+	}
+	m.archiveStale = false
+	m.archiveScan = m.client.ScanArchivedRuns(m.visibleSessions())
+	return waitArchive(m.archiveScan)
+}
+
+// waitArchive waits for the next snapshot from an archive scan.
+func waitArchive(ch <-chan data.ArchiveProgress) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return archiveProgressMsg{p}
+	}
+}
+
+func (m Model) fetchActivity() tea.Msg {
+	now := time.Now()
+	sessions, full := m.activityTargets()
+	activity := make(map[string][]int, len(sessions))
+	for _, s := range sessions {
+		activity[s.Key] = m.client.SessionActivity(s, now)
 	}
-	return archivedMsg{runs}
+	return activityMsg{activity, full}
 }
 
 func (m Model) fetchHealth() tea.Msg {
 	h, err := m.client.FetchGatewayHealth()
-	if err != nil {
-		return errMsg{err}
+	return healthMsg{health: h, stats: m.client.HealthStats(), err: err}
+}
+
+func (m Model) fetchWorkspace(key string) tea.Cmd {
+	client := m.client
+	var sess *data.Session
+	for i := range m.sessions {
+		if m.sessions[i].Key == key {
+			sess = &m.sessions[i]
+			break
+		}
+	}
+	if sess == nil {
+		return nil
+	}
+	s := *sess
+	return func() tea.Msg {
+		dir := client.SessionWorkspace(s)
+		if dir == "" {
+			return workspaceMsg{key: key}
+		}
+		st, err := client.FetchWorkspaceStatus(dir)
+		if err != nil {
+			return workspaceMsg{key: key}
+		}
+		return workspaceMsg{key: key, status: st}
+	}
+}
+
+func (m Model) fetchDiff() tea.Cmd {
+	client := m.client
+	dir := m.workspace.Path
+	return func() tea.Msg {
+		content, err := client.FetchWorkspaceDiff(dir)
+		if err != nil {
+			return errMsg{err: fmt.Errorf("diff(%s): %w", dir, err)}
+		}
+		return diffMsg{content}
 	}
-	return healthMsg{h}
 }
 
 func (m Model) fetchLogs(id string) tea.Cmd {
 	logTab := m.selectedLogTab
 	client := m.client
 	verbose := m.verboseLevel
+	showThinking := m.showThinking
+	depth := m.logDepth
+	if depth <= 0 {
+		depth = m.defaultLogDepth(logTab)
+	}
 	// Look up sessionID for transcript fallback
 	var sessionID string
+	var session data.Session
 	for _, s := range m.sessions {
 		if s.Key == id {
 			sessionID = s.SessionID
+			session = s
 			break
 		}
 	}
+	raw := m.rawLog
+	// Messages already on disk are neither formatted nor kept again
+	after, limit, query := m.spilledThrough(), m.cfg.LogMemory, m.currentQuery
+	format := m.formatSpilled
 	return func() tea.Msg {
+		if raw {
+			return fetchRawLog(client, logTab, id, session, depth, m.fetchLogs(id))
+		}
 		switch logTab {
 		case tabSessions:
 			// Debug: log what we're fetching
 			debugInfo := fmt.Sprintf("[DEBUG] Fetching session:\n  Key: %s\n  SessionID: %s\n", id, sessionID)
-			msgs, err := client.FetchSessionMessages(id, 200, sessionID)
+			msgs, err := client.FetchSessionMessages(id, depth, sessionID)
 			if err != nil {
 				// Return error with context about what was tried
-				return errMsg{fmt.Errorf("sessions(%s, sessionID=%s): %w", id, sessionID, err)}
+				return errMsg{fmt.Errorf("sessions(%s, sessionID=%s): %w", id, sessionID, err), m.fetchLogs(id), pollLogs}
 			}
 			if len(msgs) == 0 {
-				return logsMsg{content: debugInfo + "[No messages returned from session]", query: "", messages: msgs, logTab: logTab}
+				return logsMsg{content: debugInfo + "[No messages returned from session]", query: "", messages: msgs, logTab: logTab, complete: true}
 			}
-			content := data.FormatHistory(msgs, verbose)
+			complete := len(msgs) < depth
+			start, ok := unspilled(msgs, after)
+			if !ok {
+				after = nil
+			}
+			msgs, cut := splitForSpill(msgs, start, after, limit, format)
+			content := data.FormatHistory(msgs, verbose, showThinking)
 			content = cleanLogContent(content)
 			content = compressLogContent(content)
-			query := extractQuery(content)
-			return logsMsg{content: content, query: query, messages: msgs, logTab: logTab}
+			return logsMsg{content: content, query: spilledQuery(query, cut, content), messages: msgs, logTab: logTab, complete: complete, spill: cut}
 		case tabHistory:
 			// For transcripts, read raw but also parse messages
-			content, err := client.ReadTranscriptVerbose(id, verbose)
+			msgs, err := client.ReadTranscriptMessages(id)
 			if err != nil {
-				return errMsg{fmt.Errorf("history(%s): %w", id, err)}
+				return errMsg{fmt.Errorf("history(%s): %w", id, err), m.fetchLogs(id), pollLogs}
+			}
+			complete := len(msgs) <= depth
+			if !complete {
+				msgs = msgs[len(msgs)-depth:]
+			}
+			start, ok := unspilled(msgs, after)
+			if !ok {
+				after = nil
 			}
+			msgs, cut := splitForSpill(msgs, start, after, limit, format)
+			content := data.FormatHistory(msgs, verbose, showThinking)
 			content = cleanLogContent(content)
 			content = compressLogContent(content)
-			query := extractQuery(content)
-			return logsMsg{content: content, query: query, logTab: logTab}
+			return logsMsg{content: content, query: spilledQuery(query, cut, content), messages: msgs, logTab: logTab, complete: complete, spill: cut}
 		default:
-			content, err := client.FetchProcessLog(id, 200)
+			content, err := client.FetchProcessLog(id, depth)
 			if err != nil {
-				return errMsg{fmt.Errorf("processes(%s): %w", id, err)}
+				return errMsg{fmt.Errorf("processes(%s): %w", id, err), m.fetchLogs(id), pollLogs}
 			}
-			content = cleanLogContent(content)
+			procLog := strings.ReplaceAll(content, "\r", "")
+			content = asciiBoxContent(cleanLogContent(content))
 			query := extractQuery(content)
-			return logsMsg{content: content, query: query, logTab: logTab}
+			return logsMsg{content: content, query: query, logTab: logTab, complete: strings.Count(content, "\n") < depth, procLog: procLog}
 		}
 	}
 }
 
-// cleanLogContent removes carriage returns, box-drawing characters, and other
-// problematic Unicode that interferes with the TUI layout.
+// defaultLogDepth is the configured fetch size for a tab's logs.
+func (m Model) defaultLogDepth(tab int) int {
+	if tab == tabProcesses {
+		return m.cfg.ProcessLogLines
+	}
+	return m.cfg.FetchDepth
+}
+
+// loadOlder grows the fetch depth of the open log by one configured step
+// and refetches, so scrolling past the top reveals older entries.
+func (m *Model) loadOlder() tea.Cmd {
+	if m.selectedLogID == "" || m.diffView || m.pageInSpill() || m.logComplete {
+		return nil
+	}
+	m.logDepth += m.defaultLogDepth(m.selectedLogTab)
+	if m.logSpill != nil {
+		// Older messages go before those on disk, so the spill starts over
+		m.logSpill.last = nil
+	}
+	m.setStatus(fmt.Sprintf("loading older entries (up to %d)…", m.logDepth))
+	return m.fetchLogs(m.selectedLogID)
+}
+
+// cleanLogContent removes carriage returns and ANSI escape sequences that
+// interfere with the TUI layout.
 func cleanLogContent(content string) string {
 	// Replace Windows line endings
 	content = strings.ReplaceAll(content, "\r\n", "\n")
 	// Replace standalone carriage returns (Docker progress bars)
 	content = strings.ReplaceAll(content, "\r", "\n")
 	// Strip ANSI escape sequences
-	content = data.StripANSI(content)
-	// Replace box-drawing / table characters that break TUI rendering
+	return data.StripANSI(content)
+}
+
+// asciiBoxContent replaces box-drawing, block, and braille characters with
+// ASCII. Only process logs get it: there they come from progress bars and
+// spinners redrawn in place, while in transcripts they are tables and
+// diagrams the agent drew on purpose.
+func asciiBoxContent(content string) string {
 	var b strings.Builder
 	b.Grow(len(content))
 	for _, r := range content {
-		switch {
-		// Box Drawing block: U+2500–U+257F
-		case r >= 0x2500 && r <= 0x257F:
-			// Horizontals → dash, verticals → pipe, corners/junctions → +
-			switch {
-			case r == 0x2500 || r == 0x2501 || r == 0x2504 || r == 0x2505 ||
-				r == 0x2508 || r == 0x2509 || r == 0x254C || r == 0x254D:
-				b.WriteByte('-')
-			case r == 0x2502 || r == 0x2503 || r == 0x2506 || r == 0x2507 ||
-				r == 0x250A || r == 0x250B || r == 0x254E || r == 0x254F:
-				b.WriteByte('|')
-			default:
-				b.WriteByte('+')
-			}
-		// Block Elements: U+2580–U+259F
-		case r >= 0x2580 && r <= 0x259F:
-			b.WriteByte('#')
-		// Braille patterns: U+2800–U+28FF (sometimes used for charts)
-		case r >= 0x2800 && r <= 0x28FF:
-			b.WriteByte('.')
-		default:
+		if c, ok := asciiBox(r); ok {
+			b.WriteByte(c)
+		} else {
 			b.WriteRune(r)
 		}
 	}
 	return b.String()
 }
 
+// asciiBox maps box-drawing, block, and braille characters to an ASCII
+// stand-in of the same width.
+func asciiBox(r rune) (byte, bool) {
+	switch {
+	// Box Drawing block: U+2500–U+257F
+	case r >= 0x2500 && r <= 0x257F:
+		// Horizontals → dash, verticals → pipe, corners/junctions → +
+		switch {
+		case r == 0x2500 || r == 0x2501 || r == 0x2504 || r == 0x2505 ||
+			r == 0x2508 || r == 0x2509 || r == 0x254C || r == 0x254D:
+			return '-', true
+		case r == 0x2502 || r == 0x2503 || r == 0x2506 || r == 0x2507 ||
+			r == 0x250A || r == 0x250B || r == 0x254E || r == 0x254F:
+			return '|', true
+		default:
+			return '+', true
+		}
+	// Block Elements: U+2580–U+259F
+	case r >= 0x2580 && r <= 0x259F:
+		return '#', true
+	// Braille patterns: U+2800–U+28FF (sometimes used for charts)
+	case r >= 0x2800 && r <= 0x28FF:
+		return '.', true
+	}
+	return 0, false
+}
+
 // compressLogContent removes verbose noise from agent transcripts:
-// - Strips ALL ASSISTANT/USER role headers entirely
+// - Strips USER role headers, and ASSISTANT headers except where the model
+//   changes, so each model's turns stay identifiable
 // - Removes planning filler lines ("Now let's...", "Now I'll...", "Let me...", etc.)
 // - Collapses blank lines
 func compressLogContent(content string) string {
 	lines := strings.Split(content, "\n")
 	var out []string
 	prevBlank := false
+	prevModel := ""
 
 	for i := 0; i < len(lines); i++ {
 		line := lines[i]
@@ -312,6 +783,14 @@ func compressLogContent(content string) string {
 		// Strip ASSISTANT headers like "─── ASSISTANT (model) ───" or "--- ASSISTANT (model) ---"
 		if (strings.HasPrefix(trimmed, "─── ASSISTANT") || strings.HasPrefix(trimmed, "--- ASSISTANT")) &&
 			(strings.HasSuffix(trimmed, "───") || strings.HasSuffix(trimmed, "---")) {
+			_, rest, _ := strings.Cut(trimmed, "(")
+			model, _, _ := strings.Cut(rest, ")")
+			if model == "" || model == prevModel {
+				continue
+			}
+			prevModel = model
+			out = append(out, trimmed)
+			prevBlank = false
 			continue
 		}
 
@@ -388,8 +867,8 @@ func extractQuery(content string) string {
 }
 
 // Tick commands for periodic refresh
-func tickSessions() tea.Cmd {
-	return tea.Tick(5*time.Second, func(time.Time) tea.Msg {
+func tickSessions(d time.Duration) tea.Cmd {
+	return tea.Tick(d, func(time.Time) tea.Msg {
 		return tickSessionsMsg{}
 	})
 }
@@ -401,13 +880,13 @@ func tickProcesses() tea.Cmd {
 }
 
 func tickLogs() tea.Cmd {
-	return tea.Tick(2*time.Second, func(time.Time) tea.Msg {
+	return tea.Tick(logPollBase, func(time.Time) tea.Msg {
 		return tickLogsMsg{}
 	})
 }
 
 func tickHealth() tea.Cmd {
-	return tea.Tick(30*time.Second, func(time.Time) tea.Msg {
+	return tea.Tick(10*time.Second, func(time.Time) tea.Msg {
 		return tickHealthMsg{}
 	})
 }
@@ -423,30 +902,151 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return (&m).handleKey(msg)
 
 	case sessionsMsg:
+		if m.sessionsRefreshes > 0 {
+			m.recordEvents(data.SessionEvents(m.sessions, msg.sessions, time.Now()))
+		}
 		m.sessions = msg.sessions
-		m.lastError = ""
-		return m, m.fetchArchived
+		m.clearPollError(pollSessions)
+		m.reportCompactions()
+		m.checkDeadlines()
+		m.unarchiveActive()
+		m.autoArchive()
+		cmds := tea.Batch(m.scanArchive(), m.fetchActivity, m.fetchMainWidget(), m.openStartSession(), m.followLatest(), m.fetchLocks())
+		m.sessionsRefreshes++
+		return m, cmds
+
+	case killDoneMsg:
+		if msg.err != nil {
+			(&m).noteDenied(data.PermKill, msg.err)
+			m.setStatus(fmt.Sprintf("kill %s: %v", msg.name, msg.err))
+		} else {
+			m.setStatus(fmt.Sprintf("sent SIG%s to %s", msg.signal, msg.name))
+			m.userStopped[msg.name] = true
+		}
+		return m, m.fetchProcesses
+
+	case timelineMsg:
+		(&m).handleTimeline(msg)
+		return m, nil
+
+	case archiveStatsMsg:
+		m.indexing = false
+		m.archiveStats = &msg.stats
+		m.refreshAnalytics()
+		return m, nil
+
+	case mainWidgetMsg:
+		m.mainWidget = msg
+		return m, nil
+
+	case archiveProgressMsg:
+		m.archiveProgress = msg.progress
+		if !msg.progress.Finished {
+			// Fill the list as the first scan goes; later scans swap it in
+			// when done so the list doesn't shrink under the cursor.
+			if !m.archiveLoaded {
+				m.archived = msg.progress.Runs
+				(&m).resortArchived()
+			}
+			return m, waitArchive(m.archiveScan)
+		}
+		m.archiveScan = nil
+		m.archiveLoaded = true
+		m.archived = msg.progress.Runs
+		(&m).resortArchived()
+		cmd := m.indexArchive()
+		if m.archiveStale {
+			cmd = tea.Batch(cmd, m.scanArchive())
+		}
+		if !m.retentionChecked {
+			m.retentionChecked = true
+			if m.cfg.Retention.OnStartup && m.cfg.Retention.Enabled() {
+				cmd = tea.Batch(cmd, m.showRetentionPlan(false))
+			}
+		}
+		return m, cmd
+
+	case purgeDoneMsg:
+		if msg.err != nil {
+			m.setStatus(fmt.Sprintf("purge: removed %d, %v", msg.removed, msg.err))
+		} else {
+			m.setStatus(m.deco("🗑", fmt.Sprintf("Purged %d archived runs", msg.removed)))
+		}
+		return m, m.scanArchive()
 
-	case archivedMsg:
-		m.archived = msg.runs
+	case activityMsg:
+		if msg.full || m.activity == nil {
+			m.activity = msg.activity
+			return m, nil
+		}
+		for k, v := range msg.activity {
+			m.activity[k] = v
+		}
 		return m, nil
 
 	case processesMsg:
-		m.processes = msg.processes
-		m.lastError = ""
+		prev := m.processes
+		m.processes = m.dropIgnored(msg.processes)
+		m.clearPollError(pollProcesses)
+		m.refreshProcessDetail()
+		m.processesSeen = true
+		pick := (&m).pickStartTab()
+		return m, tea.Batch(m.superviseProcesses(prev, m.processes), pick)
+
+	case restartDoneMsg:
+		return m, m.handleRestartDone(msg)
+
+	case panicDoneMsg:
+		text := m.deco("🛑", "Emergency stop: ") + fmt.Sprintf("aborted %d sessions, killed %d processes", msg.aborted, msg.killed)
+		if len(msg.errs) > 0 {
+			text += fmt.Sprintf(" (%d failed: %v)", len(msg.errs), msg.errs[0])
+		}
+		m.setStatus(text)
+		return m, tea.Batch(m.fetchSessions, m.fetchProcesses)
+
+	case bulkTaskDoneMsg:
+		return m, m.handleBulkTaskDone(msg)
+
+	case workspaceMsg:
+		m.workspace = msg.status
+		m.workspaceFor = msg.key
+		return m, nil
+
+	case diffMsg:
+		if !m.diffView {
+			return m, nil
+		}
+		m.dropSpill()
+		m.logContent = cleanLogContent(msg.content)
+		m.logContentHash = fmt.Sprintf("%x", sha256.Sum256([]byte(m.logContent)))
+		m.logScrollPos = 0
 		return m, nil
 
 	case logsMsg:
+		if m.diffView || msg.spill != nil && !m.spillContinues(msg.spill) {
+			return m, nil
+		}
+		m.clearPollError(pollLogs)
 		m.cachedMessages = msg.messages
 		m.cachedLogTab = msg.logTab
+		(&m).trackStream(msg)
+		var loopCmd tea.Cmd
+		if msg.logTab == tabSessions && len(msg.messages) > 0 {
+			loopCmd = m.checkLoop(m.selectedLogID, msg.messages)
+		}
+		m.imagePaths = data.FindPaths(msg.messages, m.imagePattern)
+		if msg.logTab == tabProcesses {
+			m.captureProcessLog(m.selectedLogID, msg.procLog)
+		}
 		m.lastLogFetch = time.Now()
+		m.logComplete = msg.complete
 
 		// Apply source filter if active
 		filtered := m.filterMessagesBySource(msg.messages)
 		// Re-format with filter applied (for sessions/history tabs)
 		var newContent string
 		if m.selectedLogTab != tabProcesses && len(filtered) != len(msg.messages) {
-			newContent = compressLogContent(data.FormatHistory(filtered, m.verboseLevel))
+			newContent = compressLogContent(data.FormatHistory(filtered, m.verboseLevel, m.showThinking))
 		} else {
 			newContent = msg.content
 		}
@@ -454,20 +1054,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Skip empty content (preserve existing log on fetch failure/empty)
 		if newContent == "" && m.logContent != "" && m.logContent != "Loading..." {
 			m.currentQuery = msg.query
-			return m, nil
+			return m, loopCmd
 		}
 
 		// Hash-based change detection for stable rendering
 		newHash := fmt.Sprintf("%x", sha256.Sum256([]byte(newContent)))
-		if newHash == m.logContentHash {
+		if newHash == m.logContentHash && (msg.spill == nil || msg.spill.older == "") {
 			// Content unchanged, just update query if needed
 			m.currentQuery = msg.query
-			return m, nil
+			m.logIdle++
+			m.applyLogJump()
+			return m, loopCmd
 		}
+		m.logIdle = 0
 
 		// Content changed - update everything
 		oldContent := m.logContent
-		m.logContent = newContent
+		w := m.logWidth()
+		oldMax := m.maxLogScroll(w)
+		m.logContent = m.capLogContent(newContent, msg.spill)
 		m.logContentHash = newHash
 		m.currentQuery = msg.query
 
@@ -477,15 +1082,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		if m.logFollow {
 			wasEmpty := len(oldContent) == 0 || oldContent == "Loading..."
-			contentGrew := len(newContent) > len(oldContent)
+			// Capped content can stay the same length as it changes
+			contentGrew := len(m.logContent) > len(oldContent) || m.logSpill != nil
 			if contentGrew || wasEmpty {
 				m.logScrollPos = m.maxLogScroll(m.logWidth())
 			}
 		} else {
 			// When not following, anchor scroll position relative to the
-			// bottom so that appended content doesn't shift the view.
-			w := m.logWidth()
-			oldMax := m.maxLogScroll(w)
+			// bottom so that appended content doesn't shift the view, and
+			// older content loaded above keeps the current lines in place.
 			distFromBottom := oldMax - m.logScrollPos
 			newMax := m.maxLogScroll(w)
 			m.logScrollPos = newMax - distFromBottom
@@ -493,21 +1098,93 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logScrollPos = 0
 			}
 		}
-		return m, nil
+		m.applyLogJump()
+		return m, loopCmd
 
 	case healthMsg:
+		m.healthStats = msg.stats
+		setup := (&m).noteHealth(msg.err)
+		if !m.healthChecked {
+			m.healthChecked = true
+			if reason := healthFailure(msg.health, msg.err); reason != "" {
+				setup = tea.Batch(setup, m.openGatewaySetup(reason))
+			}
+		} else if m.setup != nil && healthFailure(msg.health, msg.err) == "" {
+			// The gateway came up on its own
+			m.setup = nil
+		}
+		if msg.err != nil {
+			m.health = &data.GatewayHealth{Ts: time.Now().UnixMilli()}
+			pick := (&m).pickStartTab()
+			next, cmd := m.Update(errMsg{msg.err, m.fetchHealth, pollHealth})
+			return next, tea.Batch(setup, pick, cmd)
+		}
 		m.health = msg.health
-		m.lastError = ""
+		m.clearPollError(pollHealth)
+		return m, tea.Batch(setup, (&m).pickStartTab())
+
+	case gatewayTestMsg:
+		m.handleGatewayTest(msg)
 		return m, nil
 
-	case agentReplyMsg:
+	case imageActionMsg:
+		if msg.err != nil {
+			m.setStatus("image: " + msg.err.Error())
+		} else {
+			m.setStatus(msg.status)
+		}
+		return m, nil
+
+	case execOutputMsg:
+		return m, m.handleExecOutput(msg)
+
+	case execPollMsg:
+		return m, m.pollRun(msg)
+
+	case compactDoneMsg:
+		return m, m.handleCompactDone(msg)
+
+	case outputsMsg:
+		m.handleOutputs(msg)
+		return m, nil
+
+	case gatewayToolsMsg:
+		m.handleGatewayTools(msg)
+		return m, nil
+
+	case toolCatalogMsg:
+		(&m).handleToolCatalog(msg)
+		return m, nil
+
+	case toolResultMsg:
+		(&m).handleToolResult(msg)
+		return m, nil
+
+	case locksMsg:
+		(&m).handleLocks(msg)
+		return m, nil
+
+	case scopesMsg:
+		(&m).handleScopes(msg)
+		return m, nil
+
+	case deniedMsg:
 		m.sending = false
-		// Append reply to log content and refresh
-		reply := cleanLogContent(msg.reply)
-		m.logContent += "\n─── SENT ───\n" + reply + "\n"
-		if m.logFollow {
-			m.logScrollPos = m.maxLogScroll(m.logWidth())
+		(&m).noteDenied(msg.action, msg.err)
+		m.setStatus(m.deco("⊘", fmt.Sprintf("the gateway token can't %s: %v", msg.action, msg.err)))
+		return m, nil
+
+	case slashDoneMsg:
+		if msg.content != "" {
+			m.showLogView(msg.title, cleanLogContent(msg.content))
 		}
+		m.setStatus(msg.status)
+		return m, m.fetchSessions
+
+	case agentReplyMsg:
+		m.sending = false
+		m.logIdle = 0
+		(&m).mergeSent(msg)
 		// Refresh the session history
 		if m.selectedLogID != "" {
 			return m, m.fetchLogs(m.selectedLogID)
@@ -530,17 +1207,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case spawnSuccessMsg:
 		m.spawnSpinning = false
 		m.spawning = false
-		m.lastError = ""
+		m.setStatus("")
+		if msg.deadline > 0 && msg.label != "" {
+			(&m).setDeadline(msg.label, msg.deadline)
+		}
 		if msg.result != nil && msg.result.SessionID != "" {
-			m.lastError = "✅ Spawned: " + msg.result.SessionID
+			m.setStatus(m.deco("✅", "Spawned: "+msg.result.SessionID))
 		}
 		// Refresh sessions to show the new one
 		return m, m.fetchSessions
 
+	case historySearchMsg:
+		m.handleHistorySearch(msg)
+		return m, nil
+
+	case compareMsg:
+		if m.matrix != nil {
+			m.showComparison(msg.answers)
+		}
+		return m, nil
+
+	case spawnFailedMsg:
+		m.spawnSpinning = false
+		(&m).noteDenied(data.PermSpawn, msg.err)
+		if !m.spawning {
+			// The form was closed while the spawn was in flight
+			err := fmt.Errorf("spawn: %w", msg.err)
+			m.setStatus(err.Error())
+			(&m).noteErr(err)
+			return m, nil
+		}
+		m.spawnErr = msg.err
+		return m, nil
+
 	case errMsg:
 		m.sending = false
 		m.spawnSpinning = false
 		m.lastError = msg.err.Error()
+		(&m).noteErr(msg.err)
+		m.lastRetry = msg.retry
+		m.lastErrPoll = msg.source
 		// If log fetch failed, show error in log panel
 		if m.selectedLogID != "" && m.logContent == "" || m.logContent == "Loading..." {
 			m.logContent = "Error loading logs:\n" + msg.err.Error()
@@ -549,29 +1255,282 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case tickSessionsMsg:
-		return m, tea.Batch(m.fetchSessions, tickSessions())
+		return m, tea.Batch(m.fetchSessions, tickSessions(m.sessionsInterval()))
 
 	case tickProcessesMsg:
 		return m, tea.Batch(m.fetchProcesses, tickProcesses())
 
 	case tickLogsMsg:
+		stream := (&m).syncLogStream()
 		// Only fetch logs when following and a session is selected
-		// Throttle to avoid visual glitching (min 2s between fetches)
-		if m.selectedLogID != "" && m.logFollow {
-			if time.Since(m.lastLogFetch) >= 2*time.Second {
-				return m, tea.Batch(m.fetchLogs(m.selectedLogID), tickLogs())
+		// Throttle to avoid visual glitching (min 2s between fetches), and
+		// back off further while the log isn't changing
+		if m.selectedLogID != "" && m.logFollow && !m.diffView {
+			interval := m.logInterval()
+			if m.logStream != nil {
+				// The stream brings changes; fetch only to catch what it missed
+				interval = logPollMax
+			}
+			if time.Since(m.lastLogFetch) >= interval {
+				cmds := []tea.Cmd{m.fetchLogs(m.selectedLogID), tickLogs(), stream}
+				if m.selectedLogTab == tabSessions {
+					cmds = append(cmds, m.fetchWorkspace(m.selectedLogID))
+				}
+				return m, tea.Batch(cmds...)
 			}
 		}
-		return m, tickLogs()
+		return m, tea.Batch(stream, tickLogs())
+
+	case logStreamOpenedMsg:
+		return m, (&m).handleLogStreamOpened(msg)
+
+	case logDeltaMsg:
+		return m.handleLogDelta(msg)
 
 	case tickHealthMsg:
+		if m.reconnect != nil {
+			return m, tickHealth() // the reconnect countdown checks instead
+		}
 		return m, tea.Batch(m.fetchHealth, tickHealth())
+
+	case tickReconnectMsg:
+		return m, (&m).handleReconnectTick()
+
+	case tickScheduleMsg:
+		return m, tea.Batch((&m).sendDue(), m.checkQueued(), tickSchedule())
+
+	case tickPrivacyMsg:
+		(&m).checkIdle()
+		return m, m.tickPrivacy()
+
+	case scheduledSentMsg:
+		(&m).handleScheduledSent(msg)
+		if msg.err != nil {
+			return m.Update(errMsg{err: fmt.Errorf("scheduled send to %s (will retry): %w", msg.send.Target, msg.err)})
+		}
+		m.setStatus(m.deco("🕘", "sent scheduled message to "+msg.send.Target))
+		return m, m.fetchSessions
+
+	case loopStopMsg:
+		(&m).handleLoopStop(msg)
+		return m, nil
+
+	case runExportedMsg:
+		(&m).handleRunExported(msg)
+		return m, nil
+
+	case filePreviewMsg:
+		(&m).handleFilePreview(msg)
+		return m, nil
+
+	case busyCheckMsg:
+		return m, (&m).handleBusyCheck(msg)
+
+	case queuedReadyMsg:
+		return m, (&m).sendQueued(msg.ready)
+
+	case queuedSentMsg:
+		if msg.err != nil {
+			(&m).noteDenied(data.PermMessage, msg.err)
+			return m.Update(errMsg{err: fmt.Errorf("queued send to %s: %w", msg.send.target, msg.err)})
+		}
+		m.setStatus(fmt.Sprintf("%s finished %s; sent the queued message", msg.send.target, msg.send.tool))
+		return m, m.fetchSessions
+	}
+
+	// Forward anything else (clipboard paste results, cursor blinks) to
+	// whichever text input currently has focus.
+	return m, m.updateFocusedInput(msg)
+}
+
+// panicTargets returns the sessions and processes an emergency stop hits:
+// every running session and every running or active process.
+func (m Model) panicTargets() ([]data.Session, []data.Process) {
+	var sessions []data.Session
+	for _, s := range m.sessions {
+		if s.EffectiveStatus() == "running" {
+			sessions = append(sessions, s)
+		}
+	}
+	var procs []data.Process
+	for _, p := range m.processes {
+		if p.Status == "running" || p.Status == "active" {
+			procs = append(procs, p)
+		}
+	}
+	return sessions, procs
+}
+
+// emergencyStop aborts all running sessions and kills all agent processes.
+func (m Model) emergencyStop() tea.Cmd {
+	sessions, procs := m.panicTargets()
+	client := m.client
+	return func() tea.Msg {
+		var done panicDoneMsg
+		for _, s := range sessions {
+			if err := client.AbortSession(s.Key); err != nil {
+				done.errs = append(done.errs, fmt.Errorf("abort %s: %w", s.Key, err))
+				continue
+			}
+			done.aborted++
+		}
+		for _, p := range procs {
+			if err := client.StopProcess(p.SessionName); err != nil {
+				done.errs = append(done.errs, fmt.Errorf("kill %s: %w", p.SessionName, err))
+				continue
+			}
+			done.killed++
+		}
+		return done
+	}
+}
+
+// sendMessage sends text to the current message target and clears the
+// composer.
+func (m *Model) sendMessage(text string) tea.Cmd {
+	m.sending = true
+	m.msgInput.SetValue("")
+	sessionID := m.msgTarget
+	client := m.client
+	return func() tea.Msg {
+		sentAt := time.Now()
+		reply, err := client.SendMessage(sessionID, text)
+		if data.IsForbidden(err) {
+			return deniedMsg{data.PermMessage, err}
+		}
+		if err != nil {
+			return errMsg{err: fmt.Errorf("send: %w", err)}
+		}
+		return agentReplyMsg{sessionID, data.SentExchange(text, sentAt, reply)}
+	}
+}
+
+// setStatus replaces the status-bar message with text that isn't tied to a
+// retryable failure (pass "" to clear it).
+func (m *Model) setStatus(text string) {
+	m.lastError = text
+	m.lastErr = nil
+	m.lastRetry = nil
+	m.lastErrPoll = ""
+}
+
+// clearPollError clears the status-bar message when it reports a failure
+// of poll, which has just succeeded. Other messages, and failures of other
+// commands, stay until replaced.
+func (m *Model) clearPollError(poll string) {
+	if m.lastErrPoll == poll {
+		m.setStatus("")
 	}
+}
+
+// updateFocusedInput passes msg to the focused text input, if any.
+func (m *Model) updateFocusedInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	switch {
+	case m.setup != nil:
+		cmd = m.updateSetupInput(msg)
+	case m.catalog != nil && m.catalog.form != nil && len(m.catalog.form.inputs) > 0:
+		f := m.catalog.form
+		f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	case m.searching:
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		m.filter = m.searchInput.Value()
+	case m.messaging:
+		m.msgInput, cmd = m.msgInput.Update(msg)
+	case m.bulkPrompting:
+		m.bulkInput, cmd = m.bulkInput.Update(msg)
+	case m.jumping:
+		m.jumpInput, cmd = m.jumpInput.Update(msg)
+	case m.runPrompting:
+		m.runInput, cmd = m.runInput.Update(msg)
+	case m.historySearching:
+		m.historySearchInput, cmd = m.historySearchInput.Update(msg)
+	case m.editingNote:
+		m.noteInput, cmd = m.noteInput.Update(msg)
+	case m.panicking:
+		m.panicInput, cmd = m.panicInput.Update(msg)
+	case m.pending != nil:
+		m.confirmInput, cmd = m.confirmInput.Update(msg)
+	case m.spawning && m.spawnField == spawnFieldPrompt:
+		m.spawnPrompt, cmd = m.spawnPrompt.Update(msg)
+	case m.spawning && m.spawnField == spawnFieldLabel:
+		m.spawnLabel, cmd = m.spawnLabel.Update(msg)
+	case m.spawning && m.spawnField == spawnFieldDeadline:
+		m.spawnDeadline, cmd = m.spawnDeadline.Update(msg)
+	case m.spawning && m.spawnField == spawnFieldEnv:
+		m.spawnEnv, cmd = m.spawnEnv.Update(msg)
+	}
+	return cmd
+}
 
-	return m, nil
+// typing reports whether a text input has the keyboard.
+func (m Model) typing() bool {
+	return m.searching || m.messaging || m.spawning || m.bulkPrompting || m.editingNote || m.jumping || m.runPrompting ||
+		m.historySearching || m.catalog != nil && m.catalog.form != nil
 }
 
 func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	// Once the user acts, the tab they see stays
+	m.pickingTab = false
+	// The key that lifts the privacy veil does nothing else
+	if m.unveil() {
+		return *m, nil
+	}
+	// The startup gateway prompt takes over all input
+	if m.setup != nil {
+		return *m, m.handleGatewaySetup(msg)
+	}
+	// Emergency stop confirmation takes over all input
+	if m.panicking {
+		switch {
+		case key.Matches(msg, keys.Escape):
+			m.panicking = false
+			return *m, nil
+		case key.Matches(msg, keys.Enter):
+			m.panicking = false
+			if strings.TrimSpace(strings.ToLower(m.panicInput.Value())) != "yes" {
+				m.setStatus("emergency stop cancelled")
+				return *m, nil
+			}
+			return *m, m.emergencyStop()
+		default:
+			var cmd tea.Cmd
+			m.panicInput, cmd = m.panicInput.Update(msg)
+			return *m, cmd
+		}
+	}
+	// A pending confirmation takes over input too
+	if m.pending != nil {
+		return *m, m.handleConfirmation(msg)
+	}
+	// ctrl+k keeps its editing meaning inside text inputs
+	if key.Matches(msg, keys.Panic) && !m.typing() {
+		// Require a second press within two seconds before asking for "yes"
+		if time.Since(m.panicArmedAt) > 2*time.Second {
+			m.panicArmedAt = time.Now()
+			m.setStatus("press ctrl+k again for emergency stop")
+			return *m, nil
+		}
+		m.panicArmedAt = time.Time{}
+		m.panicking = true
+		m.panicInput.SetValue("")
+		m.panicInput.Focus()
+		m.setStatus("")
+		return *m, textinput.Blink
+	}
+
+	// Bracketed paste arrives as a single key event; never let its contents
+	// trigger keybindings. Pastes into the spawn form land in the prompt
+	// unless a text field is already focused.
+	if msg.Paste {
+		if m.spawning && m.spawnField != spawnFieldPrompt && m.spawnField != spawnFieldLabel && m.spawnField != spawnFieldDeadline && m.spawnField != spawnFieldEnv {
+			m.spawnField = spawnFieldPrompt
+			m.spawnLabel.Blur()
+			m.spawnPrompt.Focus()
+		}
+		return *m, m.updateFocusedInput(msg)
+	}
+
 	// Handle search input mode
 	if m.searching {
 		switch {
@@ -606,23 +1565,113 @@ func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 				return *m, nil
 			}
 			m.messaging = false
-			m.sending = true
-			m.msgInput.SetValue("")
-			sessionID := m.msgTarget
-			return *m, func() tea.Msg {
-				reply, err := m.client.SendMessage(sessionID, text)
-				if err != nil {
-					return errMsg{fmt.Errorf("send: %w", err)}
-				}
-				return agentReplyMsg{reply}
+			m.rememberPrompt(text)
+			m.noteComposed(text)
+			if isComposerCommand(text) {
+				return *m, m.runComposerCommand(text)
 			}
+			// Messages to bridged channels reach real people: preview first
+			// unless the confirm policy says otherwise
+			if data.IsExternalChannel(m.msgTargetChannel) && m.cfg.ConfirmLevel(config.ActionSend) != config.ConfirmNone {
+				m.confirmingSend = true
+				return *m, nil
+			}
+			return *m, m.sendChecked(text)
+		case key.Matches(msg, keys.Tab):
+			_, m.completions = complete(&m.msgInput, m.sessions)
+			return *m, nil
+		case msg.Type == tea.KeyUp, msg.Type == tea.KeyDown:
+			m.recallPrompt(&m.msgInput, recallDelta(msg))
+			return *m, nil
+		case msg.Type == tea.KeyCtrlR:
+			m.searchPrompt(&m.msgInput)
+			return *m, nil
 		default:
+			m.completions = nil
+			m.resetRecall()
 			var cmd tea.Cmd
 			m.msgInput, cmd = m.msgInput.Update(msg)
 			return *m, cmd
 		}
 	}
 
+	// Handle external delivery confirmation
+	if m.confirmingSend {
+		switch {
+		case key.Matches(msg, keys.ConfirmY), key.Matches(msg, keys.Enter):
+			m.confirmingSend = false
+			if m.requiresTyping(config.ActionSend) {
+				prompt := fmt.Sprintf("Deliver to %s via %s?", m.msgTargetName, m.msgTargetChannel)
+				return *m, m.confirmTyped(prompt, m.msgTargetName, func(m *Model) tea.Cmd {
+					return m.sendChecked(m.msgInput.Value())
+				})
+			}
+			return *m, m.sendChecked(m.msgInput.Value())
+		case key.Matches(msg, keys.ConfirmN), key.Matches(msg, keys.Escape):
+			// Back to the composer with the text intact for editing
+			m.confirmingSend = false
+			m.messaging = true
+			m.msgInput.Focus()
+			return *m, textinput.Blink
+		}
+		return *m, nil
+	}
+
+	// Handle jump-to-number prompt
+	if m.jumping {
+		return *m, m.handleJump(msg)
+	}
+
+	// Handle workspace command prompt
+	if m.runPrompting {
+		return *m, m.handleRunPrompt(msg)
+	}
+
+	// Handle gateway history search prompt
+	if m.historySearching {
+		return *m, m.handleHistorySearchPrompt(msg)
+	}
+
+	// Handle bulk spawn file prompt
+	if m.bulkPrompting {
+		switch {
+		case key.Matches(msg, keys.Escape):
+			m.bulkPrompting = false
+			return *m, nil
+		case key.Matches(msg, keys.Enter):
+			m.bulkPrompting = false
+			path := strings.TrimSpace(m.bulkInput.Value())
+			if path == "" {
+				return *m, nil
+			}
+			return *m, m.startBulkSpawn(path)
+		default:
+			var cmd tea.Cmd
+			m.bulkInput, cmd = m.bulkInput.Update(msg)
+			return *m, cmd
+		}
+	}
+
+	// Handle session note editor
+	if m.editingNote {
+		switch {
+		case key.Matches(msg, keys.Escape):
+			m.editingNote = false
+			m.runEdit = nil
+			return *m, nil
+		case key.Matches(msg, keys.Enter) && m.runEdit != nil:
+			return *m, m.runEditStep(m.noteInput.Value())
+		case key.Matches(msg, keys.Enter):
+			m.editingNote = false
+			m.saveNote(m.noteInput.Value())
+			return *m, nil
+		default:
+			var cmd tea.Cmd
+			m.noteInput, cmd = m.noteInput.Update(msg)
+			return *m, cmd
+		}
+	}
+
 	// Handle spawn form mode
 	if m.spawning {
 		switch {
@@ -630,19 +1679,53 @@ func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.spawning = false
 			m.spawnPrompt.SetValue("")
 			m.spawnLabel.SetValue("")
+			m.spawnDeadline.SetValue("")
+			m.spawnEnv.SetValue("")
 			m.spawnModelCursor = 0
+			m.spawnModels = nil
 			return *m, nil
 		case key.Matches(msg, keys.Tab):
+			// Tab completes in the prompt when the word before the cursor is
+			// completable, and otherwise moves to the next field.
+			if m.spawnField == spawnFieldPrompt {
+				var ok bool
+				if ok, m.completions = complete(&m.spawnPrompt, m.sessions); ok {
+					return *m, nil
+				}
+			}
+			m.completions = nil
 			m.spawnField = (m.spawnField + 1) % spawnFieldCount
 			m.spawnPrompt.Blur()
 			m.spawnLabel.Blur()
+			m.spawnDeadline.Blur()
+			m.spawnEnv.Blur()
 			switch m.spawnField {
 			case spawnFieldPrompt:
 				m.spawnPrompt.Focus()
 			case spawnFieldLabel:
 				m.spawnLabel.Focus()
+			case spawnFieldDeadline:
+				m.spawnDeadline.Focus()
+			case spawnFieldEnv:
+				m.spawnEnv.Focus()
 			}
 			return *m, textinput.Blink
+		case m.spawnField == spawnFieldPrompt && (msg.Type == tea.KeyUp || msg.Type == tea.KeyDown):
+			m.recallPrompt(&m.spawnPrompt, recallDelta(msg))
+			return *m, nil
+		case m.spawnField == spawnFieldPrompt && msg.Type == tea.KeyCtrlR:
+			m.searchPrompt(&m.spawnPrompt)
+			return *m, nil
+		case m.spawnField == spawnFieldParent && (key.Matches(msg, keys.Up) || key.Matches(msg, keys.Down)):
+			if len(m.spawnParents) == 0 {
+				return *m, nil
+			}
+			delta := 1
+			if key.Matches(msg, keys.Up) {
+				delta = -1
+			}
+			m.spawnParentCursor = (m.spawnParentCursor + delta + len(m.spawnParents)) % len(m.spawnParents)
+			return *m, nil
 		case m.spawnField == spawnFieldModel && (key.Matches(msg, keys.Up) || key.Matches(msg, keys.Down)):
 			delta := 1
 			if key.Matches(msg, keys.Up) {
@@ -656,75 +1739,126 @@ func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 				m.spawnModelCursor = 0
 			}
 			return *m, nil
+		case m.spawnField == spawnFieldModel && msg.Type == tea.KeySpace:
+			m.toggleMatrixModel()
+			return *m, nil
+		case m.spawnField == spawnFieldEnv && msg.Type == tea.KeyCtrlT:
+			m.toggleEnvMask()
+			return *m, nil
 		case key.Matches(msg, keys.Enter):
 			prompt := m.spawnPrompt.Value()
 			if prompt == "" {
-				m.lastError = "prompt is required"
+				m.setStatus("prompt is required")
 				return *m, nil
 			}
-			// Extract model ID (strip alias display suffix)
-			model := ""
-			selected := m.spawnModelOptions[m.spawnModelCursor]
-			if selected != "(default)" {
-				// Strip "  (alias)" suffix if present
-				if idx := strings.Index(selected, "  ("); idx > 0 {
-					selected = selected[:idx]
-				}
-				model = selected
-			}
+			model := spawnModelID(m.spawnModelOptions[m.spawnModelCursor])
 			label := m.spawnLabel.Value()
+			if label == "" {
+				label = autoLabel(prompt, m.takenLabels())
+			}
 
-			// Find the main session
-			mainSessionID := ""
-			for _, s := range m.sessions {
-				if s.Kind == "main" || strings.HasSuffix(s.Key, ":main") {
-					mainSessionID = s.SessionID
-					break
+			var deadline time.Duration
+			if v := strings.TrimSpace(m.spawnDeadline.Value()); v != "" {
+				d, ok := parseDeadline(v)
+				if !ok {
+					m.setStatus("invalid expected duration " + v + " (try 45m or 2h)")
+					return *m, nil
+				}
+				deadline = d
+				// Deadlines are tracked by label
+				if label == "" {
+					label = "task-" + time.Now().Format("150405")
 				}
 			}
-			if mainSessionID == "" {
-				m.lastError = "no main session found"
+
+			env, err := data.ParseEnv(m.spawnEnv.Value())
+			if err != nil {
+				m.setStatus("env: " + err.Error())
+				return *m, nil
+			}
+
+			if len(m.spawnParents) == 0 {
+				m.setStatus("no parent session found")
 				return *m, nil
 			}
+			parentSessionID := m.spawnParents[m.spawnParentCursor].SessionID
+			m.rememberPrompt(prompt)
+			if len(m.spawnModels) > 0 {
+				cmd := m.startSpawnMatrix(prompt, label, env, m.spawnParents[m.spawnParentCursor])
+				m.bulk.deadline = deadline
+				return *m, cmd
+			}
 
 			m.spawnSpinning = true
-			m.lastError = ""
+			m.spawnErr = nil
+			m.setStatus("")
 			client := m.client
 			return *m, func() tea.Msg {
-				result, err := client.SpawnSession(mainSessionID, prompt, model, label)
+				result, err := client.SpawnSession(parentSessionID, prompt, model, label, env)
 				if err != nil {
-					return errMsg{fmt.Errorf("spawn: %w", err)}
+					return spawnFailedMsg{err}
 				}
-				return spawnSuccessMsg{result}
+				return spawnSuccessMsg{result, label, deadline}
 			}
 		default:
+			m.completions = nil
+			m.resetRecall()
 			var cmd tea.Cmd
 			switch m.spawnField {
 			case spawnFieldPrompt:
 				m.spawnPrompt, cmd = m.spawnPrompt.Update(msg)
 			case spawnFieldLabel:
 				m.spawnLabel, cmd = m.spawnLabel.Update(msg)
+			case spawnFieldDeadline:
+				m.spawnDeadline, cmd = m.spawnDeadline.Update(msg)
+			case spawnFieldEnv:
+				m.spawnEnv, cmd = m.spawnEnv.Update(msg)
 			}
 			return *m, cmd
 		}
 	}
 
-	// Handle confirmation mode
-	if m.confirming {
+	// Handle retention purge confirmation
+	if m.confirmingPurge {
 		switch {
 		case key.Matches(msg, keys.ConfirmY):
-			m.confirming = false
-			target := m.confirmTarget
-			m.confirmTarget = ""
-			return *m, killProcess(target)
+			m.confirmingPurge = false
+			plan := m.purgePlan
+			m.purgePlan = nil
+			if m.requiresTyping(config.ActionPurge) {
+				prompt := fmt.Sprintf("Purge %d archived runs?", len(plan))
+				return *m, m.confirmTyped(prompt, "purge", runs(m.purge(plan)))
+			}
+			return *m, m.purge(plan)
 		case key.Matches(msg, keys.ConfirmN), key.Matches(msg, keys.Escape):
-			m.confirming = false
-			m.confirmTarget = ""
+			m.confirmingPurge = false
+			m.purgePlan = nil
 			return *m, nil
 		}
 		return *m, nil
 	}
 
+	// Handle confirmation mode
+	if m.confirming {
+		return *m, m.handleKillMenu(msg)
+	}
+
+	if m.busySend != nil {
+		return *m, m.handleBusySend(msg)
+	}
+
+	if m.catalog != nil {
+		return *m, m.handleCatalogKey(msg)
+	}
+
+	if m.preview != nil && !m.typing() {
+		return *m, m.handlePreviewKey(msg)
+	}
+
+	if n, ok := favoriteNumber(msg); ok {
+		return *m, m.jumpToFavorite(n)
+	}
+
 	switch {
 	case key.Matches(msg, keys.Quit):
 		return *m, tea.Quit
@@ -733,6 +1867,9 @@ func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 		if m.activePanel == panelList {
 			m.moveCursor(-1)
 		} else {
+			if m.logScrollPos == 0 {
+				return *m, m.loadOlder()
+			}
 			m.logScrollPos = max(0, m.logScrollPos-1)
 			m.clampLogScroll(m.logWidth())
 			m.logFollow = false
@@ -754,6 +1891,9 @@ func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 
 	case key.Matches(msg, keys.PageUp):
 		if m.activePanel == panelLogs {
+			if m.logScrollPos == 0 {
+				return *m, m.loadOlder()
+			}
 			pageSize := m.logViewHeight() - 3
 			if pageSize < 1 {
 				pageSize = 10
@@ -761,6 +1901,8 @@ func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.logScrollPos = max(0, m.logScrollPos-pageSize)
 			m.clampLogScroll(m.logWidth())
 			m.logFollow = false
+		} else {
+			m.moveCursor(-m.listRows())
 		}
 		return *m, nil
 
@@ -776,6 +1918,8 @@ func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 			if m.isAtBottom(m.logWidth()) {
 				m.logFollow = true
 			}
+		} else {
+			m.moveCursor(m.listRows())
 		}
 		return *m, nil
 
@@ -796,6 +1940,7 @@ func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 			m.activePanel = panelList
 			return *m, nil
 		}
+		m.dismissError()
 		return *m, nil
 
 	case key.Matches(msg, keys.Tab1):
@@ -806,130 +1951,675 @@ func (m *Model) handleKey(msg tea.KeyMsg) (Model, tea.Cmd) {
 		m.activeTab = tabProcesses
 		return *m, nil
 
-	case key.Matches(msg, keys.Tab3):
-		m.activeTab = tabHistory
-		return *m, nil
+	case key.Matches(msg, keys.Tab3):
+		m.activeTab = tabHistory
+		return *m, nil
+
+	case key.Matches(msg, keys.Tab4):
+		m.activeTab = tabActivity
+		return *m, nil
+
+	case key.Matches(msg, keys.Enter):
+		if m.activePanel == panelLogs {
+			if cmd, ok := m.followSpawnLink(); ok {
+				return *m, cmd
+			}
+			if cmd, ok := m.followSearchHit(); ok {
+				return *m, cmd
+			}
+		}
+		id := m.selectedItemID()
+		if id != "" {
+			m.logTrail = nil
+			tab := m.activeTab
+			if tab == tabActivity {
+				tab = tabSessions // events open their session
+			}
+			return *m, m.openLog(id, tab)
+		}
+		if m.filteredListLen() == 0 {
+			return *m, m.runEmptyAction()
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.Back):
+		return *m, m.followBack()
+
+	case key.Matches(msg, keys.Pin):
+		m.pinToolOutput()
+		return *m, nil
+
+	case key.Matches(msg, keys.Unpin):
+		m.pinned = nil
+		return *m, nil
+
+	case key.Matches(msg, keys.Compact):
+		return *m, m.compactSelected()
+
+	case key.Matches(msg, keys.Outputs):
+		return *m, m.showOutputs()
+
+	case key.Matches(msg, keys.Run):
+		return *m, m.openRunPrompt()
+
+	case key.Matches(msg, keys.OpenImage):
+		return *m, m.openImage()
+
+	case key.Matches(msg, keys.CopyPath):
+		return *m, m.copyImagePath()
+
+	case key.Matches(msg, keys.CopyLogPath):
+		return *m, m.copyLogPath()
+
+	case key.Matches(msg, keys.Kill):
+		switch {
+		case m.activeTab == tabProcesses && m.can(data.PermKill):
+			return *m, m.openKillMenu()
+		case m.activeTab == tabSessions:
+			return *m, m.abortSelected()
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.TokenSplit):
+		m.state.TokenSplit = !m.state.TokenSplit
+		m.state.Save()
+		if m.state.TokenSplit {
+			m.setStatus("tokens: input/output")
+		} else {
+			m.setStatus("tokens: total")
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.Preview):
+		return *m, m.openFilePreview()
+
+	case key.Matches(msg, keys.Lock):
+		return *m, m.toggleLock()
+
+	case key.Matches(msg, keys.Tools):
+		return *m, m.openToolCatalog()
+
+	case key.Matches(msg, keys.Favorite):
+		m.toggleFavorite()
+		return *m, nil
+
+	case key.Matches(msg, keys.FollowLatest):
+		return *m, m.toggleFollowLatest()
+
+	case key.Matches(msg, keys.SplitLeft):
+		m.resizeSplit(-1)
+		return *m, nil
+
+	case key.Matches(msg, keys.SplitRight):
+		m.resizeSplit(1)
+		return *m, nil
+
+	case key.Matches(msg, keys.HistorySearch):
+		return *m, m.openHistorySearch()
+
+	case key.Matches(msg, keys.Compare):
+		return *m, m.compareMatrix()
+
+	case key.Matches(msg, keys.Archive):
+		return *m, m.archiveSelected()
+
+	case key.Matches(msg, keys.RestartPolicy):
+		m.cycleRestartPolicy()
+		return *m, nil
+
+	case key.Matches(msg, keys.NotAgent):
+		m.ignoreProcess()
+		return *m, nil
+
+	case key.Matches(msg, keys.ErrorDetail):
+		m.showErrorDetail()
+		return *m, nil
+
+	case key.Matches(msg, keys.Retry):
+		if m.reconnect != nil {
+			return *m, m.retryGateway()
+		}
+		if m.lastRetry == nil {
+			return *m, nil
+		}
+		retry, poll := m.lastRetry, m.lastErrPoll
+		m.setStatus("retrying...")
+		m.lastErrPoll = poll // cleared when the retried poll succeeds
+		return *m, retry
+
+	case key.Matches(msg, keys.Diff):
+		if m.workspaceLine() == "" {
+			return *m, nil
+		}
+		m.diffView = !m.diffView
+		m.activePanel = panelLogs
+		if m.diffView {
+			m.logFollow = false
+			m.logContent = "Loading diff..."
+			return *m, m.fetchDiff()
+		}
+		m.logContentHash = ""
+		m.logFollow = true
+		return *m, m.fetchLogs(m.selectedLogID)
+
+	case key.Matches(msg, keys.SortHistory):
+		if m.activeTab != tabHistory {
+			return *m, nil
+		}
+		m.state.HistorySort = nextHistorySort(m.state.HistorySort)
+		m.resortArchived()
+		m.historyCursor = 0
+		if err := m.state.Save(); err != nil {
+			m.setStatus("sort: " + err.Error())
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.HistoryRange):
+		if m.activeTab != tabHistory {
+			return *m, nil
+		}
+		m.historyRange = nextHistoryRange(m.historyRange)
+		m.historyCursor = 0
+		return *m, nil
+
+	case key.Matches(msg, keys.HistoryDates):
+		if m.activeTab != tabHistory {
+			return *m, nil
+		}
+		m.state.AbsoluteDates = !m.state.AbsoluteDates
+		if err := m.state.Save(); err != nil {
+			m.setStatus("dates: " + err.Error())
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.Project):
+		if m.activeTab != tabSessions {
+			return *m, nil
+		}
+		m.state.Project = nextProjectView(m.sessions, m.state.Project)
+		m.sessionCursor = 0
+		if err := m.state.Save(); err != nil {
+			m.setStatus("project: " + err.Error())
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.MainWidget):
+		m.state.MainWidget = !m.state.MainWidget
+		if err := m.state.Save(); err != nil {
+			m.setStatus("widget: " + err.Error())
+		}
+		m.clampLogScroll(m.logWidth())
+		return *m, m.fetchMainWidget()
+
+	case key.Matches(msg, keys.Analytics):
+		m.showAnalytics()
+		return *m, m.indexArchive()
+
+	case key.Matches(msg, keys.ProcessInfo):
+		if m.activeTab == tabProcesses {
+			m.showProcessDetail()
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.Health):
+		return *m, m.showHealth()
+
+	case key.Matches(msg, keys.Scheduled):
+		m.showScheduled()
+		return *m, nil
+
+	case key.Matches(msg, keys.Trace):
+		m.showLogView("Gateway requests", data.FormatTrace(m.client.Trace()))
+		return *m, nil
+
+	case key.Matches(msg, keys.Export):
+		// From the session list, export the sessions instead of the log
+		if m.activePanel == panelList && m.activeTab == tabSessions {
+			path, err := m.exportSessionsCSV()
+			if err != nil {
+				m.setStatus("export: " + err.Error())
+			} else if path != "" {
+				m.setStatus("exported sessions to " + shortenHome(path))
+			}
+			return *m, nil
+		}
+		// From the History list, push the run to its exporters if any match
+		if m.activePanel == panelList && m.activeTab == tabHistory {
+			if m.exportRun() {
+				return *m, nil
+			}
+		}
+		// A process log saves everything captured, not just the last fetch
+		if m.procLogs[m.selectedLogID] != nil && m.selectedLogTab == tabProcesses && m.logView == "" && !m.diffView {
+			m.saveProcessLog()
+			return *m, nil
+		}
+		path, err := m.exportLogView()
+		if err != nil {
+			m.setStatus("export: " + err.Error())
+		} else if path != "" {
+			m.setStatus("exported view to " + shortenHome(path))
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.Purge):
+		if !m.cfg.Retention.Enabled() {
+			m.setStatus("no retention policy configured in " + config.CommanderPath())
+			return *m, nil
+		}
+		return *m, m.showRetentionPlan(true)
+
+	case key.Matches(msg, keys.Search):
+		m.searching = true
+		m.searchInput.Focus()
+		return *m, textinput.Blink
+
+	case key.Matches(msg, keys.Follow):
+		m.logFollow = !m.logFollow
+		if m.logFollow {
+			m.logIdle = 0
+			m.logScrollPos = m.maxLogScroll(m.logWidth())
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.SourceFilter):
+		// Cycle through source filters: all -> signal -> matrix -> all
+		switch m.sourceFilter {
+		case "":
+			m.sourceFilter = "signal"
+		case "signal":
+			m.sourceFilter = "matrix"
+		case "matrix":
+			m.sourceFilter = ""
+		}
+		// Re-render cached messages with new filter
+		return *m, m.reformatLog()
+
+	case key.Matches(msg, keys.RawLog):
+		return *m, m.toggleRawLog()
+
+	case key.Matches(msg, keys.Thinking):
+		m.showThinking = !m.showThinking
+		return *m, m.reformatLog()
+
+	case key.Matches(msg, keys.Verbose):
+		m.verboseLevel = m.verboseLevel.Next()
+		// Re-render cached messages if we have them
+		return *m, m.reformatLog()
+
+	case key.Matches(msg, keys.Message):
+		if m.activeTab == tabSessions && m.can(data.PermMessage) {
+			ss := m.filteredSessions()
+			if m.sessionCursor < len(ss) {
+				return *m, m.openComposer(ss[m.sessionCursor], "")
+			}
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.Timeline):
+		return *m, m.openTimeline(timelineHours * time.Hour)
+
+	case key.Matches(msg, keys.MessageLast):
+		return *m, m.messageLast()
+
+	case key.Matches(msg, keys.Jump):
+		return *m, m.openJump()
+
+	case key.Matches(msg, keys.Note):
+		switch m.activeTab {
+		case tabSessions:
+			return *m, m.openNoteEditor()
+		case tabHistory:
+			return *m, m.openRunEditor()
+		}
+		return *m, nil
+
+	case key.Matches(msg, keys.Spawn):
+		if !m.can(data.PermSpawn) {
+			return *m, nil
+		}
+		return *m, m.openSpawnForm()
+
+	case key.Matches(msg, keys.BulkSpawn):
+		if m.bulk != nil && !m.bulk.finished() {
+			m.showLogView("Bulk spawn", m.renderBulkTable())
+			return *m, nil
+		}
+		if !m.can(data.PermSpawn) {
+			return *m, nil
+		}
+		m.bulkPrompting = true
+		m.bulkInput.Focus()
+		return *m, textinput.Blink
+	}
+
+	return *m, nil
+}
+
+// openLog shows the logs of item id from tab in the log panel and starts
+// following them.
+func (m *Model) openLog(id string, tab int) tea.Cmd {
+	m.selectedLogID = id
+	m.logView = ""
+	m.selectedLogTab = tab
+	m.logDepth = m.defaultLogDepth(tab)
+	m.logComplete = false
+	m.cachedMessages = nil // a stream merges into these until the fetch lands
+	m.activePanel = panelLogs
+	// Don't clear logContent immediately - let the fetch update it
+	// This way if fetch fails, we still show something
+	if m.logContent == "" {
+		m.logContent = "Loading..."
+	}
+	m.logScrollPos = 0  // Reset scroll position
+	m.logFollow = true  // Enable follow for new selection
+	m.logIdle = 0
+	m.imagePaths = nil
+	m.preview = nil
+	m.dropSpill()
+	// Invalidate cache when selecting new log (using hash)
+	m.wrappedLinesHash = ""
+	m.lastLogWidth = 0
+	m.wrappedLines = nil
+	m.diffView = false
+	if m.workspaceFor != id {
+		m.workspace = nil
+	}
+	cmds := []tea.Cmd{m.fetchLogs(id), tickLogs()}
+	if tab == tabSessions {
+		cmds = append(cmds, m.fetchWorkspace(id))
+	}
+	return tea.Batch(cmds...)
+}
+
+// takenLabels returns the labels of live sessions and archived runs.
+func (m Model) takenLabels() map[string]bool {
+	taken := make(map[string]bool, len(m.sessions)+len(m.archived))
+	for _, s := range m.sessions {
+		taken[s.Label] = true
+	}
+	for _, r := range m.archived {
+		taken[r.Label] = true
+	}
+	return taken
+}
+
+// autoLabel derives a label for an unlabeled spawn from its prompt, unique
+// among taken, which it then adds to. It returns "" if the prompt has no
+// usable words.
+func autoLabel(prompt string, taken map[string]bool) string {
+	label := data.LabelFromPrompt(prompt)
+	if label == "" {
+		return ""
+	}
+	label = data.UniqueLabel(label, taken)
+	taken[label] = true
+	return label
+}
+
+// openSpawnForm resets and shows the spawn form, loading model options.
+func (m *Model) openSpawnForm() tea.Cmd {
+	m.spawning = true
+	m.spawnErr = nil
+	m.completions = nil
+	m.resetRecall()
+	m.spawnField = spawnFieldPrompt
+	m.spawnPrompt.SetValue("")
+	m.spawnModelCursor = 0
+	m.spawnModels = nil
+	m.spawnLabel.SetValue("")
+	m.spawnDeadline.SetValue("")
+	m.spawnEnv.SetValue("")
+	m.spawnEnv.EchoMode = textinput.EchoPassword
+	m.spawnParents = spawnParentCandidates(m.sessions)
+	m.spawnParentCursor = 0
+	m.spawnPrompt.Focus()
+	m.spawnLabel.Blur()
+	m.spawnDeadline.Blur()
+	m.spawnEnv.Blur()
+	client := m.client
+	return tea.Batch(textinput.Blink, func() tea.Msg {
+		models, _ := client.FetchConfiguredModels()
+		return modelListMsg{models}
+	})
+}
+
+// emptyAction is an action offered in place of an empty list.
+type emptyAction int
+
+const (
+	emptyActionClearFilter emptyAction = iota
+	emptyActionSpawn
+	emptyActionRefresh
+	emptyActionCheckGateway
+	emptyActionShowConfig
+)
+
+// emptyActions returns the actions offered when the active list is empty.
+func (m Model) emptyActions() []emptyAction {
+	var actions []emptyAction
+	if m.filter != "" {
+		actions = append(actions, emptyActionClearFilter)
+	}
+	switch m.activeTab {
+	case tabSessions:
+		if len(spawnParentCandidates(m.sessions)) > 0 {
+			actions = append(actions, emptyActionSpawn)
+		}
+		actions = append(actions, emptyActionCheckGateway)
+	case tabProcesses:
+		actions = append(actions, emptyActionRefresh, emptyActionCheckGateway)
+	case tabHistory, tabActivity:
+		actions = append(actions, emptyActionRefresh)
+	}
+	return append(actions, emptyActionShowConfig)
+}
+
+func (m Model) emptyActionLabel(a emptyAction) string {
+	switch a {
+	case emptyActionClearFilter:
+		return m.deco("✖", "Clear filter \""+m.filter+"\"")
+	case emptyActionSpawn:
+		return m.deco("🚀", "Spawn a new agent")
+	case emptyActionRefresh:
+		return m.deco("↻", "Refresh now")
+	case emptyActionCheckGateway:
+		return m.deco("🔌", "Check gateway connectivity ("+m.cfg.GatewayURL+")")
+	case emptyActionShowConfig:
+		return m.deco("⚙", "Show config in use ("+shortenHome(config.OpenclawPath())+")")
+	}
+	return ""
+}
 
-	case key.Matches(msg, keys.Enter):
-		id := m.selectedItemID()
-		if id != "" {
-			m.selectedLogID = id
-			m.selectedLogTab = m.activeTab
-			m.activePanel = panelLogs
-			// Don't clear logContent immediately - let the fetch update it
-			// This way if fetch fails, we still show something
-			if m.logContent == "" {
-				m.logContent = "Loading..."
-			}
-			m.logScrollPos = 0  // Reset scroll position
-			m.logFollow = true  // Enable follow for new selection
-			// Invalidate cache when selecting new log (using hash)
-			m.wrappedLinesHash = ""
-			m.lastLogWidth = 0
-			m.wrappedLines = nil
-			return *m, tea.Batch(m.fetchLogs(id), tickLogs())
-		}
-		return *m, nil
+// runEmptyAction performs the empty-state action under the cursor.
+func (m *Model) runEmptyAction() tea.Cmd {
+	actions := m.emptyActions()
+	cursor := m.currentCursor()
+	if cursor >= len(actions) {
+		return nil
+	}
+	switch actions[cursor] {
+	case emptyActionClearFilter:
+		m.filter = ""
+		m.searchInput.SetValue("")
+		m.setCursor(0)
+	case emptyActionSpawn:
+		return m.openSpawnForm()
+	case emptyActionRefresh:
+		switch m.activeTab {
+		case tabProcesses:
+			return m.fetchProcesses
+		case tabHistory, tabActivity:
+			return m.fetchSessions
+		}
+	case emptyActionCheckGateway:
+		m.setStatus("checking gateway " + m.cfg.GatewayURL + "...")
+		return tea.Batch(m.fetchHealth, m.fetchSessions)
+	case emptyActionShowConfig:
+		m.showConfigInfo()
+	}
+	return nil
+}
 
-	case key.Matches(msg, keys.Kill):
-		id := m.selectedItemID()
-		if id != "" && m.activeTab == tabProcesses {
-			m.confirming = true
-			m.confirmTarget = id
+// showConfigInfo lists the configuration sources in use in the log panel.
+func (m *Model) showConfigInfo() {
+	fileState := func(path string) string {
+		if _, err := os.Stat(path); err != nil {
+			return "(not found)"
 		}
-		return *m, nil
+		return "(found)"
+	}
+	token := "(none)"
+	if m.cfg.Token != "" {
+		token = "(set, " + fmt.Sprint(len(m.cfg.Token)) + " chars)"
+	}
+	proxy := m.cfg.Proxy
+	if proxy == "" {
+		proxy = "(from environment)"
+	}
+	home, _ := os.UserHomeDir()
+	sessDir := filepath.Join(home, ".openclaw", "agents", "main", "sessions")
+	procFile := filepath.Join(home, ".openclaw", "process-list.json")
 
-	case key.Matches(msg, keys.Search):
-		m.searching = true
-		m.searchInput.Focus()
-		return *m, textinput.Blink
+	var b strings.Builder
+	b.WriteString("Configuration in use\n\n")
+	b.WriteString("  Gateway URL:     " + m.cfg.GatewayURL + "\n")
+	b.WriteString("  Gateway token:   " + token + "\n")
+	b.WriteString("  Proxy:           " + proxy + "\n\n")
+	b.WriteString("  Gateway config:  " + config.OpenclawPath() + " " + fileState(config.OpenclawPath()) + "\n")
+	b.WriteString("  Commander prefs: " + config.CommanderPath() + " " + fileState(config.CommanderPath()) + "\n")
+	b.WriteString("  Transcripts:     " + sessDir + " " + fileState(sessDir) + "\n")
+	b.WriteString("  Process list:    " + procFile + " " + fileState(procFile) + "\n\n")
+	b.WriteString("Sessions are listed with `openclaw sessions --json`; make sure the\n")
+	b.WriteString("openclaw CLI is on PATH and the gateway is running.\n")
+
+	m.showLogView("Config", b.String())
+}
 
-	case key.Matches(msg, keys.Follow):
-		m.logFollow = !m.logFollow
-		if m.logFollow {
-			m.logScrollPos = m.maxLogScroll(m.logWidth())
+// renderEmptyState renders guidance and selectable actions for an empty list.
+func (m Model) renderEmptyState(message string) string {
+	var b strings.Builder
+	b.WriteString(dimStyle.Render("  "+message) + "\n\n")
+	cursor := m.currentCursor()
+	for i, a := range m.emptyActions() {
+		line := "  " + m.emptyActionLabel(a)
+		if i == cursor && m.activePanel == panelList {
+			line = selectedStyle.Render(m.cursorMark(true) + m.emptyActionLabel(a))
 		}
-		return *m, nil
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n" + dimStyle.Render("  ↑↓ choose  ↵ run"))
+	return b.String()
+}
 
-	case key.Matches(msg, keys.SourceFilter):
-		// Cycle through source filters: all -> signal -> matrix -> all
-		switch m.sourceFilter {
-		case "":
-			m.sourceFilter = "signal"
-		case "signal":
-			m.sourceFilter = "matrix"
-		case "matrix":
-			m.sourceFilter = ""
+// spawnParentCandidates returns the sessions a sub-agent can be attached to:
+// every non-subagent session with a session ID, main sessions first.
+func spawnParentCandidates(sessions []data.Session) []data.Session {
+	var mains, others []data.Session
+	for _, s := range sessions {
+		if s.SessionID == "" || s.IsSubagent() {
+			continue
 		}
-		// Re-render cached messages with new filter
-		if len(m.cachedMessages) > 0 && m.selectedLogTab != tabProcesses {
-			filtered := m.filterMessagesBySource(m.cachedMessages)
-			m.logContent = compressLogContent(data.FormatHistory(filtered, m.verboseLevel))
-			if m.logFollow {
-				m.logScrollPos = m.maxLogScroll(m.logWidth())
-			} else {
-				m.clampLogScroll(m.logWidth())
-			}
+		if s.Kind == "main" || strings.HasSuffix(s.Key, ":main") {
+			mains = append(mains, s)
+		} else {
+			others = append(others, s)
 		}
-		return *m, nil
+	}
+	return append(mains, others...)
+}
 
-	case key.Matches(msg, keys.Verbose):
-		m.verboseLevel = m.verboseLevel.Next()
-		// Re-render cached messages if we have them
-		if len(m.cachedMessages) > 0 && m.selectedLogTab != tabProcesses {
-			filtered := m.filterMessagesBySource(m.cachedMessages)
-			m.logContent = compressLogContent(data.FormatHistory(filtered, m.verboseLevel))
-			if m.logFollow {
-				m.logScrollPos = m.maxLogScroll(m.logWidth())
-			} else {
-				m.clampLogScroll(m.logWidth())
-			}
-		}
-		return *m, nil
+// purge deletes the archived runs in plan.
+func (m Model) purge(plan []data.ArchivedRun) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		removed, err := client.PurgeArchivedRuns(plan)
+		return purgeDoneMsg{removed, err}
+	}
+}
 
-	case key.Matches(msg, keys.Message):
-		if m.activeTab == tabSessions {
-			ss := m.filteredSessions()
-			if m.sessionCursor < len(ss) {
-				s := ss[m.sessionCursor]
-				m.msgTarget = s.SessionID
-				m.msgTargetName = sessionDisplayName(s)
-				m.messaging = true
-				m.msgInput.Focus()
-				return *m, textinput.Blink
-			}
+// showRetentionPlan evaluates the retention policy against the archive and,
+// if anything would be purged, lists it in the log panel and asks for
+// confirmation, or purges at once when the confirm policy allows. When
+// manual is set, an empty plan is reported too.
+func (m *Model) showRetentionPlan(manual bool) tea.Cmd {
+	plan := data.PlanRetention(m.archived, m.cfg.Retention, time.Now())
+	if len(plan) == 0 {
+		if manual {
+			m.setStatus("retention: nothing to purge")
 		}
-		return *m, nil
+		return nil
+	}
 
-	case key.Matches(msg, keys.Spawn):
-		m.spawning = true
-		m.spawnField = spawnFieldPrompt
-		m.spawnPrompt.SetValue("")
-		m.spawnModelCursor = 0
-		m.spawnLabel.SetValue("")
-		m.spawnPrompt.Focus()
-		m.spawnLabel.Blur()
-		client := m.client
-		return *m, tea.Batch(textinput.Blink, func() tea.Msg {
-			models, _ := client.FetchConfiguredModels()
-			return modelListMsg{models}
-		})
+	var total int64
+	var b strings.Builder
+	b.WriteString("Retention policy would purge these archived runs:\n\n")
+	for _, r := range plan {
+		total += r.Size
+		label := r.Label
+		if label == "" {
+			label = r.SessionID
+		}
+		if len(label) > 60 {
+			label = label[:57] + "..."
+		}
+		b.WriteString(fmt.Sprintf("  %6dK  %5s  %s\n", r.Size/1024,
+			formatDuration(time.Since(time.UnixMilli(r.ModifiedAt))), label))
+	}
+	if m.cfg.ConfirmLevel(config.ActionPurge) == config.ConfirmNone {
+		b.WriteString(fmt.Sprintf("\n%d runs, %.1f MB total. Purging.\n", len(plan), float64(total)/(1024*1024)))
+		m.showLogView("Retention", b.String())
+		return m.purge(plan)
 	}
+	b.WriteString(fmt.Sprintf("\n%d runs, %.1f MB total. Press y to purge, n to keep.\n",
+		len(plan), float64(total)/(1024*1024)))
 
-	return *m, nil
+	m.purgePlan = plan
+	m.confirmingPurge = true
+	m.showLogView("Retention", b.String())
+	return nil
 }
 
-func killProcess(sessionID string) tea.Cmd {
-	return func() tea.Msg {
-		// placeholder — actual kill would use a different API call
-		return tickProcessesMsg{}
+// showLogView replaces the log panel with generated content (a report or
+// progress table) titled view, detaching it from any selected item.
+func (m *Model) showLogView(view, content string) {
+	m.logView = view
+	m.selectedLogID = ""
+	m.cachedMessages = nil
+	m.imagePaths = nil
+	m.diffView = false
+	m.preview = nil
+	m.dropSpill()
+	m.logContent = content
+	m.logContentHash = fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+	m.logScrollPos = 0
+	m.logFollow = false
+	m.activePanel = panelLogs
+}
+
+// refreshLogView replaces the content of the generated view already shown,
+// as a background refresh does: focus, scroll position, and follow stay as
+// the user left them.
+func (m *Model) refreshLogView(content string) {
+	m.logContent = content
+	m.logContentHash = fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
+	m.clampLogScroll(m.logWidth())
+}
+
+// listRows returns how many list entries fit on one page, matching the
+// heights View passes to the list renderers.
+func (m Model) listRows() int {
+	contentHeight := max(5, m.height-4-m.headerHeight()-m.widgetHeight())
+	if m.cfg.A11y {
+		contentHeight = max(5, m.height/3)
 	}
+	return max(1, contentHeight-4) // tabs, search bar, title
 }
 
 func (m *Model) moveCursor(delta int) {
 	listLen := m.filteredListLen()
 	if listLen == 0 {
-		return
+		listLen = len(m.emptyActions())
 	}
 	cursor := m.currentCursor()
 	cursor += delta
@@ -948,6 +2638,8 @@ func (m Model) currentCursor() int {
 		return m.sessionCursor
 	case tabHistory:
 		return m.historyCursor
+	case tabActivity:
+		return m.eventCursor
 	default:
 		return m.processCursor
 	}
@@ -959,6 +2651,8 @@ func (m *Model) setCursor(v int) {
 		m.sessionCursor = v
 	case tabHistory:
 		m.historyCursor = v
+	case tabActivity:
+		m.eventCursor = v
 	default:
 		m.processCursor = v
 	}
@@ -970,24 +2664,22 @@ func (m Model) filteredListLen() int {
 		return len(m.filteredSessions())
 	case tabHistory:
 		return len(m.filteredArchived())
+	case tabActivity:
+		return len(m.filteredEvents())
 	default:
 		return len(m.filteredProcesses())
 	}
 }
 
 func (m Model) filteredSessions() []data.Session {
+	sessions := applyProjectView(m.visibleSessions(), m.state.Project)
 	if m.filter == "" {
-		return m.sessions
+		return sessions
 	}
 	var out []data.Session
-	f := strings.ToLower(m.filter)
-	for _, s := range m.sessions {
-		if strings.Contains(strings.ToLower(s.Key), f) ||
-			strings.Contains(strings.ToLower(s.Model), f) ||
-			strings.Contains(strings.ToLower(s.Kind), f) ||
-			strings.Contains(strings.ToLower(s.DisplayName), f) ||
-			strings.Contains(strings.ToLower(s.Label), f) ||
-			strings.Contains(strings.ToLower(s.Channel), f) {
+	q := parseFilter(m.filter)
+	for _, s := range sessions {
+		if q.matches(sessionFilterItem(s, m.noteFor(s))) {
 			out = append(out, s)
 		}
 	}
@@ -999,10 +2691,9 @@ func (m Model) filteredProcesses() []data.Process {
 		return m.processes
 	}
 	var out []data.Process
-	f := strings.ToLower(m.filter)
+	q := parseFilter(m.filter)
 	for _, p := range m.processes {
-		if strings.Contains(strings.ToLower(p.SessionName), f) ||
-			strings.Contains(strings.ToLower(p.Command), f) {
+		if q.matches(processFilterItem(p)) {
 			out = append(out, p)
 		}
 	}
@@ -1010,14 +2701,14 @@ func (m Model) filteredProcesses() []data.Process {
 }
 
 func (m Model) filteredArchived() []data.ArchivedRun {
+	runs := inHistoryRange(m.sortedArchived, m.historyRange, time.Now())
 	if m.filter == "" {
-		return m.archived
+		return runs
 	}
 	var out []data.ArchivedRun
-	f := strings.ToLower(m.filter)
-	for _, a := range m.archived {
-		if strings.Contains(strings.ToLower(a.Label), f) ||
-			strings.Contains(strings.ToLower(a.SessionID), f) {
+	q := parseFilter(m.filter)
+	for _, a := range runs {
+		if q.matches(archivedFilterItem(a)) {
 			out = append(out, a)
 		}
 	}
@@ -1036,6 +2727,11 @@ func (m Model) selectedItemID() string {
 		if m.historyCursor < len(aa) {
 			return aa[m.historyCursor].Path // use path as ID for transcripts
 		}
+	case tabActivity:
+		ee := m.filteredEvents()
+		if m.eventCursor < len(ee) {
+			return ee[m.eventCursor].Session.Key
+		}
 	default:
 		pp := m.filteredProcesses()
 		if m.processCursor < len(pp) {
@@ -1059,10 +2755,7 @@ func (m *Model) maxLogScroll(width int) int {
 			total++
 		}
 	}
-	viewH := m.logViewHeight() - 3
-	if m.currentQuery != "" {
-		viewH--
-	}
+	viewH := m.logViewHeight() - 3 - m.logHeaderExtra()
 	if viewH < 1 {
 		viewH = 1
 	}
@@ -1090,21 +2783,76 @@ func (m *Model) clampLogScroll(width int) {
 }
 
 func (m Model) logViewHeight() int {
-	// Approximate: total height minus borders and status bar
-	return max(1, m.height-4)
+	// Approximate: total height minus borders, status bar, fleet summary, and widget
+	return max(1, m.height-4-m.headerHeight()-m.widgetHeight())
 }
 
-// logWidth returns the consistent width calculation for the log panel.
+// logWidth returns the consistent width calculation for the log panel's
+// text, which leaves room for the scroll minimap outside accessible mode.
 // This must match the calculation used in View().
 func (m Model) logWidth() int {
-	listWidth := m.width*2/5 - 2
-	logWidth := m.width - listWidth - 6
+	if m.cfg.A11y {
+		return max(20, m.width)
+	}
+	logWidth := m.width - m.listWidth() - 6 - minimapWidth
 	if logWidth < 20 {
 		logWidth = 20
 	}
 	return logWidth
 }
 
+// logHeaderExtra returns the number of optional header lines shown above
+// the log content (query, workspace status, session note, image path,
+// pinned tool output).
+func (m Model) logHeaderExtra() int {
+	n := 0
+	if m.currentQuery != "" {
+		n++
+	}
+	if m.workspaceLine() != "" {
+		n++
+	}
+	if m.noteLine() != "" {
+		n++
+	}
+	if m.imageLine() != "" {
+		n++
+	}
+	return n + m.pinHeight()
+}
+
+// workspaceLine renders the git summary for the selected session's
+// workspace, or "" when there is none.
+func (m Model) workspaceLine() string {
+	if m.workspace == nil || m.selectedLogTab != tabSessions || m.workspaceFor != m.selectedLogID {
+		return ""
+	}
+	ws := m.workspace
+	line := dimStyle.Render("Workspace: ") + shortenHome(ws.Path)
+	if ws.Branch != "" {
+		line += dimStyle.Render(" ⎇ " + ws.Branch)
+	}
+	summary := ws.Summary()
+	if ws.Files > 0 {
+		summary = statusThinking.Render(summary)
+	} else {
+		summary = dimStyle.Render(summary)
+	}
+	hint := "  d:diff"
+	if m.diffView {
+		hint = "  d:logs"
+	}
+	return line + "  " + summary + dimStyle.Render(hint)
+}
+
+// shortenHome replaces the home directory prefix of path with "~".
+func shortenHome(path string) string {
+	if home, err := os.UserHomeDir(); err == nil && home != "" && strings.HasPrefix(path, home) {
+		return "~" + path[len(home):]
+	}
+	return path
+}
+
 func (m Model) filterMessagesBySource(msgs []data.HistoryMessage) []data.HistoryMessage {
 	if m.sourceFilter == "" {
 		return msgs
@@ -1122,16 +2870,24 @@ func (m Model) filterMessagesBySource(msgs []data.HistoryMessage) []data.History
 }
 
 func (m Model) View() string {
+	v := m.render()
+	if m.cfg.NoEmoji {
+		v = plainText(v)
+	}
+	return v
+}
+
+func (m Model) render() string {
 	if m.width == 0 || m.height == 0 {
 		return "Loading..."
 	}
-
-	listWidth := m.width*2/5 - 2
-	if listWidth < 20 {
-		listWidth = 20
+	if m.cfg.A11y {
+		return m.viewLinear()
 	}
+
+	listWidth := m.listWidth()
 	logWidth := m.logWidth()
-	contentHeight := m.height - 4 // borders + status bar
+	contentHeight := m.height - 4 - m.headerHeight() - m.widgetHeight() // borders + status bar + header + widget
 	if contentHeight < 5 {
 		contentHeight = 5
 	}
@@ -1139,27 +2895,47 @@ func (m Model) View() string {
 	leftPanel := m.renderListPanel(listWidth, contentHeight)
 	rightPanel := m.renderLogPanel(logWidth, contentHeight)
 	statusBar := m.renderStatusBar()
+	if m.veiled {
+		// Lists and the fleet summary only: no transcripts, replies, or forms
+		rightPanel = m.renderVeil(logWidth, contentHeight)
+		return lipgloss.JoinVertical(lipgloss.Left, m.renderHeader(),
+			lipgloss.JoinHorizontal(lipgloss.Top,
+				panelBorder.Width(listWidth).Height(contentHeight).Render(leftPanel),
+				panelBorder.Width(logWidth+minimapWidth).Height(contentHeight).Render(rightPanel)),
+			statusBar)
+	}
 
 	// Apply panel borders
 	var leftBorder, rightBorder lipgloss.Style
 	if m.activePanel == panelList {
-		leftBorder = activePanelBorder
+		leftBorder = m.focusedBorder()
 		rightBorder = panelBorder
 	} else {
 		leftBorder = panelBorder
-		rightBorder = activePanelBorder
+		rightBorder = m.focusedBorder()
 	}
 
 	left := leftBorder.Width(listWidth).Height(contentHeight).Render(leftPanel)
-	right := rightBorder.Width(logWidth).Height(contentHeight).Render(rightPanel)
+	right := rightBorder.Width(logWidth + minimapWidth).Height(contentHeight).Render(rightPanel)
+
+	main := lipgloss.JoinVertical(lipgloss.Left, m.renderHeader(), lipgloss.JoinHorizontal(lipgloss.Top, left, right))
+	if m.state.MainWidget {
+		main = lipgloss.JoinVertical(lipgloss.Left, main, m.renderMainWidget())
+	}
 
-	main := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+	if m.setup != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, main, m.renderGatewaySetup())
+	}
 
 	if m.spawning {
 		overlay := m.renderSpawnForm()
 		return lipgloss.JoinVertical(lipgloss.Left, main, overlay)
 	}
 
+	if m.confirmingSend {
+		return lipgloss.JoinVertical(lipgloss.Left, main, m.renderSendPreview())
+	}
+
 	return lipgloss.JoinVertical(lipgloss.Left, main, statusBar)
 }
 
@@ -1170,15 +2946,24 @@ func (m Model) renderListPanel(width, height int) string {
 	tab1 := inactiveTabStyle.Render("1:Sessions")
 	tab2 := inactiveTabStyle.Render("2:Processes")
 	tab3 := inactiveTabStyle.Render("3:History")
+	tab4 := inactiveTabStyle.Render("4:Activity")
+	active := func(name string) string {
+		if m.cfg.A11y {
+			name = "[" + name + "]"
+		}
+		return activeTabStyle.Render(name)
+	}
 	switch m.activeTab {
 	case tabSessions:
-		tab1 = activeTabStyle.Render("1:Sessions")
+		tab1 = active("1:Sessions")
 	case tabProcesses:
-		tab2 = activeTabStyle.Render("2:Processes")
+		tab2 = active("2:Processes")
 	case tabHistory:
-		tab3 = activeTabStyle.Render("3:History")
+		tab3 = active("3:History")
+	case tabActivity:
+		tab4 = active("4:Activity")
 	}
-	b.WriteString(tab1 + " " + tab2 + " " + tab3 + "\n")
+	b.WriteString(tab1 + " " + tab2 + " " + tab3 + " " + tab4 + "\n")
 
 	// Search bar
 	if m.searching {
@@ -1196,6 +2981,8 @@ func (m Model) renderListPanel(width, height int) string {
 		b.WriteString(m.renderProcessList(width, height-3))
 	case tabHistory:
 		b.WriteString(m.renderHistoryList(width, height-3))
+	case tabActivity:
+		b.WriteString(m.renderEventList(width, height-3))
 	}
 
 	return b.String()
@@ -1225,32 +3012,71 @@ func sessionDisplayName(s data.Session) string {
 	return key
 }
 
-func sessionStatus(s data.Session) string {
-	// Check explicit status/error fields first
-	if s.ErrorMessage != "" || s.Status == "failed" || s.Status == "error" {
-		return "failed"
+// sparkLevels are the glyphs used for sparkline buckets, lowest first.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders activity counts as a fixed-width bar sparkline.
+// Empty buckets are dimmed so stalled sessions read as a flat line.
+// formatTokens renders a token count compactly, e.g. "12k" or "1.4M".
+func formatTokens(n int) string {
+	switch {
+	case n >= 1000000:
+		return fmt.Sprintf("%.1fM", float64(n)/1000000)
+	case n >= 1000:
+		return fmt.Sprintf("%dk", n/1000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// formatCount renders n with thousands separators, e.g. "5,000".
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0 && s[i-1] != '-'; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
+
+func sparkline(counts []int) string {
+	if len(counts) == 0 {
+		return dimStyle.Render(strings.Repeat(" ", data.ActivityBuckets))
 	}
-	if s.Status == "completed" || s.Status == "done" {
-		return "completed"
+	peak := 0
+	for _, c := range counts {
+		if c > peak {
+			peak = c
+		}
 	}
-	if s.AbortedLastRun {
-		return "failed"
+	var b strings.Builder
+	for _, c := range counts {
+		if c == 0 {
+			b.WriteString(dimStyle.Render(string(sparkLevels[0])))
+			continue
+		}
+		level := (c*(len(sparkLevels)-1) + peak - 1) / peak
+		b.WriteString(statusRunning.Render(string(sparkLevels[level])))
 	}
+	return b.String()
+}
 
-	// Infer from activity
-	var age time.Duration
-	if s.AgeMs > 0 {
-		age = time.Duration(s.AgeMs) * time.Millisecond
-	} else if s.UpdatedAt > 0 {
-		age = time.Since(time.UnixMilli(s.UpdatedAt))
+// listWindow returns the bounds of the page of an n-row list that contains
+// cursor, with at most rows entries per page, so the cursor is always shown.
+func listWindow(cursor, n, rows int) (start, end int) {
+	if rows < 1 {
+		rows = 1
 	}
+	cursor = max(0, min(cursor, n-1))
+	start = cursor / rows * rows
+	return start, min(start+rows, n)
+}
 
-	if age < time.Minute {
-		return "running"
-	} else if age < 5*time.Minute {
-		return "running"
+// listPosition renders a "12/87" indicator when a list spans several pages.
+func listPosition(cursor, n, rows int) string {
+	if n <= max(rows, 1) {
+		return ""
 	}
-	return "idle"
+	return dimStyle.Render(fmt.Sprintf("  %d/%d", min(cursor+1, n), n))
 }
 
 func sessionStatusEmoji(status string) string {
@@ -1269,22 +3095,37 @@ func sessionStatusEmoji(status string) string {
 func (m Model) renderSessionList(width, maxItems int) string {
 	sessions := m.filteredSessions()
 	if len(sessions) == 0 {
-		return dimStyle.Render("  No sessions found")
+		msg := "No sessions found — is the gateway running and the openclaw CLI on PATH?"
+		switch {
+		case m.filter != "":
+			msg = "No sessions match the filter."
+		case m.state.Project != projectAll && m.state.Project != projectGrouped:
+			msg = "No sessions in " + data.ProjectName(m.state.Project) + " — press g to change the project view."
+		}
+		return m.renderEmptyState(msg)
 	}
 
 	var b strings.Builder
 	activeCount := 0
 	for _, s := range sessions {
-		st := sessionStatus(s)
+		st := s.EffectiveStatus()
 		if st == "running" {
 			activeCount++
 		}
 	}
-	b.WriteString(titleStyle.Render(fmt.Sprintf(" Sessions (%d active)", activeCount)) + "\n")
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" Sessions (%d active)", activeCount)) +
+		listPosition(m.sessionCursor, len(sessions), maxItems-1))
+	if view := projectViewTitle(m.state.Project); view != "" {
+		b.WriteString(dimStyle.Render("  g:" + view))
+	}
+	b.WriteString("\n")
 
 	// Calculate column widths based on available width
-	// Layout: "  🟡 label          5m  running  opus  12k"
-	nameWidth := width - 30 // reserve space for other columns
+	// Layout: "  🟡 label          5m  opus  12k ▁▃▇▅▁"
+	nameWidth := width - 30 - data.ActivityBuckets - 1 - len(strconv.Itoa(len(sessions))) // reserve space for other columns
+	if m.state.TokenSplit {
+		nameWidth -= tokenSplitWidth - 4
+	}
 	if nameWidth < 10 {
 		nameWidth = 10
 	}
@@ -1292,14 +3133,12 @@ func (m Model) renderSessionList(width, maxItems int) string {
 		nameWidth = 24
 	}
 
-	count := 0
-	for i, s := range sessions {
-		if count >= maxItems-1 {
-			break
-		}
+	start, end := listWindow(m.sessionCursor, len(sessions), maxItems-1)
+	for i := start; i < end; i++ {
+		s := sessions[i]
 
-		status := sessionStatus(s)
-		emoji := sessionStatusEmoji(status)
+		status := s.EffectiveStatus()
+		emoji := m.statusMark(status)
 
 		name := sessionDisplayName(s)
 		if len(name) > nameWidth {
@@ -1316,31 +3155,43 @@ func (m Model) renderSessionList(width, maxItems int) string {
 			runtimeStr = formatDuration(time.Since(time.UnixMilli(s.UpdatedAt)))
 		}
 
-		tokStr := ""
-		if s.TotalTokens > 0 {
-			if s.TotalTokens >= 1000000 {
-				tokStr = fmt.Sprintf("%.1fM", float64(s.TotalTokens)/1000000)
-			} else if s.TotalTokens >= 1000 {
-				tokStr = fmt.Sprintf("%dk", s.TotalTokens/1000)
-			} else {
-				tokStr = fmt.Sprintf("%d", s.TotalTokens)
+		prefix := m.cursorMark(i == m.sessionCursor)
+
+		modelCol := modelStyle(s.Model, m.cfg.ModelColors).Render(fmt.Sprintf("%-10s", modelAlias))
+		line := fmt.Sprintf("%s%s %s %-*s %4s  %s %s %s",
+			prefix, indexColumn(i, len(sessions)), emoji, nameWidth, name, dimStyle.Render(runtimeStr), modelCol, m.tokenColumn(s),
+			m.activityColumn(m.activity[s.Key]))
+		if due := m.deadlineColumn(s); due != "" {
+			line += " " + due
+		}
+		if loop := m.loopColumn(s); loop != "" {
+			line += " " + loop
+		}
+		if lock := m.lockColumn(s); lock != "" {
+			line += " " + lock
+		}
+		if ctx := contextColumn(s); ctx != "" {
+			line += " " + ctx
+		}
+		if m.noteFor(s) != "" {
+			mark := "📝"
+			switch {
+			case m.cfg.A11y:
+				mark = "NOTE"
+			case m.asciiGlyphs():
+				mark = "n"
 			}
+			line += " " + dimStyle.Render(mark)
 		}
-
-		prefix := "  "
-		if i == m.sessionCursor {
-			prefix = "▸ "
+		if m.state.Project == projectGrouped && s.Project != "" {
+			line += " " + dimStyle.Render(data.ProjectName(s.Project))
 		}
 
-		line := fmt.Sprintf("%s%s %-*s %4s  %-10s %4s",
-			prefix, emoji, nameWidth, name, dimStyle.Render(runtimeStr), modelAlias, dimStyle.Render(tokStr))
-
 		if i == m.sessionCursor {
 			line = selectedStyle.Render(line)
 		}
 
 		b.WriteString(line + "\n")
-		count++
 	}
 
 	return b.String()
@@ -1349,7 +3200,11 @@ func (m Model) renderSessionList(width, maxItems int) string {
 func (m Model) renderProcessList(width, maxItems int) string {
 	procs := m.filteredProcesses()
 	if len(procs) == 0 {
-		return dimStyle.Render("  No processes found")
+		msg := "No agent processes running."
+		if m.filter != "" {
+			msg = "No processes match the filter."
+		}
+		return m.renderEmptyState(msg)
 	}
 
 	var b strings.Builder
@@ -1359,15 +3214,14 @@ func (m Model) renderProcessList(width, maxItems int) string {
 			runCount++
 		}
 	}
-	b.WriteString(titleStyle.Render(fmt.Sprintf(" Processes (%d running)", runCount)) + "\n")
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" Processes (%d running)", runCount)) +
+		listPosition(m.processCursor, len(procs), maxItems-1) + "\n")
 
-	count := 0
-	for i, p := range procs {
-		if count >= maxItems-1 {
-			break
-		}
+	start, end := listWindow(m.processCursor, len(procs), maxItems-1)
+	for i := start; i < end; i++ {
+		p := procs[i]
 
-		indicator := processIndicator(p.Status)
+		indicator := m.processMark(p.Status)
 		name := p.SessionName
 		if len(name) > 14 {
 			name = name[:14]
@@ -1379,20 +3233,19 @@ func (m Model) renderProcessList(width, maxItems int) string {
 		}
 
 		runtime := dimStyle.Render(p.Runtime)
-
-		prefix := "  "
-		if i == m.processCursor {
-			prefix = "▸ "
+		if p.RSS > 0 {
+			runtime += dimStyle.Render(" " + formatBytes(p.RSS))
 		}
 
-		line := fmt.Sprintf("%s%s %-14s %-20s %s", prefix, indicator, name, cmd, runtime)
+		prefix := m.cursorMark(i == m.processCursor)
+
+		line := fmt.Sprintf("%s%s %s %-14s %-20s %s%s", prefix, indexColumn(i, len(procs)), indicator, name, cmd, runtime, m.restartColumn(p))
 
 		if i == m.processCursor {
 			line = selectedStyle.Render(line)
 		}
 
 		b.WriteString(line + "\n")
-		count++
 	}
 
 	return b.String()
@@ -1401,61 +3254,103 @@ func (m Model) renderProcessList(width, maxItems int) string {
 func (m Model) renderHistoryList(width, maxItems int) string {
 	runs := m.filteredArchived()
 	if len(runs) == 0 {
-		return dimStyle.Render("  No archived runs found")
+		msg := "No archived runs — completed sub-agent transcripts appear here."
+		if m.filter != "" {
+			msg = "No archived runs match the filter."
+		} else if len(m.archived) > 0 {
+			msg = "No archived runs in range " + m.historyRange + " — press r to widen it."
+		}
+		return m.renderEmptyState(msg)
 	}
 
 	var b strings.Builder
-	b.WriteString(titleStyle.Render(fmt.Sprintf(" History (%d runs)", len(runs))) + "\n")
+	order := m.state.HistorySort
+	if order == "" {
+		order = historySorts[0]
+	}
+	rng := m.historyRange
+	if rng == "" {
+		rng = historyRanges[0]
+	}
+	b.WriteString(titleStyle.Render(fmt.Sprintf(" History (%d runs)", len(runs))) +
+		listPosition(m.historyCursor, len(runs), maxItems-1) + dimStyle.Render("  o:sort "+order+"  r:range "+rng) + "\n")
 
-	count := 0
-	for i, r := range runs {
-		if count >= maxItems-1 {
-			break
-		}
+	now := time.Now()
+	timeWidth := 5
+	if m.state.AbsoluteDates {
+		timeWidth = 12
+	}
 
-		age := time.Since(time.UnixMilli(r.ModifiedAt))
-		ageStr := formatDuration(age)
+	start, end := listWindow(m.historyCursor, len(runs), maxItems-1)
+	for i := start; i < end; i++ {
+		r := runs[i]
+
+		ageStr := historyTime(r.ModifiedAt, m.state.AbsoluteDates, now)
 		sizeStr := fmt.Sprintf("%dK", r.Size/1024)
 
 		label := r.Label
 		if label == "" {
-			label = r.SessionID[:12]
+			label = r.SessionID
+			if len(label) > 12 {
+				label = label[:12]
+			}
 		}
 		if len(label) > 30 {
 			label = label[:27] + "..."
 		}
 
-		prefix := "  "
-		if i == m.historyCursor {
-			prefix = "▸ "
-		}
+		prefix := m.cursorMark(i == m.historyCursor)
 
-		line := fmt.Sprintf("%s📋 %-30s %5s %5s", prefix, label, dimStyle.Render(sizeStr), dimStyle.Render(ageStr))
+		line := fmt.Sprintf("%s%s %s%s %-30s %5s %s", prefix, indexColumn(i, len(runs)), m.deco("📋", ""), m.outcomeBadge(r.Outcome), label, dimStyle.Render(sizeStr), dimStyle.Render(fmt.Sprintf("%*s", timeWidth, ageStr)))
+		// Mark transcripts from other tools; OpenClaw's own are the default
+		if r.Format != "" && r.Format != "openclaw" {
+			line += dimStyle.Render(" [" + r.Format + "]")
+		}
+		switch {
+		case r.Sealed:
+			line += " " + statusFailed.Render(m.deco("🔐", "encrypted, no key"))
+		case r.Encrypted:
+			line += " " + dimStyle.Render(m.deco("🔐", "encrypted"))
+		}
+		if r.Note != "" {
+			line += " " + dimStyle.Render(m.deco("📝", "note"))
+		}
 
 		if i == m.historyCursor {
 			line = selectedStyle.Render(line)
 		}
 
 		b.WriteString(line + "\n")
-		count++
 	}
 
 	return b.String()
 }
 
 func (m Model) renderLogPanel(width, height int) string {
+	if m.catalog != nil {
+		return m.renderToolCatalog(width, height)
+	}
+	if m.preview != nil {
+		return m.renderFilePreview(width, height)
+	}
 	var b strings.Builder
 
-	// Title with current query
-	logTitle := "Logs"
-	if m.selectedLogID != "" {
-		logTitle = "Logs: " + m.selectedLogID
-	}
 	followTag := ""
 	if m.logFollow {
 		followTag = statusRunning.Render(" [follow]")
+		if m.logStream != nil && m.logStream.SessionKey == m.selectedLogID {
+			followTag = statusRunning.Render(" [live]") // streamed, not polled
+		}
 	}
-	b.WriteString(titleStyle.Render(logTitle) + followTag + "\n")
+	if m.rawLog && m.logView == "" && !m.diffView {
+		followTag += statusThinking.Render(" [raw]")
+	}
+	if m.state.FollowLatest {
+		followTag += statusRunning.Render(" [latest]")
+	}
+	followTag += m.streamTag()
+	title := m.logTitle() + followTag + m.spawnLinkHint(width, max(1, height-3-m.logHeaderExtra()))
+	b.WriteString(lipgloss.NewStyle().MaxWidth(max(width, 1)).Render(title) + "\n")
 
 	// Show current query if available
 	if m.currentQuery != "" {
@@ -1466,6 +3361,22 @@ func (m Model) renderLogPanel(width, height int) string {
 		b.WriteString(dimStyle.Render("Query: ") + queryStyle.Render(queryText) + "\n")
 	}
 
+	if ws := m.workspaceLine(); ws != "" {
+		b.WriteString(ws + "\n")
+	}
+
+	if note := m.noteLine(); note != "" {
+		b.WriteString(note + "\n")
+	}
+
+	if img := m.imageLine(); img != "" {
+		b.WriteString(img + "\n")
+	}
+
+	if m.pinned != nil {
+		b.WriteString(m.renderPinned(width))
+	}
+
 	b.WriteString(dimStyle.Render(strings.Repeat("\u2500", min(width, 40))) + "\n")
 
 	if m.logContent == "" {
@@ -1493,10 +3404,7 @@ func (m Model) renderLogPanel(width, height int) string {
 	}
 	lines := m.wrappedLines
 
-	viewH := height - 3
-	if m.currentQuery != "" {
-		viewH-- // Account for query line
-	}
+	viewH := height - 3 - m.logHeaderExtra()
 	if viewH < 1 {
 		viewH = 1
 	}
@@ -1510,13 +3418,53 @@ func (m Model) renderLogPanel(width, height int) string {
 		end = len(lines)
 	}
 
-	for _, line := range lines[start:end] {
-		b.WriteString(line + "\n")
+	linkRow := -1
+	if m.activePanel == panelLogs {
+		_, linkRow = m.activeSpawnLink(width, viewH)
+	}
+	var minimap []string
+	if !m.cfg.A11y {
+		minimap = renderMinimap(lines, start, viewH)
+	}
+	for i, line := range lines[start:end] {
+		rendered := m.styleLogLine(line)
+		if start+i == linkRow {
+			rendered = selectedStyle.Render(line)
+		}
+		if minimap != nil {
+			rendered = withMinimapCell(rendered, line, width, minimap[i])
+		}
+		b.WriteString(rendered + "\n")
 	}
 
 	return b.String()
 }
 
+// jsonKeyLine matches a member line of indented JSON: its indent, key, and
+// the rest.
+var jsonKeyLine = regexp.MustCompile(`^(\s{2,})("(?:[^"\\]|\\.)*")(: .*)$`)
+
+// styleLogLine colors message headers with the model's identity color,
+// e.g. "─── ASSISTANT (anthropic/claude-opus-4-6) ───", dims reasoning, and
+// colors the keys of pretty-printed JSON.
+func (m Model) styleLogLine(line string) string {
+	if strings.HasPrefix(line, data.ThinkingPrefix) {
+		return thinkingStyle.Render(line)
+	}
+	if sub := jsonKeyLine.FindStringSubmatch(line); sub != nil {
+		return sub[1] + jsonKeyStyle.Render(sub[2]) + sub[3]
+	}
+	rest, ok := strings.CutPrefix(line, "─── ASSISTANT (")
+	if !ok {
+		return line
+	}
+	model, _, ok := strings.Cut(rest, ")")
+	if !ok {
+		return line
+	}
+	return modelStyle(model, m.cfg.ModelColors).Render(line)
+}
+
 func (m Model) renderSpawnForm() string {
 	var b strings.Builder
 	width := m.width
@@ -1524,41 +3472,95 @@ func (m Model) renderSpawnForm() string {
 		width = 80
 	}
 
-	title := titleStyle.Render("🚀 Spawn New Agent")
+	title := titleStyle.Render(m.deco("🚀", "Spawn New Agent"))
 	if m.spawnSpinning {
-		title += statusThinking.Render(" ⏳ spawning...")
+		title += statusThinking.Render(" " + m.deco("⏳", "spawning..."))
 	}
 	b.WriteString(title + "\n")
 
 	// Prompt field
-	promptMarker, promptLabel := "  ", dimStyle
+	promptMarker, promptLabel := m.cursorMark(m.spawnField == spawnFieldPrompt), dimStyle
 	if m.spawnField == spawnFieldPrompt {
-		promptMarker, promptLabel = "▸ ", accentStyle
+		promptLabel = accentStyle
 	}
 	b.WriteString(promptMarker + promptLabel.Render("Prompt: ") + m.spawnPrompt.View() + "\n")
+	if len(m.completions) > 0 && m.spawnField == spawnFieldPrompt {
+		b.WriteString("          " + dimStyle.Render(strings.Join(m.completions, "  ")) + "\n")
+	} else if hint := m.recallHint(); hint != "" && m.spawnField == spawnFieldPrompt {
+		b.WriteString("          " + dimStyle.Render(hint) + "\n")
+	} else if prompt := m.spawnPrompt.Value(); prompt != "" {
+		// The spawn instruction is delivered to the parent session
+		var parent data.Session
+		if len(m.spawnParents) > 0 {
+			parent = m.spawnParents[m.spawnParentCursor]
+		}
+		b.WriteString("          " + tokenMeter(prompt, parent, len(m.spawnParents) > 0) + "\n")
+	}
+
+	// Parent session selector field
+	parentMarker, parentLabel := m.cursorMark(m.spawnField == spawnFieldParent), dimStyle
+	if m.spawnField == spawnFieldParent {
+		parentLabel = accentStyle
+	}
+	parent := "(no eligible parent)"
+	if len(m.spawnParents) > 0 {
+		p := m.spawnParents[m.spawnParentCursor]
+		parent = p.AgentID() + " › " + sessionDisplayName(p)
+	}
+	var parentDisplay string
+	if m.spawnField == spawnFieldParent {
+		parentDisplay = dimStyle.Render("↑↓ ") + accentStyle.Render(parent) + dimStyle.Render(" ↑↓")
+	} else {
+		parentDisplay = parent
+	}
+	b.WriteString(parentMarker + parentLabel.Render("Parent: ") + parentDisplay + "\n")
 
 	// Model selector field
-	modelMarker, modelLabel := "  ", dimStyle
+	modelMarker, modelLabel := m.cursorMark(m.spawnField == spawnFieldModel), dimStyle
 	if m.spawnField == spawnFieldModel {
-		modelMarker, modelLabel = "▸ ", accentStyle
+		modelLabel = accentStyle
 	}
 	selected := m.spawnModelOptions[m.spawnModelCursor]
+	if m.spawnModels[spawnModelID(selected)] {
+		selected = m.deco("✓", selected+" (compare)")
+	}
 	var modelDisplay string
 	if m.spawnField == spawnFieldModel {
 		modelDisplay = dimStyle.Render("↑↓ ") + accentStyle.Render(selected) + dimStyle.Render(" ↑↓")
 	} else {
 		modelDisplay = selected
 	}
+	if summary := m.matrixSummary(); summary != "" {
+		modelDisplay += "  " + dimStyle.Render(summary)
+	}
 	b.WriteString(modelMarker + modelLabel.Render("Model:  ") + modelDisplay + "\n")
 
 	// Label field
-	labelMarker, labelLabel := "  ", dimStyle
+	labelMarker, labelLabel := m.cursorMark(m.spawnField == spawnFieldLabel), dimStyle
 	if m.spawnField == spawnFieldLabel {
-		labelMarker, labelLabel = "▸ ", accentStyle
+		labelLabel = accentStyle
 	}
 	b.WriteString(labelMarker + labelLabel.Render("Label:  ") + m.spawnLabel.View() + "\n")
 
-	b.WriteString(dimStyle.Render("  tab:next field  ↑↓:select model  ↵:spawn  esc:cancel"))
+	// Deadline field
+	deadlineMarker, deadlineLabel := m.cursorMark(m.spawnField == spawnFieldDeadline), dimStyle
+	if m.spawnField == spawnFieldDeadline {
+		deadlineLabel = accentStyle
+	}
+	b.WriteString(deadlineMarker + deadlineLabel.Render("ETA:    ") + m.spawnDeadline.View() + "\n")
+
+	// Environment field
+	envMarker, envLabel := m.cursorMark(m.spawnField == spawnFieldEnv), dimStyle
+	if m.spawnField == spawnFieldEnv {
+		envLabel = accentStyle
+	}
+	b.WriteString(envMarker + envLabel.Render("Env:    ") + m.spawnEnv.View() + "\n")
+	if hint := m.envHint(); hint != "" {
+		b.WriteString("          " + hint + "\n")
+	}
+	b.WriteString(m.renderSpawnError())
+
+	b.WriteString(dimStyle.Render("  tab:next field  ↑↓:select parent/model  space:compare model  ↵:spawn  esc:cancel"))
 	if m.lastError != "" {
 		b.WriteString("  " + statusFailed.Render(m.lastError))
 	}
@@ -1567,29 +3569,62 @@ func (m Model) renderSpawnForm() string {
 	return statusBarStyle.Width(width).Render(b.String())
 }
 
+// renderSendPreview shows exactly what an external channel will receive
+// and asks for confirmation.
+func (m Model) renderSendPreview() string {
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(m.deco("📨", fmt.Sprintf("Deliver to %s via %s?", m.msgTargetName, m.msgTargetChannel))) + "\n")
+	preview := data.PreviewDelivery(m.msgTargetChannel, m.msgInput.Value())
+	lines := strings.Split(preview, "\n")
+	if len(lines) > 6 {
+		lines = append(lines[:6], "…")
+	}
+	for _, line := range lines {
+		b.WriteString("  " + queryStyle.Render(line) + "\n")
+	}
+	b.WriteString(dimStyle.Render("  This reaches real people on " + m.msgTargetChannel + ".  y/↵:send  n/esc:edit"))
+	return statusBarStyle.Width(width).Render(b.String())
+}
+
 func (m Model) renderStatusBar() string {
 	width := m.width
 	if width == 0 {
 		width = 80
 	}
 
-	// Left: gateway status
+	// Left: environment and gateway status
 	var leftParts []string
+	if badge := m.environmentBadge(); badge != "" {
+		leftParts = append(leftParts, badge)
+	}
 	if m.health != nil {
-		healthStatus := "connected"
-		if !m.health.OK {
-			healthStatus = "disconnected"
-		}
-		st := statusRunning.Render("\u25cf " + healthStatus)
-		leftParts = append(leftParts, st)
-		leftParts = append(leftParts, dimStyle.Render(fmt.Sprintf("%dms", m.health.DurationMs)))
+		leftParts = append(leftParts, m.healthIndicator())
 	} else {
-		leftParts = append(leftParts, dimStyle.Render("\u25cb gateway"))
+		leftParts = append(leftParts, dimStyle.Render(m.deco("\u25cb", "gateway")))
+	}
+
+	// Drafts and prompts stay hidden with the transcripts
+	if m.veiled {
+		leftParts = append(leftParts, dimStyle.Render("content hidden, press any key"))
+		return statusBarStyle.Width(width).Render(strings.Join(leftParts, " "))
 	}
 
 	if m.messaging {
 		prompt := statusThinking.Render(fmt.Sprintf("→ %s: ", m.msgTargetName))
 		leftParts = append(leftParts, prompt+m.msgInput.View())
+		if m.msgInput.Value() != "" {
+			target, ok := m.sessionByKey(m.msgTargetKey)
+			leftParts = append(leftParts, tokenMeter(m.msgInput.Value(), target, ok))
+		}
+		if len(m.completions) > 0 {
+			leftParts = append(leftParts, dimStyle.Render(strings.Join(m.completions, "  ")))
+		} else if hint := m.recallHint(); hint != "" {
+			leftParts = append(leftParts, dimStyle.Render(hint))
+		}
 		gap := width - lipgloss.Width(strings.Join(leftParts, " "))
 		if gap < 1 {
 			gap = 1
@@ -1597,8 +3632,62 @@ func (m Model) renderStatusBar() string {
 		return statusBarStyle.Width(width).Render(strings.Join(leftParts, " ") + strings.Repeat(" ", gap))
 	}
 
+	if m.pending != nil {
+		leftParts = append(leftParts, m.renderConfirmation())
+		return statusBarStyle.Width(width).Render(strings.Join(leftParts, " "))
+	}
+
+	if m.panicking {
+		sessions, procs := m.panicTargets()
+		prompt := m.deco("🛑", "EMERGENCY STOP: ") + fmt.Sprintf("abort %d sessions and kill %d processes? Type yes: ", len(sessions), len(procs))
+		leftParts = append(leftParts, statusFailed.Render(prompt)+m.panicInput.View())
+		return statusBarStyle.Width(width).Render(strings.Join(leftParts, " "))
+	}
+
+	if m.bulkPrompting {
+		leftParts = append(leftParts, statusThinking.Render("Bulk spawn tasks file: ")+m.bulkInput.View())
+		return statusBarStyle.Width(width).Render(strings.Join(leftParts, " "))
+	}
+
+	if m.jumping {
+		leftParts = append(leftParts, statusThinking.Render(":")+m.jumpInput.View())
+		return statusBarStyle.Width(width).Render(strings.Join(leftParts, " "))
+	}
+
+	if m.historySearching {
+		leftParts = append(leftParts, statusThinking.Render("Search history on the gateway: ")+m.historySearchInput.View())
+		return statusBarStyle.Width(width).Render(strings.Join(leftParts, " "))
+	}
+
+	if m.runPrompting {
+		prompt := fmt.Sprintf("Run in %s: ", shortenHome(m.runTarget.Workspace))
+		leftParts = append(leftParts, statusThinking.Render(prompt)+m.runInput.View())
+		if len(m.cfg.WorkspaceCommands) > 1 {
+			leftParts = append(leftParts, dimStyle.Render("tab:next command"))
+		}
+		return statusBarStyle.Width(width).Render(strings.Join(leftParts, " "))
+	}
+
+	if m.editingNote {
+		prompt := fmt.Sprintf("Note for %s: ", m.noteTargetName)
+		if m.runEdit != nil {
+			prompt = m.runEditPrompt()
+		}
+		leftParts = append(leftParts, statusThinking.Render(prompt)+m.noteInput.View())
+		return statusBarStyle.Width(width).Render(strings.Join(leftParts, " "))
+	}
+
 	if m.sending {
-		leftParts = append(leftParts, statusThinking.Render(fmt.Sprintf("⏳ sending to %s...", m.msgTargetName)))
+		leftParts = append(leftParts, statusThinking.Render(m.deco("⏳", fmt.Sprintf("sending to %s...", m.msgTargetName))))
+	}
+
+	if m.bulk != nil && !m.bulk.finished() {
+		done, failed := m.bulk.counts()
+		leftParts = append(leftParts, statusThinking.Render(m.deco("⏳", fmt.Sprintf("bulk spawn %d/%d", done+failed, len(m.bulk.list.Tasks)))))
+	}
+
+	if p := m.archiveProgress; m.archiveScan != nil && !p.Finished && p.Total > 0 {
+		leftParts = append(leftParts, statusThinking.Render(m.deco("⏳", fmt.Sprintf("indexing %s/%s transcripts", formatCount(p.Done), formatCount(p.Total)))))
 	}
 
 	if m.lastError != "" {
@@ -1606,11 +3695,29 @@ func (m Model) renderStatusBar() string {
 		if len(errText) > 80 {
 			errText = errText[:80] + "..."
 		}
-		leftParts = append(leftParts, statusFailed.Render(errText))
+		if m.lastErr != nil {
+			kind := data.KindOf(m.lastErr)
+			errText = "[" + kind.String() + "] " + errText
+			if m.lastRetry != nil {
+				errText += " (R:retry)"
+			}
+			errText += " (E:details)"
+			leftParts = append(leftParts, errorKindStyle(kind).Render(errText))
+		} else {
+			leftParts = append(leftParts, statusFailed.Render(errText))
+		}
 	}
 
 	if m.confirming {
-		leftParts = append(leftParts, statusThinking.Render(fmt.Sprintf("Kill %s? [y/n]", m.confirmTarget)))
+		leftParts = append(leftParts, m.renderKillMenu())
+	}
+
+	if m.busySend != nil {
+		leftParts = append(leftParts, m.renderBusySend())
+	}
+
+	if m.confirmingPurge {
+		leftParts = append(leftParts, statusThinking.Render(fmt.Sprintf("Purge %d archived runs? [y/n]", len(m.purgePlan))))
 	}
 
 	left := strings.Join(leftParts, " ")
@@ -1623,7 +3730,7 @@ func (m Model) renderStatusBar() string {
 	} else {
 		sourceTag = dimStyle.Render(" c:all")
 	}
-	right := dimStyle.Render("↑↓:nav  ←→:panel  1/2/3:tab  ↵:view  esc:back  m:msg  s:spawn  /:search  f:follow  ") + verboseTag + sourceTag + dimStyle.Render("  q:quit")
+	right := dimStyle.Render("↑↓:nav  ←→:panel  1-4:tab  ↵:view  esc:back  "+m.actionHints()+"/:search  f:follow  ") + verboseTag + sourceTag + dimStyle.Render("  q:quit")
 
 	gap := width - lipgloss.Width(left) - lipgloss.Width(right)
 	if gap < 1 {
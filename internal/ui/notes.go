@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// noteFor returns the operator note attached to a session, if any.
+func (m Model) noteFor(s data.Session) string {
+	if s.SessionID == "" {
+		return ""
+	}
+	return m.state.Notes[s.SessionID]
+}
+
+// openNoteEditor starts editing the note of the selected session.
+func (m *Model) openNoteEditor() tea.Cmd {
+	ss := m.filteredSessions()
+	if m.sessionCursor >= len(ss) {
+		return nil
+	}
+	s := ss[m.sessionCursor]
+	if s.SessionID == "" {
+		m.setStatus("note: " + sessionDisplayName(s) + " has no session ID")
+		return nil
+	}
+	m.noteTarget = s.SessionID
+	m.noteTargetName = sessionDisplayName(s)
+	m.noteInput.SetValue(m.noteFor(s))
+	m.noteInput.CursorEnd()
+	m.noteInput.Focus()
+	m.editingNote = true
+	return textinput.Blink
+}
+
+// saveNote stores text as the note of the session being edited; empty text
+// removes the note. Notes are persisted so they survive a restart.
+func (m *Model) saveNote(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		delete(m.state.Notes, m.noteTarget)
+	} else {
+		if m.state.Notes == nil {
+			m.state.Notes = make(map[string]string)
+		}
+		m.state.Notes[m.noteTarget] = text
+	}
+	if err := m.state.Save(); err != nil {
+		m.setStatus("note: " + err.Error())
+	}
+}
+
+// noteLine renders the note of the session or archived run shown in the
+// log panel, or "" when it has none.
+func (m Model) noteLine() string {
+	if m.selectedLogID == "" || m.logView != "" {
+		return ""
+	}
+	var note string
+	switch m.selectedLogTab {
+	case tabSessions:
+		if s, ok := m.sessionByKey(m.selectedLogID); ok {
+			note = m.noteFor(s)
+		}
+	case tabHistory:
+		if run, ok := m.runByPath(m.selectedLogID); ok {
+			note = run.Note
+		}
+	}
+	if note == "" {
+		return ""
+	}
+	return dimStyle.Render(m.deco("📝", "Note: ")) + queryStyle.Render(note)
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// outputsMsg carries the outputs collected from an archived run.
+type outputsMsg struct {
+	title   string
+	outputs data.RunOutputs
+	err     error
+}
+
+// showOutputs collects the files, links, and final answer of the selected
+// History run and shows them in the log panel.
+func (m *Model) showOutputs() tea.Cmd {
+	runs := m.filteredArchived()
+	if m.activeTab != tabHistory || m.historyCursor >= len(runs) {
+		m.setStatus("outputs: select a run in the History tab")
+		return nil
+	}
+	run := runs[m.historyCursor]
+	name := run.Label
+	if name == "" {
+		name = run.SessionID
+	}
+	client := m.client
+	return func() tea.Msg {
+		msgs, err := client.ReadTranscriptMessages(run.Path)
+		if err != nil {
+			return outputsMsg{err: fmt.Errorf("outputs(%s): %w", name, err)}
+		}
+		return outputsMsg{title: "Outputs: " + name, outputs: data.CollectOutputs(msgs)}
+	}
+}
+
+// handleOutputs shows collected outputs, making their image paths
+// available to the open and copy-path actions.
+func (m *Model) handleOutputs(msg outputsMsg) {
+	if msg.err != nil {
+		m.setStatus(msg.err.Error())
+		return
+	}
+	m.showLogView(msg.title, cleanLogContent(data.FormatOutputs(msg.outputs)))
+	for _, f := range msg.outputs.Files {
+		if m.imagePattern.MatchString(f) {
+			m.imagePaths = append(m.imagePaths, f)
+		}
+	}
+	m.setStatus(fmt.Sprintf("%d files, %d links", len(msg.outputs.Files), len(msg.outputs.Links)))
+}
@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// scopesMsg carries the gateway token's scopes.
+type scopesMsg struct {
+	scopes data.Scopes
+	err    error
+}
+
+// deniedMsg reports an action the gateway refused the token.
+type deniedMsg struct {
+	action string
+	err    error
+}
+
+// fetchScopes asks the gateway what the token may do.
+func (m Model) fetchScopes() tea.Msg {
+	scopes, err := m.client.FetchScopes()
+	return scopesMsg{scopes, err}
+}
+
+// handleScopes records the token's scopes. Without them every action is
+// offered until the gateway refuses it.
+func (m *Model) handleScopes(msg scopesMsg) {
+	if msg.err != nil {
+		m.scopesErr = msg.err
+		return
+	}
+	m.scopes, m.scopesKnown, m.scopesErr = msg.scopes, true, nil
+}
+
+// allowed reports whether the token may perform action as far as the
+// Commander knows: its scopes allow it and the gateway hasn't refused it.
+func (m Model) allowed(action string) bool {
+	if _, denied := m.denied[action]; denied {
+		return false
+	}
+	return !m.scopesKnown || m.scopes.Allows(action)
+}
+
+// can reports whether the token may perform action, saying why not in the
+// status bar when it may not.
+func (m *Model) can(action string) bool {
+	if m.allowed(action) {
+		return true
+	}
+	m.setStatus(m.deco("⊘", fmt.Sprintf("the gateway token can't %s (%s); press H for its scopes", action, m.deniedReason(action))))
+	return false
+}
+
+// deniedReason says why action isn't allowed.
+func (m Model) deniedReason(action string) string {
+	if reason, ok := m.denied[action]; ok {
+		return reason
+	}
+	return "needs " + data.NeededScope(action)
+}
+
+// noteDenied stops offering action when err is the gateway refusing it.
+func (m *Model) noteDenied(action string, err error) {
+	if data.IsForbidden(err) {
+		m.denied[action] = "refused by the gateway"
+	}
+}
+
+// actionHints are the status bar hints for the actions the token may
+// perform.
+func (m Model) actionHints() string {
+	var b strings.Builder
+	if m.allowed(data.PermMessage) {
+		b.WriteString("m:msg  ")
+	}
+	if m.allowed(data.PermSpawn) {
+		b.WriteString("s:spawn  ")
+	}
+	return b.String()
+}
+
+// scopeReport lists the token's scopes and the actions they rule out, for
+// the health report.
+func (m Model) scopeReport() string {
+	var b strings.Builder
+	switch {
+	case m.scopesKnown:
+		scopes := strings.Join(m.scopes, ", ")
+		if scopes == "" {
+			scopes = "none"
+		}
+		fmt.Fprintf(&b, "\nToken scopes: %s\n", scopes)
+	case errors.Is(m.scopesErr, data.ErrScopesUnsupported):
+		b.WriteString("\nToken scopes: not reported by this gateway; actions are disabled once refused\n")
+	case m.scopesErr != nil:
+		fmt.Fprintf(&b, "\nToken scopes: %v\n", m.scopesErr)
+	default:
+		b.WriteString("\nToken scopes: checking…\n")
+	}
+	for _, action := range []string{data.PermSpawn, data.PermMessage, data.PermKill, data.PermAbort} {
+		if !m.allowed(action) {
+			fmt.Fprintf(&b, "  can't %-8s %s\n", action, m.deniedReason(action))
+		}
+	}
+	return b.String()
+}
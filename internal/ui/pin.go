@@ -0,0 +1,104 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// pinMaxLines is how many lines of a pinned tool output stay visible.
+const pinMaxLines = 5
+
+// pinnedOutput is a tool result kept at the top of the log panel.
+type pinnedOutput struct {
+	source string   // session or transcript it came from
+	header string   // the tool call as shown in the log
+	lines  []string // the tool output, up to pinMaxLines
+}
+
+// isToolResultLine reports whether a formatted log line starts a tool
+// result: a summary line (" ✓ 🛠️ ran ...") or a full-output header.
+func isToolResultLine(line string) bool {
+	return strings.HasPrefix(line, " ✓ ") || strings.HasPrefix(line, " ✗ ") ||
+		strings.HasPrefix(line, "─── TOOLRESULT") || strings.HasPrefix(line, "─── TOOL ")
+}
+
+// pinToolOutput pins the first tool result visible in the log panel.
+func (m *Model) pinToolOutput() {
+	if m.logView != "" || m.diffView || m.selectedLogTab == tabProcesses {
+		return
+	}
+	index, header := -1, ""
+	count := 0
+	m.scanLogLines(m.logWidth(), max(1, m.logViewHeight()-3-m.logHeaderExtra()), func(line string, _ int, visible bool) bool {
+		if !isToolResultLine(line) {
+			return true
+		}
+		if visible {
+			index, header = count, strings.TrimSpace(strings.Trim(line, "─ "))
+			return false
+		}
+		count++
+		return true
+	})
+	if index < 0 {
+		m.setStatus("pin: no tool output on screen (press v if tools are hidden)")
+		return
+	}
+
+	var results []data.HistoryMessage
+	for _, msg := range m.filterMessagesBySource(m.cachedMessages) {
+		if msg.Role == "toolResult" || msg.Role == "tool" {
+			results = append(results, msg)
+		}
+	}
+	if index >= len(results) {
+		m.setStatus("pin: tool output not found")
+		return
+	}
+	text := strings.TrimRight(cleanLogContent(results[index].Text), "\n")
+	lines := strings.Split(text, "\n")
+	if text == "" {
+		lines = []string{"(no output)"}
+	}
+	if len(lines) > pinMaxLines {
+		// The end of an output is where failures are usually reported
+		lines = append([]string{"…"}, lines[len(lines)-pinMaxLines+1:]...)
+	}
+
+	source := m.selectedLogID
+	if s, ok := m.sessionByKey(source); ok {
+		source = sessionDisplayName(s)
+	} else if m.selectedLogTab == tabHistory {
+		source = filepath.Base(source)
+	}
+	m.pinned = &pinnedOutput{source: source, header: header, lines: lines}
+	m.clampLogScroll(m.logWidth())
+}
+
+// pinHeight is the number of log panel rows the pinned output takes.
+func (m Model) pinHeight() int {
+	if m.pinned == nil {
+		return 0
+	}
+	return 1 + len(m.pinned.lines)
+}
+
+// renderPinned renders the pinned tool output, each line cut to width.
+func (m Model) renderPinned(width int) string {
+	p := m.pinned
+	cut := func(s string, n int) string {
+		if n > 3 && len(s) > n {
+			return s[:n-3] + "..."
+		}
+		return s
+	}
+	var b strings.Builder
+	head := m.deco("📌", p.source+": ")
+	b.WriteString(accentStyle.Render(cut(head+p.header, width-10)) + dimStyle.Render("  u:unpin") + "\n")
+	for _, line := range p.lines {
+		b.WriteString(queryStyle.Render(cut("  "+line, width)) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Plain output (config.NoEmoji) rewrites the rendered screen to ASCII so it
+// survives dumb terminals and screen recordings. Known markers get
+// meaningful stand-ins; anything else outside ASCII becomes '?' padding of
+// the same width, so columns stay aligned.
+
+var plainGlyphs = map[rune]string{
+	// Session status
+	'🟡': "* ",
+	'✅': "+ ",
+	'❌': "x ",
+	'⚪': "- ",
+	// Process status, health, cursor
+	'▶': ">",
+	'■': "#",
+	'●': "*",
+	'○': "o",
+	'▸': ">",
+	// Tool results and progress
+	'✓': "+",
+	'✗': "x",
+	'…': ".",
+	'•': "*",
+	'▪': "=",
+	'⊘': "/",
+	'✉': "@",
+	'↻': "~",
+	'💭': "~ ",
+	// Key hints
+	'←': "<",
+	'→': ">",
+	'↑': "^",
+	'↓': "v",
+	'↵': "<",
+	'×': "x",
+	'—': "-",
+	'–': "-",
+	'·': ".",
+}
+
+// plainText replaces non-ASCII characters in s, leaving escape sequences
+// (which are ASCII) intact.
+func plainText(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		switch {
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		case r == 0xFE0F || r == 0x200D: // emoji variation selector, joiner
+		default:
+			if g, ok := plainGlyphs[r]; ok {
+				b.WriteString(g)
+			} else if c, ok := asciiBox(r); ok {
+				b.WriteByte(c)
+			} else {
+				b.WriteString(strings.Repeat("?", max(1, lipgloss.Width(string(r)))))
+			}
+		}
+	}
+	return b.String()
+}
+
+// applyNoColor drops all colors from rendered styles. Selection falls back
+// to reverse video so the cursor row stays visible.
+func applyNoColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// Session list polling: fast while a session is working, slow when
+// everything is idle.
+const (
+	sessionsPollActive = 2 * time.Second
+	sessionsPollRecent = 5 * time.Second
+	sessionsPollIdle   = 15 * time.Second
+
+	// idleActivityEvery is how many session refreshes pass between
+	// recomputing the sparklines of idle sessions.
+	idleActivityEvery = 6
+)
+
+// Log polling backs off once a log has stopped changing.
+const (
+	logPollBase = 2 * time.Second
+	logPollMax  = 16 * time.Second
+
+	// logIdleCycles is how many unchanged fetches it takes before the log
+	// poll interval doubles.
+	logIdleCycles = 3
+)
+
+// sessionsInterval picks the next session list refresh interval from how
+// recently any session was active.
+func (m Model) sessionsInterval() time.Duration {
+	interval := sessionsPollIdle
+	for _, s := range m.sessions {
+		if s.EffectiveStatus() != "running" {
+			continue
+		}
+		if sessionRecentlyUpdated(s, time.Minute) {
+			return sessionsPollActive
+		}
+		interval = sessionsPollRecent
+	}
+	return interval
+}
+
+// sessionRecentlyUpdated reports whether s was updated within d.
+func sessionRecentlyUpdated(s data.Session, d time.Duration) bool {
+	if s.AgeMs > 0 {
+		return time.Duration(s.AgeMs)*time.Millisecond < d
+	}
+	return s.UpdatedAt > 0 && time.Since(time.UnixMilli(s.UpdatedAt)) < d
+}
+
+// activityTargets returns the sessions whose sparklines should be
+// recomputed on this refresh: running ones every time, all of them every
+// idleActivityEvery refreshes. full reports the latter.
+func (m Model) activityTargets() (sessions []data.Session, full bool) {
+	if m.sessionsRefreshes%idleActivityEvery == 0 {
+		return m.sessions, true
+	}
+	for _, s := range m.sessions {
+		if s.EffectiveStatus() == "running" {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, false
+}
+
+// logInterval is the minimum time between fetches of the followed log,
+// doubling for every logIdleCycles fetches that brought nothing new.
+func (m Model) logInterval() time.Duration {
+	d := logPollBase
+	for i := logIdleCycles; i <= m.logIdle && d < logPollMax; i += logIdleCycles {
+		d *= 2
+	}
+	return d
+}
@@ -0,0 +1,52 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// privacyCheckInterval is how often the time since the last key press is
+// checked against the privacy timeout.
+const privacyCheckInterval = 5 * time.Second
+
+type tickPrivacyMsg struct{}
+
+// tickPrivacy schedules the next idle check, or nothing when the privacy
+// veil is disabled.
+func (m Model) tickPrivacy() tea.Cmd {
+	if m.cfg.PrivacyTimeout <= 0 {
+		return nil
+	}
+	return tea.Tick(privacyCheckInterval, func(time.Time) tea.Msg {
+		return tickPrivacyMsg{}
+	})
+}
+
+// checkIdle hides transcript content once there has been no input for the
+// privacy timeout, as the Commander often runs on a shared screen.
+func (m *Model) checkIdle() {
+	if m.cfg.PrivacyTimeout > 0 && time.Since(m.lastInput) >= m.cfg.PrivacyTimeout {
+		m.veiled = true
+	}
+}
+
+// unveil records a key press and lifts the veil, reporting whether it was
+// up so the key isn't also acted on.
+func (m *Model) unveil() bool {
+	m.lastInput = time.Now()
+	if !m.veiled {
+		return false
+	}
+	m.veiled = false
+	return true
+}
+
+// renderVeil stands in for the log panel while content is hidden.
+func (m Model) renderVeil(width, height int) string {
+	text := m.deco("🔒", fmt.Sprintf("Hidden after %s without input", formatDuration(m.cfg.PrivacyTimeout))) +
+		"\n" + dimStyle.Render("press any key")
+	return lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, text)
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// samplingStopped is how old the last resource sample of a process is once
+// it is no longer sampled, a few process refreshes.
+const samplingStopped = 10 * time.Second
+
+// chartBlocks are the partial-cell glyphs used for vertical bar charts.
+var chartBlocks = []rune(" ▁▂▃▄▅▆▇█")
+
+// processDetailTitle is the log view title for a process's detail view.
+func processDetailTitle(name string) string {
+	return "Process: " + name
+}
+
+// showProcessDetail renders the detail view for the selected process.
+func (m *Model) showProcessDetail() {
+	procs := m.filteredProcesses()
+	if m.processCursor >= len(procs) {
+		return
+	}
+	p := procs[m.processCursor]
+	m.detailProcess = p.SessionName
+	m.activePanel = panelLogs
+	m.showLogView(processDetailTitle(p.SessionName), m.renderProcessDetail(p))
+}
+
+// refreshProcessDetail re-renders an open detail view after a process refresh.
+func (m *Model) refreshProcessDetail() {
+	if m.detailProcess == "" || m.logView != processDetailTitle(m.detailProcess) {
+		return
+	}
+	for _, p := range m.processes {
+		if p.SessionName == m.detailProcess {
+			m.refreshLogView(m.renderProcessDetail(p))
+			return
+		}
+	}
+	// Said once: the view stops refreshing
+	m.detailProcess = ""
+	m.refreshLogView(m.logContent + "\nProcess has exited.\n")
+}
+
+func (m Model) renderProcessDetail(p data.Process) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Status:   %s\n", p.Status))
+	b.WriteString(fmt.Sprintf("Runtime:  %s\n", p.Runtime))
+	if p.PID > 0 {
+		b.WriteString(fmt.Sprintf("PID:      %d\n", p.PID))
+	}
+	b.WriteString(fmt.Sprintf("Command:  %s\n\n", p.Command))
+
+	samples := m.client.ResourceHistory(p.SessionName)
+	if len(samples) == 0 {
+		b.WriteString("No resource samples (the process list does not report a PID for this process).\n")
+		return b.String()
+	}
+
+	width := max(10, m.logWidth()-10)
+	if len(samples) > width {
+		samples = samples[len(samples)-width:]
+	}
+	mem := make([]float64, len(samples))
+	cpu := make([]float64, len(samples))
+	var peakMem int64
+	var peakCPU float64
+	for i, s := range samples {
+		mem[i], cpu[i] = float64(s.RSS), s.CPU
+		if s.RSS > peakMem {
+			peakMem = s.RSS
+		}
+		if s.CPU > peakCPU {
+			peakCPU = s.CPU
+		}
+	}
+	last := samples[len(samples)-1]
+	span := formatDuration(last.At.Sub(samples[0].At))
+
+	now := "now"
+	if age := time.Since(last.At); age > samplingStopped {
+		// The history stays as it was when the process died
+		now = "final"
+		b.WriteString(fmt.Sprintf("Sampling stopped %s ago.\n\n", formatDuration(age)))
+	}
+	b.WriteString(fmt.Sprintf("Memory   %s %s, peak %s (last %s)\n", now, formatBytes(last.RSS), formatBytes(peakMem), span))
+	for _, row := range barChart(mem, 6) {
+		b.WriteString("  " + row + "\n")
+	}
+	b.WriteString(fmt.Sprintf("\nCPU      %s %.0f%%, peak %.0f%% (last %s)\n", now, last.CPU, peakCPU, span))
+	for _, row := range barChart(cpu, 4) {
+		b.WriteString("  " + row + "\n")
+	}
+	return b.String()
+}
+
+// barChart renders values as a vertical bar chart of the given height,
+// scaled to the largest value, top row first.
+func barChart(values []float64, height int) []string {
+	peak := 0.0
+	for _, v := range values {
+		if v > peak {
+			peak = v
+		}
+	}
+	steps := len(chartBlocks) - 1
+	rows := make([]string, height)
+	for r := 0; r < height; r++ {
+		var sb strings.Builder
+		floor := (height - 1 - r) * steps
+		for _, v := range values {
+			level := 0
+			if peak > 0 {
+				level = int(v/peak*float64(height*steps) + 0.5)
+			}
+			fill := min(max(level-floor, 0), steps)
+			sb.WriteRune(chartBlocks[fill])
+		}
+		rows[r] = sb.String()
+	}
+	return rows
+}
+
+// formatBytes renders a byte count with a binary unit suffix.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%c", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// captureProcessLog adds a fetched window of process id's log to what has
+// been captured of it, which outlives the gateway's own truncation.
+func (m *Model) captureProcessLog(id, window string) {
+	if id == "" {
+		return
+	}
+	ring := m.procLogs[id]
+	if ring == nil {
+		ring = data.NewLogRing(m.cfg.ProcessLogBuffer)
+		m.procLogs[id] = ring
+	}
+	ring.Merge(window)
+}
+
+// saveProcessLog writes everything captured of the open process's log to a
+// file under config.ExportDir.
+func (m *Model) saveProcessLog() {
+	ring := m.procLogs[m.selectedLogID]
+	dir := config.ExportDir()
+	if dir == "" {
+		m.setStatus("save log: cannot resolve home directory")
+		return
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		m.setStatus("save log: " + err.Error())
+		return
+	}
+	now := time.Now()
+
+	var b strings.Builder
+	b.WriteString("# Process log: " + m.selectedLogID + "\n")
+	b.WriteString("# saved " + now.Format(time.RFC3339) + "\n")
+	fmt.Fprintf(&b, "# %d lines captured", ring.Len())
+	if ring.Dropped() > 0 {
+		fmt.Fprintf(&b, "; %d older lines dropped past the %d-line buffer", ring.Dropped(), m.cfg.ProcessLogBuffer)
+	}
+	b.WriteString("\n\n")
+	b.WriteString(strings.Join(ring.Lines(), "\n") + "\n")
+
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(m.selectedLogID, "-"), "-")
+	if name == "" {
+		name = "process"
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-full-%s.log", name, now.Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		m.setStatus("save log: " + err.Error())
+		return
+	}
+	m.setStatus(fmt.Sprintf("saved %d captured lines to %s", ring.Len(), shortenHome(path)))
+}
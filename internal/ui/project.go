@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"sort"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// Project views cycled with `g`. Any other value focuses the session list
+// on that project path.
+const (
+	projectAll     = ""  // every session, in the gateway's order
+	projectGrouped = "*" // every session, grouped by project
+)
+
+// projectViews lists the views for the current sessions: all, grouped, then
+// each detected project. A focused project that no longer has sessions
+// stays in the cycle so the choice is not lost on a refresh.
+func projectViews(sessions []data.Session, cur string) []string {
+	seen := map[string]bool{}
+	var projects []string
+	for _, s := range sessions {
+		if s.Project != "" && !seen[s.Project] {
+			seen[s.Project] = true
+			projects = append(projects, s.Project)
+		}
+	}
+	if cur != projectAll && cur != projectGrouped && !seen[cur] {
+		projects = append(projects, cur)
+	}
+	sort.Strings(projects)
+	return append([]string{projectAll, projectGrouped}, projects...)
+}
+
+// nextProjectView returns the view after cur.
+func nextProjectView(sessions []data.Session, cur string) string {
+	views := projectViews(sessions, cur)
+	for i, v := range views {
+		if v == cur {
+			return views[(i+1)%len(views)]
+		}
+	}
+	return projectAll
+}
+
+// applyProjectView narrows or orders sessions for the given view.
+func applyProjectView(sessions []data.Session, view string) []data.Session {
+	switch view {
+	case projectAll:
+		return sessions
+	case projectGrouped:
+		out := append([]data.Session(nil), sessions...)
+		// Sessions without a project sort last
+		sort.SliceStable(out, func(i, j int) bool {
+			a, b := out[i].Project, out[j].Project
+			if (a == "") != (b == "") {
+				return b == ""
+			}
+			return a < b
+		})
+		return out
+	}
+	var out []data.Session
+	for _, s := range sessions {
+		if s.Project == view {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// projectViewTitle describes the view for the Sessions title.
+func projectViewTitle(view string) string {
+	switch view {
+	case projectAll:
+		return ""
+	case projectGrouped:
+		return "by project"
+	}
+	return "project " + data.ProjectName(view)
+}
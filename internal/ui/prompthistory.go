@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+)
+
+// promptRecall tracks browsing through prompt history inside an input.
+type promptRecall struct {
+	index  int    // position in history while browsing, -1 when not browsing
+	draft  string // text typed before browsing started
+	query  string // ctrl+r search query, "" when not searching
+	active bool   // browsing or searching
+}
+
+// resetRecall ends any history browsing; call when an input opens or is edited.
+func (m *Model) resetRecall() {
+	m.recall = promptRecall{index: -1}
+}
+
+// rememberPrompt appends text to the prompt history, moving an identical
+// earlier entry to the end, and saves it unless history is disabled.
+func (m *Model) rememberPrompt(text string) {
+	text = strings.TrimSpace(text)
+	if !m.cfg.PromptHistory || text == "" {
+		return
+	}
+	for i, p := range m.prompts {
+		if p == text {
+			m.prompts = append(m.prompts[:i], m.prompts[i+1:]...)
+			break
+		}
+	}
+	m.prompts = append(m.prompts, text)
+	if len(m.prompts) > config.MaxPromptHistory {
+		m.prompts = m.prompts[len(m.prompts)-config.MaxPromptHistory:]
+	}
+	config.SavePromptHistory(m.prompts)
+}
+
+// recallPrompt steps through history: older for delta -1 (up), newer for
+// +1 (down). Stepping past the newest entry restores the draft.
+func (m *Model) recallPrompt(ti *textinput.Model, delta int) {
+	if len(m.prompts) == 0 {
+		return
+	}
+	if !m.recall.active {
+		m.recall = promptRecall{index: len(m.prompts), draft: ti.Value(), active: true}
+	}
+	m.recall.query = ""
+	i := m.recall.index + delta
+	switch {
+	case i < 0:
+		return
+	case i >= len(m.prompts):
+		m.recall.index = len(m.prompts)
+		ti.SetValue(m.recall.draft)
+	default:
+		m.recall.index = i
+		ti.SetValue(m.prompts[i])
+	}
+	ti.CursorEnd()
+}
+
+// searchPrompt implements ctrl+r: the first press searches history for the
+// current input text, and each further press moves to the next older match.
+// Matching is fuzzy: the query's characters must appear in order.
+func (m *Model) searchPrompt(ti *textinput.Model) {
+	if m.recall.query == "" {
+		query := strings.TrimSpace(ti.Value())
+		if query == "" {
+			return
+		}
+		m.recall = promptRecall{index: len(m.prompts), draft: ti.Value(), query: query, active: true}
+	}
+	for i := m.recall.index - 1; i >= 0; i-- {
+		if fuzzyMatch(m.prompts[i], m.recall.query) {
+			m.recall.index = i
+			ti.SetValue(m.prompts[i])
+			ti.CursorEnd()
+			return
+		}
+	}
+}
+
+// recallDelta maps the up/down arrow to a history step.
+func recallDelta(msg tea.KeyMsg) int {
+	if msg.Type == tea.KeyUp {
+		return -1
+	}
+	return 1
+}
+
+// recallHint describes the current history position for the input's footer.
+func (m Model) recallHint() string {
+	switch {
+	case !m.recall.active:
+		return ""
+	case m.recall.query != "":
+		return "search: " + m.recall.query + "  (ctrl+r: older match)"
+	case m.recall.index < len(m.prompts):
+		return fmt.Sprintf("history %d/%d", len(m.prompts)-m.recall.index, len(m.prompts))
+	}
+	return ""
+}
+
+// fuzzyMatch reports whether the characters of query appear in s in order,
+// ignoring case.
+func fuzzyMatch(s, query string) bool {
+	s, query = strings.ToLower(s), strings.ToLower(query)
+	for _, r := range query {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// toggleRawLog flips the log panel between the formatted view and the raw
+// transcript, and refetches the open log in the new view.
+func (m *Model) toggleRawLog() tea.Cmd {
+	m.rawLog = !m.rawLog
+	if m.rawLog {
+		m.setStatus("raw transcript: nothing is cleaned or compressed")
+	} else {
+		m.setStatus("formatted transcript")
+	}
+	if m.selectedLogID == "" || m.logView != "" || m.diffView {
+		return nil
+	}
+	m.logDepth = m.defaultLogDepth(m.selectedLogTab)
+	m.logComplete = false
+	m.logScrollPos = 0
+	m.logFollow = true
+	return m.fetchLogs(m.selectedLogID)
+}
+
+// fetchRawLog fetches the open log as it was written: the last depth lines
+// of a transcript file, or the unformatted process log. Sessions without a
+// transcript on disk fall back to their full history, formatted but not
+// cleaned or compressed. Carriage returns are still dropped, as they would
+// overwrite lines in the terminal.
+func fetchRawLog(client *data.Client, logTab int, id string, s data.Session, depth int, retry tea.Cmd) tea.Msg {
+	var (
+		content  string
+		complete bool
+		err      error
+	)
+	switch logTab {
+	case tabHistory:
+		content, complete, err = client.ReadTranscriptTail(id, depth)
+	case tabSessions:
+		transcript := data.TranscriptPath(s)
+		if transcript != "" {
+			content, complete, err = client.ReadTranscriptTail(transcript, depth)
+		}
+		if transcript == "" || err != nil {
+			var msgs []data.HistoryMessage
+			msgs, err = client.FetchSessionMessages(id, depth, s.SessionID)
+			content = data.FormatHistory(msgs, data.VerboseFull, true)
+			complete = len(msgs) < depth
+		}
+	default:
+		content, err = client.FetchProcessLog(id, depth)
+		complete = strings.Count(content, "\n") < depth
+	}
+	if err != nil {
+		return errMsg{fmt.Errorf("raw log(%s): %w", id, err), retry, pollLogs}
+	}
+	content = strings.ReplaceAll(content, "\r", "")
+	msg := logsMsg{content: content, logTab: logTab, complete: complete}
+	if logTab == tabProcesses {
+		msg.procLog = content
+	}
+	return msg
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// Reconnect attempts back off from reconnectBase, doubling up to
+// reconnectMax.
+const (
+	reconnectBase = 5 * time.Second
+	reconnectMax  = time.Minute
+)
+
+// reconnect tracks an unreachable gateway between health checks.
+type reconnect struct {
+	since    time.Time // when the gateway first failed to answer
+	attempts int       // failed checks so far
+	next     time.Time // when the next check is due
+	checking bool      // a check is in flight
+}
+
+type tickReconnectMsg struct{}
+
+func tickReconnect() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return tickReconnectMsg{}
+	})
+}
+
+// reconnectDelay is the wait after the given number of failed checks.
+func reconnectDelay(attempts int) time.Duration {
+	d := reconnectBase
+	for i := 1; i < attempts && d < reconnectMax; i++ {
+		d *= 2
+	}
+	if d > reconnectMax {
+		d = reconnectMax
+	}
+	return d
+}
+
+// noteHealth updates the reconnect state from a health check: a network
+// failure starts or extends the backoff, any answer ends it. It returns the
+// countdown tick when one starts, and a refresh of the lists on reconnect.
+func (m *Model) noteHealth(err error) tea.Cmd {
+	if err == nil || data.KindOf(err) != data.ErrKindNetwork {
+		if m.reconnect == nil {
+			return nil
+		}
+		down := time.Since(m.reconnect.since).Round(time.Second)
+		m.reconnect = nil
+		m.setStatus(fmt.Sprintf("gateway reachable again after %s", formatDuration(down)))
+		return tea.Batch(m.fetchSessions, m.fetchProcesses)
+	}
+	start := m.reconnect == nil
+	if start {
+		m.reconnect = &reconnect{since: time.Now()}
+	}
+	r := m.reconnect
+	r.attempts++
+	r.checking = false
+	r.next = time.Now().Add(reconnectDelay(r.attempts))
+	if start {
+		return tickReconnect()
+	}
+	return nil
+}
+
+// handleReconnectTick counts down to the next check while the gateway is
+// unreachable, and starts it when due.
+func (m *Model) handleReconnectTick() tea.Cmd {
+	r := m.reconnect
+	if r == nil {
+		return nil
+	}
+	if !r.checking && !time.Now().Before(r.next) {
+		r.checking = true
+		return tea.Batch(m.fetchHealth, tickReconnect())
+	}
+	return tickReconnect()
+}
+
+// retryGateway checks the gateway now instead of waiting out the backoff.
+func (m *Model) retryGateway() tea.Cmd {
+	if m.reconnect.checking {
+		return nil
+	}
+	m.reconnect.checking = true
+	m.setStatus("checking gateway " + m.cfg.GatewayURL + "...")
+	return m.fetchHealth
+}
+
+// bannerHeight is the height of the reconnect banner: a line while the
+// gateway is unreachable.
+func (m Model) bannerHeight() int {
+	if m.reconnect == nil {
+		return 0
+	}
+	return 1
+}
+
+// renderReconnectBanner spells out that the gateway is unreachable and
+// when the next attempt is.
+func (m Model) renderReconnectBanner() string {
+	r := m.reconnect
+	when := "retrying now..."
+	if !r.checking {
+		wait := time.Until(r.next).Round(time.Second)
+		if wait < 0 {
+			wait = 0
+		}
+		when = fmt.Sprintf("retrying in %s (press R to retry now)", formatDuration(wait))
+	}
+	text := fmt.Sprintf("%s, %s; down %s, %d attempts",
+		m.deco("⚠", "gateway unreachable"), when, formatDuration(time.Since(r.since)), r.attempts)
+	style := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#1a1b26")).Background(colorRed)
+	if m.cfg.A11y || m.cfg.NoColor {
+		style = lipgloss.NewStyle().Bold(true)
+	}
+	return style.Width(max(m.width, 1)).MaxWidth(max(m.width, 1)).Render(" " + text)
+}
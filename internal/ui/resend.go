@@ -0,0 +1,39 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// composedMessage is text sent from the composer and the session it went
+// to, so alt+m can pick up where it left off.
+type composedMessage struct {
+	key  string // session key of the target
+	name string // target's display name when sent
+	text string
+}
+
+// noteComposed remembers text as the last thing sent from the composer.
+func (m *Model) noteComposed(text string) {
+	m.lastComposed = &composedMessage{key: m.msgTargetKey, name: m.msgTargetName, text: text}
+}
+
+// messageLast reopens the composer on the session last messaged, wherever
+// the cursor is, with the text last sent to it ready to edit and resend.
+func (m *Model) messageLast() tea.Cmd {
+	last := m.lastComposed
+	if last == nil {
+		m.setStatus("no message sent yet; m on a session opens the composer")
+		return nil
+	}
+	if !m.can(data.PermMessage) {
+		return nil
+	}
+	s, ok := m.sessionByKey(last.key)
+	if !ok {
+		m.setStatus(last.name + " is no longer listed")
+		return nil
+	}
+	return m.openComposer(s, last.text)
+}
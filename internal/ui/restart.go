@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// Process restart policies, cycled with `a`.
+const (
+	restartNever     = "never"
+	restartOnFailure = "on-failure"
+	restartAlways    = "always"
+)
+
+// restartPolicies lists the policies in the order `a` cycles them.
+var restartPolicies = []string{restartNever, restartOnFailure, restartAlways}
+
+// Restarts back off from restartBackoff, doubling up to restartMaxBackoff,
+// and give up after maxRestarts within restartWindow.
+const (
+	restartBackoff    = 2 * time.Second
+	restartMaxBackoff = time.Minute
+	restartWindow     = 10 * time.Minute
+	maxRestarts       = 5
+)
+
+// restartDoneMsg reports a restart started by the supervisor.
+type restartDoneMsg struct {
+	command   string
+	from      string // name of the process that exited
+	sessionID string
+	err       error
+}
+
+// restartHistory tracks the supervisor's recent restarts of one command.
+type restartHistory struct {
+	at      []time.Time
+	gaveUp  bool
+	pending bool // the process vanished from the list and awaits a restart
+}
+
+// restartable reports whether p is managed by the gateway, so that it can
+// be started again from its command. Processes found by scanning the OS
+// can't be.
+func restartable(p data.Process) bool {
+	return p.Command != "" && !strings.HasPrefix(p.SessionName, "pid:")
+}
+
+// processExited reports whether status is that of a process no longer
+// running, and whether it failed.
+func processExited(status string) (exited, failed bool) {
+	switch status {
+	case "failed", "error":
+		return true, true
+	case "completed", "exited", "killed", "stopped", "done":
+		return true, false
+	}
+	return false, false
+}
+
+// restartPolicy returns the policy set for command.
+func (m Model) restartPolicy(command string) string {
+	if p, ok := m.state.RestartPolicies[command]; ok {
+		return p
+	}
+	return restartNever
+}
+
+// cycleRestartPolicy moves the selected process to the next restart policy.
+func (m *Model) cycleRestartPolicy() {
+	procs := m.filteredProcesses()
+	if m.activeTab != tabProcesses || m.processCursor >= len(procs) {
+		m.setStatus("restart policy: select a process")
+		return
+	}
+	p := procs[m.processCursor]
+	if !restartable(p) {
+		m.setStatus("restart policies apply to gateway-managed processes only")
+		return
+	}
+	next := restartPolicies[0]
+	for i, policy := range restartPolicies {
+		if policy == m.restartPolicy(p.Command) {
+			next = restartPolicies[(i+1)%len(restartPolicies)]
+		}
+	}
+	if next == restartNever {
+		delete(m.state.RestartPolicies, p.Command)
+	} else {
+		if m.state.RestartPolicies == nil {
+			m.state.RestartPolicies = map[string]string{}
+		}
+		m.state.RestartPolicies[p.Command] = next
+	}
+	// A new policy starts with a clean slate
+	delete(m.restarts, p.Command)
+	if err := m.state.Save(); err != nil {
+		m.setStatus("restart policy: " + err.Error())
+		return
+	}
+	m.setStatus(fmt.Sprintf("restart policy for %s: %s", p.SessionName, next))
+}
+
+// superviseProcesses restarts processes whose policy calls for it, given
+// the list before and after a refresh. A process that exits is restarted
+// once per exit, with backoff; one that vanishes from the list while
+// running counts as exited under the always policy.
+func (m *Model) superviseProcesses(prev, next []data.Process) tea.Cmd {
+	if len(m.state.RestartPolicies) == 0 {
+		return nil
+	}
+	running := map[string]bool{}
+	listed := map[string]bool{}
+	for _, p := range next {
+		listed[p.SessionName] = true
+		if exited, _ := processExited(p.Status); !exited {
+			running[p.Command] = true
+		}
+	}
+	for _, p := range prev {
+		if exited, _ := processExited(p.Status); !exited && !listed[p.SessionName] &&
+			m.restartPolicy(p.Command) == restartAlways && !m.userStopped[p.SessionName] {
+			m.restartState(p.Command).pending = true
+		}
+	}
+
+	now := time.Now()
+	var cmds []tea.Cmd
+	start := func(command, from string) {
+		h := m.restartState(command)
+		if h.gaveUp || now.Before(m.nextRestart(h, now)) {
+			return
+		}
+		if len(h.at) >= maxRestarts {
+			h.gaveUp = true
+			m.setStatus(fmt.Sprintf("gave up restarting %s after %d restarts in %s", from, maxRestarts, formatDuration(restartWindow)))
+			return
+		}
+		h.at = append(h.at, now)
+		h.pending = false
+		m.restarted[from] = true
+		client := m.client
+		cmds = append(cmds, func() tea.Msg {
+			id, err := client.StartProcess(command)
+			return restartDoneMsg{command: command, from: from, sessionID: id, err: err}
+		})
+	}
+	for _, p := range next {
+		exited, failed := processExited(p.Status)
+		if !exited || !restartable(p) || m.restarted[p.SessionName] || m.userStopped[p.SessionName] {
+			continue
+		}
+		policy := m.restartPolicy(p.Command)
+		if policy == restartAlways || (policy == restartOnFailure && failed) {
+			if running[p.Command] {
+				// Something else already started it again
+				m.restarted[p.SessionName] = true
+				continue
+			}
+			start(p.Command, p.SessionName)
+		}
+	}
+	for command, h := range m.restarts {
+		if h.pending && !running[command] {
+			start(command, command)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// restartState returns the restart history of command, creating it.
+func (m *Model) restartState(command string) *restartHistory {
+	h, ok := m.restarts[command]
+	if !ok {
+		h = &restartHistory{}
+		m.restarts[command] = h
+	}
+	return h
+}
+
+// nextRestart drops restarts older than restartWindow from h and returns
+// the earliest time the next one may happen.
+func (m *Model) nextRestart(h *restartHistory, now time.Time) time.Time {
+	recent := h.at[:0]
+	for _, t := range h.at {
+		if now.Sub(t) < restartWindow {
+			recent = append(recent, t)
+		}
+	}
+	h.at = recent
+	if len(h.at) == 0 {
+		return time.Time{}
+	}
+	backoff := restartBackoff << (len(h.at) - 1)
+	if backoff > restartMaxBackoff {
+		backoff = restartMaxBackoff
+	}
+	return h.at[len(h.at)-1].Add(backoff)
+}
+
+// handleRestartDone reports a supervisor restart and refreshes the list.
+func (m *Model) handleRestartDone(msg restartDoneMsg) tea.Cmd {
+	if msg.err != nil {
+		m.setStatus(fmt.Sprintf("restart %s: %v", msg.from, msg.err))
+		m.noteErr(msg.err)
+		return m.fetchProcesses
+	}
+	m.setStatus(m.deco("↻", fmt.Sprintf("restarted %s (%s)", msg.from, m.restartPolicy(msg.command))))
+	return m.fetchProcesses
+}
+
+// restartColumn marks a process row with its restart policy and how many
+// times the supervisor restarted it recently.
+func (m Model) restartColumn(p data.Process) string {
+	policy := m.restartPolicy(p.Command)
+	if policy == restartNever || !restartable(p) {
+		return ""
+	}
+	label := m.deco("↻", policy)
+	if h, ok := m.restarts[p.Command]; ok && len(h.at) > 0 {
+		label += fmt.Sprintf(" ×%d", len(h.at))
+		if h.gaveUp {
+			label += " gave up"
+		}
+	}
+	return " " + dimStyle.Render(label)
+}
@@ -0,0 +1,93 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// runEdit is an archived run whose label and note are being edited in the
+// note editor: the label first, then the note.
+type runEdit struct {
+	path    string
+	shown   string // the label shown when editing started
+	renamed bool   // shown was the operator's, not read from the transcript
+	note    string
+	label   string // the label typed, once past the first step
+	onNote  bool
+}
+
+// openRunEditor starts editing the label and note of the selected archived
+// run. They are saved in a sidecar next to the transcript.
+func (m *Model) openRunEditor() tea.Cmd {
+	runs := m.filteredArchived()
+	if m.historyCursor >= len(runs) {
+		return nil
+	}
+	r := runs[m.historyCursor]
+	m.runEdit = &runEdit{path: r.Path, shown: r.Label, renamed: r.Renamed, note: r.Note}
+	m.noteTargetName = r.Label
+	if m.noteTargetName == "" {
+		m.noteTargetName = r.SessionID
+	}
+	m.noteInput.SetValue(r.Label)
+	m.noteInput.CursorEnd()
+	m.noteInput.Focus()
+	m.editingNote = true
+	return textinput.Blink
+}
+
+// runEditStep takes the text typed for the current step: the label moves
+// on to the note, and the note saves both.
+func (m *Model) runEditStep(text string) tea.Cmd {
+	e := m.runEdit
+	if !e.onNote {
+		e.label, e.onNote = strings.TrimSpace(text), true
+		m.noteInput.SetValue(e.note)
+		m.noteInput.CursorEnd()
+		return nil
+	}
+	m.editingNote = false
+	m.runEdit = nil
+
+	// Only a label that was changed, or was already the operator's, is
+	// kept; an empty one goes back to the label read from the transcript
+	meta := data.RunMeta{Note: strings.TrimSpace(text)}
+	if e.label != e.shown || e.renamed {
+		meta.Label = e.label
+	}
+	if err := data.WriteRunMeta(e.path, meta); err != nil {
+		m.setStatus("label: " + err.Error())
+		return nil
+	}
+	for i := range m.archived {
+		if m.archived[i].Path == e.path {
+			if meta.Label != "" {
+				m.archived[i].Label, m.archived[i].Renamed = meta.Label, true
+			}
+			m.archived[i].Note = meta.Note
+		}
+	}
+	m.resortArchived()
+	if meta == (data.RunMeta{}) {
+		m.setStatus("label and note cleared")
+	} else {
+		m.setStatus("saved to " + shortenHome(data.SidecarPath(e.path)))
+	}
+	if e.renamed && meta.Label == "" {
+		return m.scanArchive()
+	}
+	return nil
+}
+
+// runEditPrompt is the status-bar prompt of the step being edited.
+func (m Model) runEditPrompt() string {
+	if m.runEdit.onNote {
+		return fmt.Sprintf("Note for %s: ", m.noteTargetName)
+	}
+	return fmt.Sprintf("Label for %s (empty: from transcript): ", m.noteTargetName)
+}
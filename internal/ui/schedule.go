@@ -0,0 +1,237 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+const (
+	// scheduleCheckInterval is how often due scheduled sends are looked for.
+	scheduleCheckInterval = 5 * time.Second
+	// scheduleClaimTTL is how long a send may stay in flight before
+	// another Commander may take it over, as after a crash mid-send.
+	scheduleClaimTTL = 10 * time.Minute
+	// scheduleRetryBase and scheduleRetryMax bound the wait before a
+	// failed send is tried again, doubling with each failure.
+	scheduleRetryBase = 30 * time.Second
+	scheduleRetryMax  = 15 * time.Minute
+)
+
+type tickScheduleMsg struct{}
+
+// scheduledSentMsg reports the delivery of a scheduled send.
+type scheduledSentMsg struct {
+	send config.ScheduledSend
+	err  error
+}
+
+func tickSchedule() tea.Cmd {
+	return tea.Tick(scheduleCheckInterval, func(time.Time) tea.Msg {
+		return tickScheduleMsg{}
+	})
+}
+
+// parseSendTime parses when a message should go out: a clock time such as
+// "09:00" (the next time it comes around) or a delay such as "+2h".
+func parseSendTime(s string, now time.Time) (time.Time, bool) {
+	if d, ok := strings.CutPrefix(s, "+"); ok {
+		dur, ok := parseDeadline(d)
+		return now.Add(dur), ok
+	}
+	t, err := time.ParseInLocation("15:04", s, now.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+	at := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, true
+}
+
+// scheduleCommand handles /later in the composer: "/later <when> <text>"
+// schedules text for the message target, "/later" lists pending sends, and
+// "/later cancel <n>" drops one.
+func (m *Model) scheduleCommand(arg string) tea.Cmd {
+	when, text, _ := strings.Cut(arg, " ")
+	text = strings.TrimSpace(text)
+	switch {
+	case when == "":
+		m.showScheduled()
+		return nil
+	case when == "cancel":
+		m.cancelScheduled(text)
+		return nil
+	}
+	at, ok := parseSendTime(when, time.Now())
+	if !ok || text == "" {
+		m.setStatus("usage: /later <09:00|+2h> <message>  (/later to list, /later cancel <n>)")
+		return nil
+	}
+	send := config.ScheduledSend{SessionID: m.msgTarget, Target: m.msgTargetName, Text: text, At: at.UnixMilli()}
+	schedule := func(m *Model) tea.Cmd {
+		m.addScheduled(send)
+		return nil
+	}
+	if data.IsExternalChannel(m.msgTargetChannel) {
+		prompt := fmt.Sprintf("Schedule delivery to %s via %s at %s?", m.msgTargetName, m.msgTargetChannel, at.Format("Mon 15:04"))
+		return m.confirmAction(config.ActionSend, prompt, m.msgTargetName, schedule)
+	}
+	return schedule(m)
+}
+
+// reloadScheduled takes the scheduled sends from the state file, which
+// other Commanders may have sent, cancelled, or added to since.
+func (m *Model) reloadScheduled() {
+	if config.StatePath() != "" {
+		m.state.Scheduled = config.LoadState().Scheduled
+	}
+}
+
+// sortScheduled keeps the scheduled sends in due order.
+func (m *Model) sortScheduled() {
+	sort.SliceStable(m.state.Scheduled, func(i, j int) bool {
+		return m.state.Scheduled[i].At < m.state.Scheduled[j].At
+	})
+}
+
+// addScheduled stores send, keeping the list in due order.
+func (m *Model) addScheduled(send config.ScheduledSend) {
+	send.ID = config.ScheduledID(send)
+	m.reloadScheduled()
+	m.state.Scheduled = append(m.state.Scheduled, send)
+	m.sortScheduled()
+	m.state.Save()
+	at := time.UnixMilli(send.At)
+	m.setStatus(m.deco("🕘", fmt.Sprintf("message to %s scheduled for %s (in %s)", send.Target, at.Format("Mon 15:04"), formatDuration(time.Until(at)))))
+}
+
+// cancelScheduled drops the nth pending send, counting from 1 as listed.
+func (m *Model) cancelScheduled(arg string) {
+	m.reloadScheduled()
+	n, err := strconv.Atoi(arg)
+	if err != nil || n < 1 || n > len(m.state.Scheduled) {
+		m.setStatus(fmt.Sprintf("/later cancel: pick 1-%d", len(m.state.Scheduled)))
+		return
+	}
+	send := m.state.Scheduled[n-1]
+	m.state.Scheduled = append(m.state.Scheduled[:n-1], m.state.Scheduled[n:]...)
+	m.state.Save()
+	m.setStatus("cancelled scheduled message to " + send.Target)
+	if m.logView == scheduledViewTitle {
+		m.showScheduled()
+	}
+}
+
+const scheduledViewTitle = "Scheduled sends"
+
+// showScheduled lists pending sends in the log panel.
+func (m *Model) showScheduled() {
+	m.reloadScheduled()
+	m.showLogView(scheduledViewTitle, m.renderScheduled())
+}
+
+// renderScheduled lists the pending sends.
+func (m Model) renderScheduled() string {
+	if len(m.state.Scheduled) == 0 {
+		return "No scheduled messages.\n\nIn the composer, /later 09:00 <message> or /later +2h <message> schedules one."
+	}
+	var b strings.Builder
+	for i, s := range m.state.Scheduled {
+		at := time.UnixMilli(s.At)
+		fmt.Fprintf(&b, "%d. %s  → %s  (in %s)", i+1, at.Format("Mon 15:04"), s.Target, formatDuration(time.Until(at)))
+		switch {
+		case s.Sending != 0:
+			b.WriteString("  sending…")
+		case s.Attempts > 0:
+			fmt.Fprintf(&b, "  retry %d: %s", s.Attempts, s.LastError)
+		}
+		b.WriteString("\n")
+		for _, line := range strings.Split(s.Text, "\n") {
+			b.WriteString("     " + line + "\n")
+		}
+	}
+	b.WriteString("\n/later cancel <n> in the composer cancels one.")
+	return b.String()
+}
+
+// sendDue delivers the scheduled sends that are due. Each is claimed first,
+// so that only one of the Commanders sharing the state file sends it, and
+// stays in the list marked in flight until handleScheduledSent hears how
+// the delivery went.
+func (m *Model) sendDue() tea.Cmd {
+	now := time.Now()
+	if len(m.state.Scheduled) == 0 || m.state.Scheduled[0].At > now.UnixMilli() {
+		return nil
+	}
+	m.reloadScheduled()
+	var due []config.ScheduledSend
+	for i := range m.state.Scheduled {
+		s := &m.state.Scheduled[i]
+		if s.At > now.UnixMilli() {
+			break
+		}
+		if s.Sending != 0 && now.Sub(time.UnixMilli(s.Sending)) < scheduleClaimTTL {
+			continue // in flight here or in another Commander
+		}
+		s.ID = config.ScheduledID(*s)
+		if !config.ClaimScheduled(s.ID, scheduleClaimTTL) {
+			continue
+		}
+		s.Sending = now.UnixMilli()
+		due = append(due, *s)
+	}
+	if len(due) == 0 {
+		return nil
+	}
+	m.state.Save()
+	client := m.client
+	cmds := make([]tea.Cmd, len(due))
+	for i, send := range due {
+		send := send
+		cmds[i] = func() tea.Msg {
+			_, err := client.SendMessage(send.SessionID, send.Text)
+			return scheduledSentMsg{send: send, err: err}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleScheduledSent settles a delivered send: it leaves the list, or on
+// failure goes back in it to be retried after a backoff.
+func (m *Model) handleScheduledSent(msg scheduledSentMsg) {
+	defer config.ReleaseScheduled(msg.send.ID)
+	m.reloadScheduled()
+	for i, s := range m.state.Scheduled {
+		if config.ScheduledID(s) != msg.send.ID {
+			continue
+		}
+		if msg.err == nil {
+			m.state.Scheduled = append(m.state.Scheduled[:i], m.state.Scheduled[i+1:]...)
+			break
+		}
+		s.Sending = 0
+		s.Attempts++
+		s.LastError = msg.err.Error()
+		wait := scheduleRetryBase << min(s.Attempts-1, 5)
+		if wait > scheduleRetryMax {
+			wait = scheduleRetryMax
+		}
+		s.At = time.Now().Add(wait).UnixMilli()
+		m.state.Scheduled[i] = s
+		m.sortScheduled()
+		break
+	}
+	m.state.Save()
+	if m.logView == scheduledViewTitle {
+		m.refreshLogView(m.renderScheduled())
+	}
+}
@@ -0,0 +1,25 @@
+package ui
+
+import (
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// mergeSent adds a sent message and the agent's reply to the open log when
+// it is the target session's, so both show with their roles until the
+// refresh brings the recorded history.
+func (m *Model) mergeSent(msg agentReplyMsg) {
+	s, ok := m.sessionByKey(m.selectedLogID)
+	if !ok || s.SessionID != msg.sessionID || m.selectedLogTab != tabSessions || m.logView != "" || m.diffView || m.rawLog {
+		return
+	}
+	msgs, cut := m.splitCached(append(append([]data.HistoryMessage(nil), m.cachedMessages...), msg.exchange...))
+	m.cachedMessages = msgs
+	filtered := m.filterMessagesBySource(msgs)
+	m.logContent = m.capLogContent(compressLogContent(data.FormatHistory(filtered, m.verboseLevel, m.showThinking)), cut)
+	m.logContentHash = "" // Force re-wrap
+	if m.logFollow {
+		m.logScrollPos = m.maxLogScroll(m.logWidth())
+	} else {
+		m.clampLogScroll(m.logWidth())
+	}
+}
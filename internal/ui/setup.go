@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// gatewaySetup is the prompt for fixing the gateway URL and token, shown
+// when the first health check at startup fails.
+type gatewaySetup struct {
+	url     textinput.Model
+	token   textinput.Model
+	field   int // 0 url, 1 token
+	testing bool
+	err     string // why the last check failed
+}
+
+// gatewayTestMsg is the result of checking an edited gateway config.
+type gatewayTestMsg struct {
+	cfg    config.Config
+	health *data.GatewayHealth
+	err    error
+}
+
+// healthFailure describes why a heartbeat counts as failed, or "" if the
+// gateway answered OK.
+func healthFailure(h *data.GatewayHealth, err error) string {
+	switch {
+	case err != nil:
+		return err.Error()
+	case h == nil || !h.OK:
+		return "gateway did not report healthy"
+	}
+	return ""
+}
+
+// openGatewaySetup shows the gateway prompt prefilled with the current
+// config.
+func (m *Model) openGatewaySetup(reason string) tea.Cmd {
+	u := textinput.New()
+	u.Placeholder = "http://127.0.0.1:18789"
+	u.CharLimit = 512
+	u.Width = 50
+	u.SetValue(m.cfg.GatewayURL)
+	u.Focus()
+
+	t := textinput.New()
+	t.Placeholder = "(none)"
+	t.CharLimit = 512
+	t.Width = 50
+	t.EchoMode = textinput.EchoPassword
+	t.SetValue(m.cfg.Token)
+
+	m.setup = &gatewaySetup{url: u, token: t, err: reason}
+	return textinput.Blink
+}
+
+// testGateway checks cfg with a fresh client.
+func testGateway(cfg config.Config) tea.Cmd {
+	return func() tea.Msg {
+		h, err := data.NewClient(cfg).FetchGatewayHealth()
+		return gatewayTestMsg{cfg: cfg, health: h, err: err}
+	}
+}
+
+// handleGatewayTest switches to the edited config once it checks out.
+func (m *Model) handleGatewayTest(msg gatewayTestMsg) {
+	if m.setup == nil {
+		return
+	}
+	m.setup.testing = false
+	if reason := healthFailure(msg.health, msg.err); reason != "" {
+		m.setup.err = reason
+		return
+	}
+	m.setup = nil
+	m.cfg = msg.cfg
+	m.client = data.NewClient(msg.cfg)
+	m.health = msg.health
+	m.setStatus("connected to " + msg.cfg.GatewayURL + " (pass --url/--token to keep it)")
+}
+
+// handleGatewaySetup handles keys while the gateway prompt is open.
+func (m *Model) handleGatewaySetup(msg tea.KeyMsg) tea.Cmd {
+	s := m.setup
+	switch {
+	case msg.Type == tea.KeyCtrlC:
+		return tea.Quit
+	case key.Matches(msg, keys.Escape):
+		m.setup = nil
+		m.setStatus("continuing without a healthy gateway")
+		return nil
+	case key.Matches(msg, keys.Tab), msg.Type == tea.KeyUp, msg.Type == tea.KeyDown:
+		s.field = 1 - s.field
+		if s.field == 0 {
+			s.token.Blur()
+			s.url.Focus()
+		} else {
+			s.url.Blur()
+			s.token.Focus()
+		}
+		return textinput.Blink
+	case key.Matches(msg, keys.Enter):
+		if s.testing {
+			return nil
+		}
+		cfg := m.cfg
+		cfg.GatewayURL = strings.TrimRight(strings.TrimSpace(s.url.Value()), "/")
+		cfg.Token = strings.TrimSpace(s.token.Value())
+		if cfg.GatewayURL == "" {
+			s.err = "gateway URL is required"
+			return nil
+		}
+		s.testing = true
+		s.err = ""
+		return testGateway(cfg)
+	}
+	return m.updateSetupInput(msg)
+}
+
+// updateSetupInput passes msg to the focused gateway prompt field.
+func (m *Model) updateSetupInput(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	if m.setup.field == 0 {
+		m.setup.url, cmd = m.setup.url.Update(msg)
+	} else {
+		m.setup.token, cmd = m.setup.token.Update(msg)
+	}
+	return cmd
+}
+
+// renderGatewaySetup draws the gateway prompt in place of the status bar.
+func (m Model) renderGatewaySetup() string {
+	s := m.setup
+	width := m.width
+	if width == 0 {
+		width = 80
+	}
+	var b strings.Builder
+	title := titleStyle.Render(m.deco("🔌", "Gateway unreachable"))
+	if s.testing {
+		title += statusThinking.Render(" " + m.deco("⏳", "testing..."))
+	}
+	b.WriteString(title + "\n")
+	if s.err != "" {
+		b.WriteString(statusFailed.Render(s.err) + "\n")
+	}
+	for i, f := range []struct {
+		label string
+		input textinput.Model
+	}{{"URL:   ", s.url}, {"Token: ", s.token}} {
+		style := dimStyle
+		if s.field == i {
+			style = accentStyle
+		}
+		b.WriteString(m.cursorMark(s.field == i) + style.Render(f.label) + f.input.View() + "\n")
+	}
+	b.WriteString(dimStyle.Render(fmt.Sprintf("tab: switch field  ↵: test and connect  esc: continue with %s", m.cfg.GatewayURL)))
+	return statusBarStyle.Width(width).Render(b.String())
+}
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// summarizePrompt is sent for /summarize.
+const summarizePrompt = "Briefly summarize what you have done so far in this session, " +
+	"what is in progress, and anything blocking you."
+
+// slashDoneMsg reports the outcome of a composer slash command. A non-empty
+// content is shown in the log panel under title.
+type slashDoneMsg struct {
+	status  string
+	title   string
+	content string
+}
+
+// composerCommands are the slash commands Commander runs itself instead of
+// sending them to the agent.
+var composerCommands = map[string]bool{
+	"/abort":     true, // abort the session's current run
+	"/model":     true, // set the session model (/model default to reset)
+	"/status":    true, // show the session status card
+	"/summarize": true, // ask the agent for a progress summary
+	"/compact":   true, // compact the session context
+	"/later":     true, // schedule a message, list or cancel scheduled ones
+}
+
+// isComposerCommand reports whether text starts with a Commander slash command.
+func isComposerCommand(text string) bool {
+	name, _, _ := strings.Cut(strings.TrimSpace(text), " ")
+	return composerCommands[name]
+}
+
+// runComposerCommand maps a slash command typed in the message composer to
+// the matching gateway action for the message target.
+func (m *Model) runComposerCommand(text string) tea.Cmd {
+	m.msgInput.SetValue("")
+	name, arg, _ := strings.Cut(strings.TrimSpace(text), " ")
+	arg = strings.TrimSpace(arg)
+	key, target := m.msgTargetKey, m.msgTargetName
+	client := m.client
+
+	switch name {
+	case "/abort":
+		return m.abortSession(key, target)
+	case "/model":
+		if arg == "" {
+			m.setStatus("usage: /model <name>  (/model default to reset)")
+			return nil
+		}
+		return func() tea.Msg {
+			card, err := client.SessionStatus(key, arg)
+			if err != nil {
+				return errMsg{err: fmt.Errorf("/model: %w", err)}
+			}
+			return slashDoneMsg{status: fmt.Sprintf("%s model set to %s", target, arg), title: "Status: " + target, content: card}
+		}
+	case "/status":
+		return func() tea.Msg {
+			card, err := client.SessionStatus(key, "")
+			if err != nil {
+				return errMsg{err: fmt.Errorf("/status: %w", err)}
+			}
+			return slashDoneMsg{title: "Status: " + target, content: card}
+		}
+	case "/compact":
+		return m.compactSession(key, target)
+	case "/summarize":
+		return m.sendMessage(summarizePrompt)
+	case "/later":
+		return m.scheduleCommand(arg)
+	}
+	return nil
+}
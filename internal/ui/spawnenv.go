@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// toggleEnvMask shows or hides the values typed in the spawn form's Env
+// field. They start hidden, as they are usually API keys.
+func (m *Model) toggleEnvMask() {
+	if m.spawnEnv.EchoMode == textinput.EchoPassword {
+		m.spawnEnv.EchoMode = textinput.EchoNormal
+	} else {
+		m.spawnEnv.EchoMode = textinput.EchoPassword
+	}
+}
+
+// envHint names the variables typed in the Env field, since the field
+// itself is masked, or says what is wrong with them.
+func (m Model) envHint() string {
+	text := strings.TrimSpace(m.spawnEnv.Value())
+	if text == "" {
+		return ""
+	}
+	env, err := data.ParseEnv(text)
+	if err != nil {
+		return statusFailed.Render(err.Error())
+	}
+	hint := fmt.Sprintf("%d variables: %s (sent to the gateway, not the transcript)", len(env), strings.Join(data.EnvNames(env), ", "))
+	if m.spawnField == spawnFieldEnv {
+		hint += "  ctrl+t:show/hide values"
+	}
+	return dimStyle.Render(hint)
+}
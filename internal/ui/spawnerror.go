@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// spawnFailedMsg reports a spawn the CLI refused. The form stays open with
+// its fields so the request can be corrected and sent again.
+type spawnFailedMsg struct{ err error }
+
+// renderSpawnError shows why the last spawn failed in the spawn form: the
+// error code and message the CLI reported, and a hint for fixing common
+// causes.
+func (m Model) renderSpawnError() string {
+	if m.spawnErr == nil {
+		return ""
+	}
+	heading, message := "Spawn failed", m.spawnErr.Error()
+	var apiErr *data.APIError
+	if errors.As(m.spawnErr, &apiErr) {
+		if apiErr.Code != "" {
+			heading += " (" + apiErr.Code + ")"
+		}
+		if apiErr.Message != "" {
+			message = apiErr.Message
+		}
+	}
+	message = strings.TrimSpace(message)
+	if lines := strings.Split(message, "\n"); len(lines) > 3 {
+		message = strings.Join(append(lines[:3], "…"), "\n")
+	}
+
+	var b strings.Builder
+	b.WriteString(statusFailed.Render(m.deco("✗", heading+":")) + "\n")
+	for _, line := range strings.Split(message, "\n") {
+		b.WriteString("  " + line + "\n")
+	}
+	if hint := spawnErrorHint(apiErr, message); hint != "" {
+		b.WriteString("  " + dimStyle.Render(hint) + "\n")
+	}
+	return b.String()
+}
+
+// spawnErrorHint suggests how to correct the form after a failed spawn.
+func spawnErrorHint(apiErr *data.APIError, message string) string {
+	what := strings.ToLower(message)
+	if apiErr != nil {
+		what = strings.ToLower(apiErr.Code) + " " + what
+		if apiErr.Kind() == data.ErrKindAuth {
+			return "Check the gateway token and the parent session's permissions, then press ↵ to retry."
+		}
+	}
+	switch {
+	case strings.Contains(what, "rate") || strings.Contains(what, "429") || strings.Contains(what, "quota"):
+		return "Wait a moment, then press ↵ to retry."
+	case strings.Contains(what, "model"):
+		return "Pick another model on the Model field, then press ↵ to retry."
+	}
+	return "Edit the form and press ↵ to retry, or esc to cancel."
+}
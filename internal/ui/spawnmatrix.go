@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// minCompareColumn is the narrowest column the comparison view lays answers
+// side by side in; narrower panels stack them instead.
+const minCompareColumn = 24
+
+// spawnMatrix is the last one-prompt, many-models spawn, remembered so the
+// sessions' answers can be compared.
+type spawnMatrix struct {
+	base   string   // label the per-model labels were derived from
+	labels []string // one per model, in the order spawned
+	models []string
+}
+
+// matrixAnswer is one model's final answer in a spawn matrix.
+type matrixAnswer struct {
+	label  string
+	model  string
+	status string // the session's status, or "" when it isn't listed yet
+	text   string
+}
+
+// compareMsg carries the final answers of a spawn matrix's sessions.
+type compareMsg struct{ answers []matrixAnswer }
+
+// spawnModelID returns the model ID of a spawn form model option, or ""
+// for the gateway default.
+func spawnModelID(option string) string {
+	if option == "(default)" {
+		return ""
+	}
+	// Strip "  (alias)" suffix if present
+	if idx := strings.Index(option, "  ("); idx > 0 {
+		option = option[:idx]
+	}
+	return option
+}
+
+// toggleMatrixModel adds the highlighted model to the spawn matrix, or
+// removes it.
+func (m *Model) toggleMatrixModel() {
+	id := spawnModelID(m.spawnModelOptions[m.spawnModelCursor])
+	if id == "" {
+		m.setStatus("pick specific models to compare")
+		return
+	}
+	if m.spawnModels[id] {
+		delete(m.spawnModels, id)
+		return
+	}
+	if m.spawnModels == nil {
+		m.spawnModels = make(map[string]bool)
+	}
+	m.spawnModels[id] = true
+}
+
+// matrixModels returns the models picked for a spawn matrix, in the order
+// the form lists them.
+func (m Model) matrixModels() []string {
+	var out []string
+	for _, opt := range m.spawnModelOptions {
+		if id := spawnModelID(opt); m.spawnModels[id] {
+			out = append(out, id)
+		}
+	}
+	return out
+}
+
+// startSpawnMatrix spawns one session per picked model with the same
+// prompt, labelled label-alias, and shows their progress like a bulk spawn.
+func (m *Model) startSpawnMatrix(prompt, label string, env map[string]string, parent data.Session) tea.Cmd {
+	models := m.matrixModels()
+	if label == "" {
+		label = "task"
+	}
+	taken := m.takenLabels()
+	list := &data.TaskList{Concurrency: len(models)}
+	matrix := &spawnMatrix{base: label, models: models}
+	for _, model := range models {
+		l := data.UniqueLabel(label+"-"+strings.ToLower(data.ModelAlias(model)), taken)
+		taken[l] = true
+		matrix.labels = append(matrix.labels, l)
+		list.Tasks = append(list.Tasks, data.SpawnTask{Prompt: prompt, Model: model, Label: l, Parent: parent.SessionID, Env: env})
+	}
+	m.matrix = matrix
+	m.spawnModels = nil
+	m.spawning = false
+	return m.runBulkSpawn("spawn matrix "+label, list)
+}
+
+// compareMatrix fetches the final answer of each session of the last spawn
+// matrix for the comparison view.
+func (m *Model) compareMatrix() tea.Cmd {
+	if m.matrix == nil {
+		m.setStatus("compare: no spawn matrix yet (mark models with space in the spawn form)")
+		return nil
+	}
+	byLabel := make(map[string]data.Session)
+	for _, s := range m.sessions {
+		byLabel[s.Label] = s
+	}
+	matrix := *m.matrix
+	client := m.client
+	m.setStatus("fetching answers…")
+	return func() tea.Msg {
+		var answers []matrixAnswer
+		for i, label := range matrix.labels {
+			a := matrixAnswer{label: label, model: matrix.models[i]}
+			if s, ok := byLabel[label]; ok {
+				a.status = s.EffectiveStatus()
+				if msgs, err := client.FetchSessionMessages(s.Key, 10, s.SessionID); err != nil {
+					a.text = "✗ " + err.Error()
+				} else {
+					a.text = finalAnswer(msgs)
+				}
+			}
+			answers = append(answers, a)
+		}
+		return compareMsg{answers}
+	}
+}
+
+// finalAnswer returns the text of the last assistant message in msgs.
+func finalAnswer(msgs []data.HistoryMessage) string {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role == "assistant" && strings.TrimSpace(msgs[i].Text) != "" {
+			return strings.TrimSpace(msgs[i].Text)
+		}
+	}
+	return ""
+}
+
+// showComparison lays the answers out side by side in the log panel, or
+// one after another when the panel is too narrow.
+func (m *Model) showComparison(answers []matrixAnswer) {
+	title := "Compare: " + m.matrix.base
+	width := m.logWidth()
+	const sep = " │ "
+	cols := len(answers)
+	colWidth := (width - (cols-1)*lipgloss.Width(sep)) / max(1, cols)
+
+	blocks := make([][]string, len(answers))
+	for i, a := range answers {
+		head := data.ModelAlias(a.model) + " · " + a.label
+		text := a.text
+		switch {
+		case a.status == "":
+			head += " · not listed yet"
+		case a.status == "running":
+			head += " · running"
+			if text != "" {
+				text += "\n…"
+			}
+		default:
+			head += " · " + a.status
+		}
+		if text == "" {
+			text = "(no answer yet)"
+		}
+		block := head + "\n" + strings.Repeat("─", min(lipgloss.Width(head), max(colWidth, 1))) + "\n" + text
+		if colWidth >= minCompareColumn {
+			block = lipgloss.NewStyle().Width(colWidth).Render(block)
+		}
+		blocks[i] = strings.Split(block, "\n")
+	}
+
+	var b strings.Builder
+	if colWidth < minCompareColumn {
+		for i, lines := range blocks {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(strings.Join(lines, "\n") + "\n")
+		}
+	} else {
+		rows := 0
+		for _, lines := range blocks {
+			rows = max(rows, len(lines))
+		}
+		for r := 0; r < rows; r++ {
+			cells := make([]string, len(blocks))
+			for i, lines := range blocks {
+				cells[i] = strings.Repeat(" ", colWidth)
+				if r < len(lines) {
+					cells[i] = lines[r]
+				}
+			}
+			b.WriteString(strings.TrimRight(strings.Join(cells, sep), " ") + "\n")
+		}
+	}
+	m.showLogView(title, b.String())
+	m.setStatus(fmt.Sprintf("comparing %d answers", len(answers)))
+}
+
+// matrixSummary lists the models picked for a spawn matrix, for the spawn
+// form.
+func (m Model) matrixSummary() string {
+	models := m.matrixModels()
+	if len(models) == 0 {
+		return ""
+	}
+	aliases := make([]string, len(models))
+	for i, id := range models {
+		aliases[i] = data.ModelAlias(id)
+	}
+	return fmt.Sprintf("compare %d: %s", len(models), strings.Join(aliases, ", "))
+}
@@ -0,0 +1,38 @@
+package ui
+
+import "fmt"
+
+// The list panel's share of the screen width, in percent, as changed with
+// ctrl+left and ctrl+right.
+const (
+	defaultListPercent = 40
+	minListPercent     = 20
+	maxListPercent     = 70
+	listPercentStep    = 5
+)
+
+// listPercent returns the list panel's share of the width.
+func (m Model) listPercent() int {
+	if p := m.state.ListPercent; p >= minListPercent && p <= maxListPercent {
+		return p
+	}
+	return defaultListPercent
+}
+
+// listWidth returns the width of the list panel's content.
+func (m Model) listWidth() int {
+	return max(20, m.width*m.listPercent()/100-2)
+}
+
+// resizeSplit moves the split between the list and log panels by delta
+// steps and remembers it.
+func (m *Model) resizeSplit(delta int) {
+	p := min(maxListPercent, max(minListPercent, m.listPercent()+delta*listPercentStep))
+	if p == m.listPercent() {
+		return
+	}
+	m.state.ListPercent = p
+	m.state.Save()
+	m.clampLogScroll(m.logWidth())
+	m.setStatus(fmt.Sprintf("split: list %d%% · log %d%%", p, 100-p))
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// applyStartFlags shows the tab and applies the filter given by --tab and
+// --filter; the --session one is opened once sessions have loaded. Without
+// a tab or session to show, pickStartTab picks the tab.
+func (m *Model) applyStartFlags() {
+	if i := config.StartTabIndex(m.cfg.StartTab); i >= 0 {
+		m.activeTab = i
+	}
+	if m.cfg.StartFilter != "" {
+		m.filter = m.cfg.StartFilter
+		m.searchInput.SetValue(m.filter)
+	}
+	m.startSession = strings.TrimSpace(m.cfg.StartSession)
+	m.pickingTab = config.StartTabIndex(m.cfg.StartTab) < 0 && m.startSession == ""
+}
+
+// pickStartTab lands on the tab most worth seeing, once the first health
+// check (and, with the gateway up, the first process list) is in: History
+// when the gateway can't be reached, since archived runs are read from
+// disk; Processes with the log of the first failed process open when one
+// has failed; otherwise Sessions stays.
+func (m *Model) pickStartTab() tea.Cmd {
+	if !m.pickingTab || !m.healthChecked {
+		return nil
+	}
+	if healthFailure(m.health, nil) != "" {
+		m.pickingTab = false
+		m.activeTab = tabHistory // the status bar says why
+		return nil
+	}
+	if !m.processesSeen {
+		return nil
+	}
+	m.pickingTab = false
+	for i, p := range m.filteredProcesses() {
+		if _, failed := processExited(p.Status); failed {
+			m.activeTab = tabProcesses
+			m.processCursor = i
+			m.setStatus("started on Processes: " + p.SessionName + " failed")
+			return m.openLog(p.SessionName, tabProcesses)
+		}
+	}
+	return nil
+}
+
+// findStartSession finds the session --session names: by key or ID, then
+// by label or display name regardless of case.
+func (m Model) findStartSession(name string) (data.Session, bool) {
+	for _, s := range m.sessions {
+		if s.Key == name || s.SessionID == name {
+			return s, true
+		}
+	}
+	for _, s := range m.sessions {
+		if strings.EqualFold(s.Label, name) || strings.EqualFold(sessionDisplayName(s), name) {
+			return s, true
+		}
+	}
+	return data.Session{}, false
+}
+
+// openStartSession selects the --session session and opens its logs,
+// following them, after the first session refresh.
+func (m *Model) openStartSession() tea.Cmd {
+	name := m.startSession
+	if name == "" {
+		return nil
+	}
+	m.startSession = ""
+	s, ok := m.findStartSession(name)
+	if !ok {
+		m.setStatus("--session: no session " + name)
+		return nil
+	}
+	if config.StartTabIndex(m.cfg.StartTab) < 0 {
+		m.activeTab = tabSessions
+	}
+	for i, fs := range m.filteredSessions() {
+		if fs.Key == s.Key {
+			m.sessionCursor = i
+		}
+	}
+	return m.openLog(s.Key, tabSessions)
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+const (
+	// streamWindow is how far back the output rate looks.
+	streamWindow = 15 * time.Second
+	// streamStall is how long a working session may go without output
+	// before the indicator says it has stalled.
+	streamStall = 10 * time.Second
+	// charsPerToken estimates tokens from text until the transcript
+	// records the tokens of a finished turn to calibrate against.
+	charsPerToken = 4.0
+)
+
+// streamSample is the assistant output one log fetch brought.
+type streamSample struct {
+	at    time.Time
+	chars int
+}
+
+// streamRate measures how fast a followed session's assistant output grows
+// between log fetches, so a slow session can be told apart from a slow
+// gateway.
+type streamRate struct {
+	key        string
+	lengths    map[string]int // output length of each assistant message seen
+	newest     int64          // latest message timestamp seen
+	samples    []streamSample // within streamWindow
+	lastGrowth time.Time
+	perToken   float64 // characters per token
+}
+
+// outputLen is how much output an assistant message holds.
+func outputLen(msg data.HistoryMessage) int {
+	return utf8.RuneCountInString(msg.Text) + utf8.RuneCountInString(msg.Thinking)
+}
+
+// observe records the assistant output in msgs, the latest fetch of the
+// session's log. Messages that grew count by how much they grew; messages
+// newer than any seen count in full. Older messages that appear when more
+// of the log loads don't count.
+func (r *streamRate) observe(msgs []data.HistoryMessage, now time.Time) {
+	first := r.lengths == nil
+	lengths := make(map[string]int, len(msgs))
+	seen := map[int64]int{}
+	newest := r.newest
+	grown, chars, tokens := 0, 0, 0
+	for _, msg := range msgs {
+		if msg.Role != "assistant" {
+			continue
+		}
+		// Timestamps identify messages; the count tells apart ones that share one
+		id := fmt.Sprintf("%d/%d", msg.Timestamp, seen[msg.Timestamp])
+		seen[msg.Timestamp]++
+		n := outputLen(msg)
+		lengths[id] = n
+		if prev, ok := r.lengths[id]; ok {
+			if n > prev {
+				grown += n - prev
+			}
+		} else if !first && msg.Timestamp > r.newest {
+			grown += n
+		}
+		if msg.Timestamp > newest {
+			newest = msg.Timestamp
+		}
+		if msg.Tokens > 0 && n > 0 {
+			chars += n
+			tokens += msg.Tokens
+		}
+	}
+	r.lengths, r.newest = lengths, newest
+	if tokens > 0 {
+		r.perToken = float64(chars) / float64(tokens)
+	}
+	if first {
+		r.samples = []streamSample{{at: now}}
+		return
+	}
+	if grown > 0 {
+		r.lastGrowth = now
+	}
+	r.samples = append(r.samples, streamSample{at: now, chars: grown})
+	// Keep one sample older than the window as the start of the span
+	for len(r.samples) > 2 && now.Sub(r.samples[1].at) > streamWindow {
+		r.samples = r.samples[1:]
+	}
+}
+
+// rate is the output per second over the window, in characters and
+// estimated tokens. ok is false until two fetches span some time.
+func (r *streamRate) rate() (chars, tokens float64, ok bool) {
+	if len(r.samples) < 2 {
+		return 0, 0, false
+	}
+	span := r.samples[len(r.samples)-1].at.Sub(r.samples[0].at)
+	if span <= 0 {
+		return 0, 0, false
+	}
+	total := 0
+	for _, s := range r.samples[1:] {
+		total += s.chars
+	}
+	chars = float64(total) / span.Seconds()
+	perToken := r.perToken
+	if perToken <= 0 {
+		perToken = charsPerToken
+	}
+	return chars, chars / perToken, true
+}
+
+// trackStream measures the output of the followed session whose log just
+// arrived. Other logs stop the measurement.
+func (m *Model) trackStream(msg logsMsg) {
+	if msg.logTab != tabSessions || !m.logFollow || m.selectedLogTab != tabSessions {
+		m.stream = nil
+		return
+	}
+	if m.stream == nil || m.stream.key != m.selectedLogID {
+		m.stream = &streamRate{key: m.selectedLogID}
+	}
+	m.stream.observe(msg.messages, time.Now())
+}
+
+// streamTag shows the output rate of the followed session next to the log
+// title, with the gateway's median latency: a session streaming slowly over
+// a fast gateway is the model being slow. A working session that has gone
+// quiet says so. Nothing shows before the session writes anything.
+func (m Model) streamTag() string {
+	r := m.stream
+	if r == nil || !m.logFollow || r.key != m.selectedLogID || r.lastGrowth.IsZero() {
+		return ""
+	}
+	gateway := ""
+	if m.healthStats.P50 > 0 {
+		gateway = "gateway " + m.healthStats.P50.Round(time.Millisecond).String()
+	}
+	if quiet := time.Since(r.lastGrowth); quiet > streamStall {
+		s, ok := m.sessionByKey(r.key)
+		if !ok || !sessionWorking(s) {
+			return ""
+		}
+		tag := statusThinking.Render(" " + m.deco("⚡", "no output for "+formatDuration(quiet)))
+		if gateway != "" {
+			tag += dimStyle.Render(" (" + gateway + ")")
+		}
+		return tag
+	}
+	chars, tokens, ok := r.rate()
+	if !ok {
+		return ""
+	}
+	detail := fmt.Sprintf("%.0f chars/s", chars)
+	if gateway != "" {
+		detail += ", " + gateway
+	}
+	return statusRunning.Render(" "+m.deco("⚡", fmt.Sprintf("%.0f tok/s", tokens))) + dimStyle.Render(" ("+detail+")")
+}
+
+// sessionWorking reports whether a session looks busy producing output.
+func sessionWorking(s data.Session) bool {
+	switch s.EffectiveStatus() {
+	case "running", "active", "thinking", "working":
+		return true
+	}
+	return false
+}
@@ -1,6 +1,14 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
 
 var (
 	// Base colors
@@ -14,6 +22,8 @@ var (
 	colorDim       = lipgloss.Color("#565f89")
 	colorTitle     = lipgloss.Color("#c0caf5")
 	colorStatusBar = lipgloss.Color("#24283b")
+	colorOrange    = lipgloss.Color("#ff9e64")
+	colorPurple    = lipgloss.Color("#bb9af7")
 
 	// Panel styles
 	panelBorder = lipgloss.NewStyle().
@@ -32,6 +42,8 @@ var (
 	dimStyle = lipgloss.NewStyle().
 			Foreground(colorDim)
 
+	thinkingStyle = dimStyle.Italic(true)
+
 	accentStyle = lipgloss.NewStyle().
 			Foreground(colorAccent).
 			Bold(true)
@@ -40,6 +52,8 @@ var (
 			Foreground(colorYellow).
 			Italic(true)
 
+	jsonKeyStyle = lipgloss.NewStyle().Foreground(colorAccent)
+
 	// Status colors
 	statusRunning  = lipgloss.NewStyle().Foreground(colorGreen)
 	statusThinking = lipgloss.NewStyle().Foreground(colorYellow)
@@ -85,6 +99,22 @@ func statusStyle(status string) lipgloss.Style {
 	}
 }
 
+// errorKindStyle colors status-bar errors by category.
+func errorKindStyle(kind data.ErrorKind) lipgloss.Style {
+	switch kind {
+	case data.ErrKindNetwork:
+		return statusFailed
+	case data.ErrKindAuth:
+		return lipgloss.NewStyle().Foreground(colorOrange)
+	case data.ErrKindTool:
+		return statusThinking
+	case data.ErrKindParse:
+		return lipgloss.NewStyle().Foreground(colorPurple)
+	default:
+		return statusFailed
+	}
+}
+
 func statusIndicator(status string) string {
 	switch status {
 	case "running", "active":
@@ -100,6 +130,29 @@ func statusIndicator(status string) string {
 	}
 }
 
+// outcomeBadge marks how an archived run ended, spelled out in
+// accessibility mode. Runs whose ending is unknown get a blank.
+func (m Model) outcomeBadge(outcome string) string {
+	glyph, style := outcomeStyle(outcome)
+	if m.cfg.A11y {
+		return style.Render(fmt.Sprintf("%-9s", outcome))
+	}
+	return style.Render(glyph)
+}
+
+// outcomeStyle returns the glyph and color of a run outcome.
+func outcomeStyle(outcome string) (string, lipgloss.Style) {
+	switch outcome {
+	case data.RunCompleted:
+		return "✓", statusRunning
+	case data.RunFailed:
+		return "✗", statusFailed
+	case data.RunAborted:
+		return "■", statusThinking
+	}
+	return " ", dimStyle
+}
+
 func processIndicator(status string) string {
 	switch status {
 	case "running", "active":
@@ -110,3 +163,84 @@ func processIndicator(status string) string {
 		return dimStyle.Render("\u25a0")
 	}
 }
+
+// modelPalette holds the colors assigned to models that have no configured
+// color. Chosen to stay distinct from each other and from status colors.
+var modelPalette = []lipgloss.Color{
+	"#7dcfff", "#bb9af7", "#ff9e64", "#73daca", "#e0af68",
+	"#2ac3de", "#f7768e", "#9ece6a", "#c0a36e", "#ad8ee6",
+}
+
+// modelStyle returns the identity color for a model. A configured color
+// applies when its key equals the model ID or alias, or failing that, the
+// longest key contained in the ID. Other models get a palette color picked
+// by hashing the alias, so a model keeps its color across sessions and runs.
+func modelStyle(model string, colors map[string]string) lipgloss.Style {
+	alias := data.ModelAlias(model)
+	lower := strings.ToLower(model)
+	color, best := "", 0
+	for k, c := range colors {
+		if k == model || k == alias {
+			color = c
+			break
+		}
+		if len(k) > best && strings.Contains(lower, strings.ToLower(k)) {
+			color, best = c, len(k)
+		}
+	}
+	if color != "" {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(color))
+	}
+	h := fnv.New32a()
+	h.Write([]byte(alias))
+	return lipgloss.NewStyle().Foreground(modelPalette[h.Sum32()%uint32(len(modelPalette))])
+}
+
+// accentColors are the palette colors behind config.AccentNames.
+var accentColors = map[string]lipgloss.Color{
+	"red":    colorRed,
+	"orange": colorOrange,
+	"yellow": colorYellow,
+	"green":  colorGreen,
+	"blue":   colorAccent,
+	"purple": colorPurple,
+}
+
+// environmentAccent returns the accent color configured for the gateway's
+// environment, if any.
+func (m Model) environmentAccent() (lipgloss.Color, bool) {
+	env, ok := m.cfg.Environment()
+	if !ok || env.Accent == "" {
+		return "", false
+	}
+	if c, ok := accentColors[env.Accent]; ok {
+		return c, true
+	}
+	return lipgloss.Color(env.Accent), true
+}
+
+// focusedBorder is the border of the focused panel, tinted with the
+// environment accent so production gateways are hard to mistake.
+func (m Model) focusedBorder() lipgloss.Style {
+	if c, ok := m.environmentAccent(); ok {
+		return activePanelBorder.BorderForeground(c)
+	}
+	return activePanelBorder
+}
+
+// environmentBadge labels the status bar with the gateway's environment,
+// on its accent color when one is set.
+func (m Model) environmentBadge() string {
+	env, ok := m.cfg.Environment()
+	if !ok {
+		return ""
+	}
+	badge := lipgloss.NewStyle().Bold(true).Padding(0, 1)
+	if c, ok := m.environmentAccent(); ok {
+		badge = badge.Background(c).Foreground(colorBg)
+	}
+	if m.cfg.NoColor || m.cfg.A11y {
+		return badge.Render("[" + env.Label + "]")
+	}
+	return badge.Render(env.Label)
+}
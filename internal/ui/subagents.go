@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// logRef identifies a log shown in the log panel.
+type logRef struct {
+	id  string
+	tab int
+}
+
+// activeSpawnLink returns the first sub-agent link visible in the log panel
+// and the wrapped row it starts on, or ("", -1) when none is on screen.
+// width and viewH must match the panel being rendered.
+func (m Model) activeSpawnLink(width, viewH int) (string, int) {
+	if m.logView != "" || m.diffView || m.selectedLogTab == tabProcesses {
+		return "", -1
+	}
+	target, linkRow := "", -1
+	m.scanLogLines(width, viewH, func(line string, row int, visible bool) bool {
+		if !visible {
+			return true
+		}
+		if t := data.SpawnLinkTarget(line); t != "" {
+			target, linkRow = t, row
+			return false
+		}
+		return true
+	})
+	return target, linkRow
+}
+
+// scanLogLines calls fn for each raw line of the log content, top to bottom,
+// with the wrapped row it starts on and whether that row is on screen, until
+// fn returns false or the lines below the screen are reached. width and
+// viewH must match the panel being rendered.
+func (m Model) scanLogLines(width, viewH int, fn func(line string, row int, visible bool) bool) {
+	raw := strings.Split(m.logContent, "\n")
+	heights := make([]int, len(raw))
+	total := 0
+	for i, line := range raw {
+		heights[i] = 1
+		if width > 0 && len(line) > width {
+			heights[i] = (len(line) + width - 1) / width
+		}
+		total += heights[i]
+	}
+	start := min(m.logScrollPos, max(0, total-viewH))
+	row := 0
+	for i, line := range raw {
+		if row >= start+viewH {
+			return
+		}
+		if !fn(line, row, row >= start) {
+			return
+		}
+		row += heights[i]
+	}
+}
+
+// resolveSpawnLink finds the live session or archived run a sub-agent link
+// points at.
+func (m Model) resolveSpawnLink(target string) (logRef, bool) {
+	for _, s := range m.sessions {
+		if s.Key == target || s.SessionID == target {
+			return logRef{id: s.Key, tab: tabSessions}, true
+		}
+	}
+	for _, r := range m.archived {
+		if r.SessionID == target {
+			return logRef{id: r.Path, tab: tabHistory}, true
+		}
+	}
+	return logRef{}, false
+}
+
+// followSpawnLink opens the transcript of the sub-agent link highlighted in
+// the log panel. ok is false when no link is on screen.
+func (m *Model) followSpawnLink() (tea.Cmd, bool) {
+	target, _ := m.activeSpawnLink(m.logWidth(), max(1, m.logViewHeight()-3-m.logHeaderExtra()))
+	if target == "" {
+		return nil, false
+	}
+	ref, ok := m.resolveSpawnLink(target)
+	if !ok {
+		m.setStatus("sub-agent " + target + " not found among sessions or history")
+		return nil, true
+	}
+	m.logTrail = append(m.logTrail, logRef{id: m.selectedLogID, tab: m.selectedLogTab})
+	return m.openLog(ref.id, ref.tab), true
+}
+
+// followBack returns to the transcript a sub-agent link was followed from.
+func (m *Model) followBack() tea.Cmd {
+	if len(m.logTrail) == 0 {
+		return nil
+	}
+	prev := m.logTrail[len(m.logTrail)-1]
+	m.logTrail = m.logTrail[:len(m.logTrail)-1]
+	return m.openLog(prev.id, prev.tab)
+}
+
+// spawnLinkHint tells the user how to follow the highlighted link or go back.
+func (m Model) spawnLinkHint(width, viewH int) string {
+	if m.activePanel != panelLogs {
+		return ""
+	}
+	var hints []string
+	if target, _ := m.activeSpawnLink(width, viewH); target != "" {
+		hints = append(hints, "↵:open sub-agent")
+	}
+	if m.activeSearchHit(width, viewH) >= 0 {
+		hints = append(hints, "↵:open match")
+	}
+	if len(m.logTrail) > 0 {
+		hints = append(hints, "⌫:back")
+	}
+	if len(hints) == 0 {
+		return ""
+	}
+	return dimStyle.Render("  " + strings.Join(hints, "  "))
+}
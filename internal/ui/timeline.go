@@ -0,0 +1,233 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+const (
+	// timelineHours is how far back the timeline looks unless told.
+	timelineHours = 12
+	// timelineMaxHours bounds the look-back to a week.
+	timelineMaxHours = 7 * 24
+	// timelineNameWidth is the width of the session name column.
+	timelineNameWidth = 22
+)
+
+// timelineTicks are the spacings the time axis labels may use, finest
+// first.
+var timelineTicks = []time.Duration{
+	15 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour,
+	3 * time.Hour, 6 * time.Hour, 12 * time.Hour, 24 * time.Hour,
+}
+
+// timelineRow is one session's activity on the shared time axis.
+type timelineRow struct {
+	name   string
+	counts []int
+}
+
+// timelineMsg carries the activity of every session from start to end.
+type timelineMsg struct {
+	title      string
+	start, end time.Time
+	rows       []timelineRow
+}
+
+// timelineSource is a transcript to plot.
+type timelineSource struct{ name, path string }
+
+// parseTimelineHours reads the look-back given to ":timeline": hours, or a
+// duration such as 90m or 2d.
+func parseTimelineHours(arg string) (time.Duration, bool) {
+	if arg == "" {
+		return timelineHours * time.Hour, true
+	}
+	if n, err := strconv.Atoi(arg); err == nil && n > 0 {
+		return time.Duration(min(n, timelineMaxHours)) * time.Hour, true
+	}
+	d, ok := parseDeadline(arg)
+	if !ok {
+		return 0, false
+	}
+	if d > timelineMaxHours*time.Hour {
+		d = timelineMaxHours * time.Hour
+	}
+	return d, true
+}
+
+// openTimeline plots the activity of every listed session, and of archived
+// runs that ended within the window, on one time axis covering the last
+// span, so it shows which agents worked in parallel and when the fleet sat
+// idle.
+func (m *Model) openTimeline(span time.Duration) tea.Cmd {
+	end := time.Now()
+	start := end.Add(-span)
+	var sources []timelineSource
+	for _, s := range m.visibleSessions() {
+		if path := data.TranscriptPath(s); path != "" {
+			sources = append(sources, timelineSource{sessionDisplayName(s), path})
+		}
+	}
+	for _, r := range m.archived {
+		if time.UnixMilli(r.ModifiedAt).Before(start) {
+			continue
+		}
+		name := r.Label
+		if name == "" {
+			name = r.SessionID
+		}
+		sources = append(sources, timelineSource{name, r.Path})
+	}
+
+	title := "Timeline: last " + formatDuration(span)
+	m.showLogView(title, "Reading transcripts…\n")
+	buckets := max(10, m.logWidth()-timelineNameWidth-3)
+	client := m.client
+	return func() tea.Msg {
+		var rows []timelineRow
+		for _, src := range sources {
+			counts := client.TimelineActivity(src.path, start, end, buckets)
+			for _, c := range counts {
+				if c > 0 {
+					rows = append(rows, timelineRow{src.name, counts})
+					break
+				}
+			}
+		}
+		// Earliest to start first, so the plot reads top-left to bottom-right
+		first := func(r timelineRow) int {
+			for i, c := range r.counts {
+				if c > 0 {
+					return i
+				}
+			}
+			return len(r.counts)
+		}
+		sort.SliceStable(rows, func(i, j int) bool { return first(rows[i]) < first(rows[j]) })
+		return timelineMsg{title: title, start: start, end: end, rows: rows}
+	}
+}
+
+// handleTimeline shows the plotted timeline, unless another view replaced
+// it while the transcripts were read.
+func (m *Model) handleTimeline(msg timelineMsg) {
+	if m.logView != msg.title {
+		return
+	}
+	m.showLogView(msg.title, m.renderTimeline(msg))
+}
+
+// timelineBar draws counts as a row of blocks scaled to their own peak, so
+// a quiet session still shows when it worked. Idle buckets are blank.
+func (m Model) timelineBar(counts []int) string {
+	levels := chartBlocks
+	if m.asciiGlyphs() || m.cfg.A11y {
+		levels = []rune(" .:-=+*#%@")
+	}
+	peak := 0
+	for _, c := range counts {
+		peak = max(peak, c)
+	}
+	var b strings.Builder
+	for _, c := range counts {
+		level := 0
+		if c > 0 {
+			level = (c*(len(levels)-1) + peak - 1) / peak
+		}
+		b.WriteRune(levels[level])
+	}
+	return b.String()
+}
+
+// timelineAxis returns the tick marks and clock labels under the plot,
+// spaced so the labels don't run into each other.
+func timelineAxis(start, end time.Time, buckets int) (ticks, labels string) {
+	span := end.Sub(start)
+	step := timelineTicks[len(timelineTicks)-1]
+	for _, d := range timelineTicks {
+		// Labels are 5 wide; leave room for a gap
+		if float64(buckets)*float64(d)/float64(span) >= 8 {
+			step = d
+			break
+		}
+	}
+	tickRow := []rune(strings.Repeat("─", buckets))
+	labelRow := []rune(strings.Repeat(" ", buckets+5))
+	for t := start.Truncate(step).Add(step); t.Before(end); t = t.Add(step) {
+		col := int(int64(t.Sub(start)) * int64(buckets) / int64(span))
+		if col >= buckets {
+			break
+		}
+		tickRow[col] = '┬'
+		label := t.Format("15:04")
+		if step >= 24*time.Hour || t.Hour() == 0 && t.Minute() == 0 {
+			label = t.Format("Jan 2")
+		}
+		copy(labelRow[col:], []rune(label))
+	}
+	return string(tickRow), strings.TrimRight(string(labelRow), " ")
+}
+
+// renderTimeline lays out one row per session, a fleet row counting how
+// many sessions were active in each slot, the time axis, and what stands
+// out: the most sessions at work at once and the longest idle stretch.
+func (m Model) renderTimeline(msg timelineMsg) string {
+	span := msg.end.Sub(msg.start)
+	if len(msg.rows) == 0 {
+		return fmt.Sprintf("No session was active in the last %s.\n", formatDuration(span))
+	}
+	buckets := len(msg.rows[0].counts)
+	slot := span / time.Duration(buckets)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Activity of %d sessions over the last %s, one column per %s.\n", len(msg.rows), formatDuration(span), formatDuration(slot.Round(time.Second)))
+	b.WriteString("Taller is busier, relative to each session's own peak; blank is idle. The fleet row counts the sessions at work.\n\n")
+
+	fleet := make([]int, buckets)
+	pad := strings.Repeat(" ", timelineNameWidth+1)
+	for _, r := range msg.rows {
+		fmt.Fprintf(&b, "%-*s %s\n", timelineNameWidth, clipLine(r.name, timelineNameWidth), m.timelineBar(r.counts))
+		for i, c := range r.counts {
+			if c > 0 {
+				fleet[i]++
+			}
+		}
+	}
+	fmt.Fprintf(&b, "%-*s %s\n", timelineNameWidth, "fleet", m.timelineBar(fleet))
+	ticks, labels := timelineAxis(msg.start, msg.end, buckets)
+	b.WriteString(pad + ticks + "\n")
+	b.WriteString(pad + labels + "\n\n")
+
+	slotTime := func(i int) time.Time { return msg.start.Add(time.Duration(i) * slot) }
+	peak, peakAt := 0, 0
+	gap, gapAt, run := 0, 0, 0
+	for i, n := range fleet {
+		if n > peak {
+			peak, peakAt = n, i
+		}
+		if n == 0 {
+			run++
+			if run > gap {
+				gap, gapAt = run, i-run+1
+			}
+		} else {
+			run = 0
+		}
+	}
+	fmt.Fprintf(&b, "Most in parallel: %d sessions at %s\n", peak, slotTime(peakAt).Format("Jan 2 15:04"))
+	if gap > 0 {
+		fmt.Fprintf(&b, "Longest fleet-wide idle: %s, from %s to %s\n", formatDuration(time.Duration(gap)*slot),
+			slotTime(gapAt).Format("Jan 2 15:04"), slotTime(gapAt+gap).Format("15:04"))
+	} else {
+		b.WriteString("The fleet was never idle for a whole slot.\n")
+	}
+	return b.String()
+}
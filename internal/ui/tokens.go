@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"fmt"
+	"unicode"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// contextWarnRatio is the context fill level at which the meter turns
+// yellow.
+const contextWarnRatio = 0.9
+
+// tokenSplitWidth is the width of the sessions list token column when it
+// shows input and output separately.
+const tokenSplitWidth = 9
+
+// Input/output ratios beyond which a session is flagged as context-heavy
+// or generation-heavy in the split token column.
+const (
+	contextHeavyRatio    = 20
+	generationHeavyRatio = 1
+)
+
+// tokenColumn renders a session's token use for the sessions list: the
+// total, or with the split on, input/output. Sessions whose input dwarfs
+// their output (context bloat) are shown in yellow, and those that write as
+// much as they read (generation-heavy runs) in the accent color.
+func (m Model) tokenColumn(s data.Session) string {
+	if !m.state.TokenSplit {
+		tok := ""
+		if s.TotalTokens > 0 {
+			tok = formatTokens(s.TotalTokens)
+		}
+		return dimStyle.Render(fmt.Sprintf("%4s", tok))
+	}
+	if s.InputTokens == 0 && s.OutputTokens == 0 {
+		return fmt.Sprintf("%*s", tokenSplitWidth, "")
+	}
+	col := fmt.Sprintf("%*s", tokenSplitWidth, formatTokens(s.InputTokens)+"/"+formatTokens(s.OutputTokens))
+	switch {
+	case s.InputTokens >= contextHeavyRatio*s.OutputTokens:
+		return statusThinking.Render(col)
+	case s.OutputTokens >= generationHeavyRatio*s.InputTokens:
+		return accentStyle.Render(col)
+	}
+	return dimStyle.Render(col)
+}
+
+// estimateTokens approximates how many tokens a BPE tokenizer produces for
+// s: about four characters per token within a word, one token per
+// punctuation mark or symbol, and one per CJK character.
+func estimateTokens(s string) int {
+	n, run := 0, 0 // run is the length of the current word
+	flush := func() {
+		n += (run + 3) / 4
+		run = 0
+	}
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+			unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+			flush()
+			n++
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			run++
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			flush()
+			n++
+		}
+	}
+	flush()
+	return n
+}
+
+// sessionByKey finds a session in the current list.
+func (m Model) sessionByKey(key string) (data.Session, bool) {
+	for _, s := range m.sessions {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return data.Session{}, false
+}
+
+// tokenMeter renders the estimated size of text and, when the target's
+// context window is known, how much room is left — warning when sending it
+// would overflow the window.
+func tokenMeter(text string, target data.Session, known bool) string {
+	est := estimateTokens(text)
+	meter := fmt.Sprintf("~%d tok", est)
+	if !known || target.ContextTokens <= 0 {
+		return dimStyle.Render(meter)
+	}
+
+	left := target.ContextTokens - target.TotalTokens
+	meter += fmt.Sprintf(" · %s/%s left", formatTokens(max(left, 0)), formatTokens(target.ContextTokens))
+	switch {
+	case est > left:
+		return statusFailed.Render(meter + " · overflows context")
+	case float64(target.TotalTokens+est) >= contextWarnRatio*float64(target.ContextTokens):
+		return statusThinking.Render(meter)
+	default:
+		return dimStyle.Render(meter)
+	}
+}
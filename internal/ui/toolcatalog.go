@@ -0,0 +1,297 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// toolCatalog is the gateway's tool list shown in place of the log panel,
+// with an argument form for invoking the selected tool.
+type toolCatalog struct {
+	tools  []data.ToolInfo
+	loaded bool
+	err    error
+	cursor int
+	form   *toolForm
+}
+
+// toolForm edits the arguments of one tool, a field per parameter.
+type toolForm struct {
+	tool    data.ToolInfo
+	inputs  []textinput.Model
+	errs    []string // why each field's value is invalid, or ""
+	focus   int
+	running bool
+}
+
+// toolCatalogMsg carries the gateway's tools.
+type toolCatalogMsg struct {
+	tools []data.ToolInfo
+	err   error
+}
+
+// toolResultMsg carries the result of invoking a tool from its form.
+type toolResultMsg struct {
+	tool   string
+	result string
+	err    error
+}
+
+// openToolCatalog shows the gateway's tools, or closes the catalog.
+func (m *Model) openToolCatalog() tea.Cmd {
+	if m.catalog != nil {
+		m.catalog = nil
+		return nil
+	}
+	m.catalog = &toolCatalog{}
+	client := m.client
+	return func() tea.Msg {
+		tools, err := client.ToolCatalog()
+		return toolCatalogMsg{tools, err}
+	}
+}
+
+// handleToolCatalog fills the catalog.
+func (m *Model) handleToolCatalog(msg toolCatalogMsg) {
+	if m.catalog == nil {
+		return
+	}
+	m.catalog.tools, m.catalog.err, m.catalog.loaded = msg.tools, msg.err, true
+}
+
+// newToolForm builds the argument form for tool, with schema defaults as
+// placeholders.
+func newToolForm(tool data.ToolInfo) *toolForm {
+	f := &toolForm{tool: tool, inputs: make([]textinput.Model, len(tool.Params)), errs: make([]string, len(tool.Params))}
+	for i, p := range tool.Params {
+		in := textinput.New()
+		in.CharLimit = 4096
+		in.Width = 50
+		switch {
+		case p.Default != "":
+			in.Placeholder = p.Default
+		case len(p.Enum) > 0:
+			in.Placeholder = strings.Join(p.Enum, " | ")
+		case p.Type == "array":
+			in.Placeholder = "a, b, c  or  [JSON]"
+		case p.Type == "object":
+			in.Placeholder = "{JSON}"
+		default:
+			in.Placeholder = p.Type
+		}
+		f.inputs[i] = in
+	}
+	if len(f.inputs) > 0 {
+		f.inputs[0].Focus()
+	}
+	return f
+}
+
+// args validates the form, returning the tool arguments when every field
+// holds a valid value. Empty fields with a schema default are left to the
+// tool.
+func (f *toolForm) args() (map[string]interface{}, bool) {
+	args := map[string]interface{}{}
+	ok := true
+	for i, p := range f.tool.Params {
+		p.Required = p.Required && p.Default == ""
+		v, err := data.ParseArg(p, f.inputs[i].Value())
+		f.errs[i] = ""
+		if err != nil {
+			f.errs[i] = "must be " + err.Error()
+			if errors.Is(err, data.ErrArgRequired) {
+				f.errs[i] = err.Error()
+			}
+			ok = false
+			continue
+		}
+		if v != nil {
+			args[p.Name] = v
+		}
+	}
+	return args, ok
+}
+
+// moveFocus focuses the field delta places away.
+func (f *toolForm) moveFocus(delta int) {
+	if len(f.inputs) == 0 {
+		return
+	}
+	f.inputs[f.focus].Blur()
+	f.focus = (f.focus + delta + len(f.inputs)) % len(f.inputs)
+	f.inputs[f.focus].Focus()
+}
+
+// handleCatalogKey handles keys while the catalog is open: ↑/↓ and ↵ pick a
+// tool from the list; in its form, tab moves between fields, ↵ invokes the
+// tool after a y/n confirmation, and esc goes back to the list.
+func (m *Model) handleCatalogKey(msg tea.KeyMsg) tea.Cmd {
+	c := m.catalog
+	f := c.form
+	if msg.String() == "ctrl+c" {
+		return tea.Quit
+	}
+	if f == nil {
+		switch msg.String() {
+		case "esc", "q", "G":
+			m.catalog = nil
+		case "up", "k":
+			c.cursor = max(0, c.cursor-1)
+		case "down", "j":
+			c.cursor = min(c.cursor+1, max(0, len(c.tools)-1))
+		case "enter":
+			if c.cursor < len(c.tools) {
+				c.form = newToolForm(c.tools[c.cursor])
+				return textinput.Blink
+			}
+		}
+		return nil
+	}
+	if f.running {
+		if msg.String() == "esc" {
+			c.form = nil // the result still lands in the log panel
+		}
+		return nil
+	}
+	switch msg.String() {
+	case "esc":
+		c.form = nil
+		return nil
+	case "tab", "down":
+		f.moveFocus(1)
+		return nil
+	case "shift+tab", "up":
+		f.moveFocus(-1)
+		return nil
+	case "enter":
+		args, ok := f.args()
+		if !ok {
+			m.setStatus(fmt.Sprintf("%s: fix the marked fields", f.tool.Name))
+			return nil
+		}
+		raw, _ := json.Marshal(args)
+		name := f.tool.Name
+		client := m.client
+		m.pending = &confirmation{
+			prompt: fmt.Sprintf("Invoke %s %s?", name, clipLine(string(raw), 80)),
+			run: func(m *Model) tea.Cmd {
+				if m.catalog != nil && m.catalog.form != nil {
+					m.catalog.form.running = true
+				}
+				return func() tea.Msg {
+					result, err := client.InvokeTool(name, args)
+					return toolResultMsg{name, result, err}
+				}
+			},
+		}
+		return nil
+	}
+	if len(f.inputs) == 0 {
+		return nil
+	}
+	var cmd tea.Cmd
+	f.inputs[f.focus], cmd = f.inputs[f.focus].Update(msg)
+	return cmd
+}
+
+// handleToolResult shows a tool's result in the log panel, closing the
+// catalog, or keeps the form open to correct the arguments on failure.
+func (m *Model) handleToolResult(msg toolResultMsg) {
+	if m.catalog != nil && m.catalog.form != nil {
+		m.catalog.form.running = false
+	}
+	if msg.err != nil {
+		err := fmt.Errorf("%s: %w", msg.tool, msg.err)
+		m.setStatus(err.Error())
+		m.noteErr(err)
+		return
+	}
+	m.catalog = nil
+	result := msg.result
+	if strings.TrimSpace(result) == "" {
+		result = "(no output)"
+	}
+	m.showLogView("Tool: "+msg.tool, result)
+	m.setStatus("invoked " + msg.tool)
+}
+
+// renderToolCatalog draws the catalog or the open form in place of the log
+// panel.
+func (m Model) renderToolCatalog(width, height int) string {
+	c := m.catalog
+	var b strings.Builder
+	if c.form != nil {
+		f := c.form
+		title := m.deco("🧰", "Invoke "+f.tool.Name)
+		if f.running {
+			title += statusThinking.Render(" " + m.deco("⏳", "running..."))
+		}
+		b.WriteString(titleStyle.Render(clipLine(title, width)) + "\n")
+		if f.tool.Description != "" {
+			b.WriteString(dimStyle.Render(clipLine(firstLine(f.tool.Description), width)) + "\n")
+		}
+		b.WriteString(dimStyle.Render("tab:next field  ↵:invoke  esc:back to tools") + "\n\n")
+		if len(f.inputs) == 0 {
+			b.WriteString(dimStyle.Render("This tool takes no arguments.") + "\n")
+		}
+		for i, p := range f.tool.Params {
+			label := p.Name
+			if p.Required {
+				label += "*"
+			}
+			style := dimStyle
+			if i == f.focus {
+				style = accentStyle
+			}
+			kind := p.Type
+			if p.Type == "array" && p.Items != "" {
+				kind = p.Items + "[]"
+			}
+			b.WriteString(m.cursorMark(i == f.focus) + style.Render(label) + " " + dimStyle.Render("("+kind+")") + "  " + f.inputs[i].View() + "\n")
+			if f.errs[i] != "" {
+				b.WriteString("    " + statusFailed.Render(m.deco("✗", f.errs[i])) + "\n")
+			} else if p.Description != "" {
+				b.WriteString("    " + dimStyle.Render(clipLine(firstLine(p.Description), width-4)) + "\n")
+			}
+		}
+		return b.String()
+	}
+
+	b.WriteString(titleStyle.Render(m.deco("🧰", fmt.Sprintf("Gateway tools (%d)", len(c.tools)))) + "\n")
+	b.WriteString(dimStyle.Render("↑/↓:select  ↵:fill in arguments and invoke  esc:close") + "\n\n")
+	switch {
+	case !c.loaded:
+		b.WriteString("Loading...\n")
+		return b.String()
+	case c.err != nil:
+		b.WriteString(statusFailed.Render("✗ "+c.err.Error()) + "\n")
+		return b.String()
+	}
+	viewH := max(1, height-4)
+	start := max(0, min(c.cursor-viewH/2, len(c.tools)-viewH))
+	for i := start; i < len(c.tools) && i < start+viewH; i++ {
+		t := c.tools[i]
+		line := clipLine(t.Name, width-2)
+		if i == c.cursor {
+			line = selectedStyle.Render(line)
+		}
+		if room := width - 4 - len([]rune(t.Name)); t.Description != "" && room > 8 {
+			line += "  " + dimStyle.Render(clipLine(firstLine(t.Description), room))
+		}
+		b.WriteString(m.cursorMark(i == c.cursor) + line + "\n")
+	}
+	return b.String()
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return line
+}
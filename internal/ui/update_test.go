@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+	"github.com/jaigner-hub/openclaw-commander/internal/fakegateway"
+)
+
+// fixtures are the recorded gateway exchanges and session list shared with
+// the data package's tests.
+var fixtures = filepath.Join("..", "data", "testdata")
+
+// fixtureModel returns a model whose gateway replays the fixtures, with its
+// state kept in a temp home.
+func fixtureModel(t *testing.T) Model {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	exchanges, err := fakegateway.LoadFixtures(filepath.Join(fixtures, "gateway.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(fakegateway.NewServer(exchanges))
+	t.Cleanup(srv.Close)
+	cfg := config.Config{
+		GatewayURL:      srv.URL,
+		Transport:       config.TransportHTTP,
+		FetchDepth:      config.DefaultFetchDepth,
+		ProcessLogLines: config.DefaultFetchDepth,
+		LogMemory:       config.DefaultLogMemoryMB << 20,
+	}
+	m := NewModel(cfg)
+	m.width, m.height = 120, 40
+	return m
+}
+
+// fixtureSessions reads the session list the openclaw CLI printed.
+func fixtureSessions(t *testing.T) []data.Session {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join(fixtures, "sessions.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var resp data.SessionsResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp.Sessions
+}
+
+// update feeds msg to m and returns the model it became.
+func update(t *testing.T, m Model, msg tea.Msg) (Model, tea.Cmd) {
+	t.Helper()
+	next, cmd := m.Update(msg)
+	return next.(Model), cmd
+}
+
+func keyPress(s string) tea.KeyMsg {
+	if s == "enter" {
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestSessionsThenOpenLog(t *testing.T) {
+	m := fixtureModel(t)
+	m, _ = update(t, m, sessionsMsg{fixtureSessions(t)})
+
+	const key = "agent:main:subagent:fix-flaky-test"
+	m.sessionCursor = -1
+	for i, s := range m.filteredSessions() {
+		if s.Key == key {
+			m.sessionCursor = i
+		}
+	}
+	if m.sessionCursor < 0 {
+		t.Fatalf("%s isn't listed", key)
+	}
+	m, _ = update(t, m, keyPress("enter"))
+	if m.selectedLogID != key || m.activePanel != panelLogs {
+		t.Fatalf("enter opened %q (panel %d), want %q in the log panel", m.selectedLogID, m.activePanel, key)
+	}
+
+	// Replay the fetch the open started
+	msg := m.fetchLogs(m.selectedLogID)()
+	logs, ok := msg.(logsMsg)
+	if !ok {
+		t.Fatalf("fetch returned %T: %+v", msg, msg)
+	}
+	m, _ = update(t, m, logs)
+	if !strings.Contains(m.logContent, "Sorted the keys before comparing") {
+		t.Errorf("log panel misses the last reply:\n%s", m.logContent)
+	}
+	if len(m.cachedMessages) == 0 || m.cachedLogTab != tabSessions {
+		t.Errorf("%d messages cached for tab %d", len(m.cachedMessages), m.cachedLogTab)
+	}
+}
+
+func TestFailedFetchRetry(t *testing.T) {
+	m := fixtureModel(t)
+	m.selectedLogID = "agent:main:subagent:private"
+	m.selectedLogTab = tabSessions
+
+	msg := m.fetchLogs(m.selectedLogID)()
+	failed, ok := msg.(errMsg)
+	if !ok {
+		t.Fatalf("fetch of a forbidden session returned %T, want errMsg", msg)
+	}
+	m, _ = update(t, m, failed)
+	if m.lastErr == nil || m.lastRetry == nil {
+		t.Fatal("the failure isn't retryable")
+	}
+
+	// Other polls succeeding leave it in place
+	m, _ = update(t, m, processesMsg{})
+	m, _ = update(t, m, sessionsMsg{fixtureSessions(t)})
+	if m.lastRetry == nil {
+		t.Fatalf("a poll cleared the log fetch's error; status %q", m.lastError)
+	}
+
+	m, cmd := update(t, m, keyPress("R"))
+	if cmd == nil {
+		t.Fatal("R has nothing to retry")
+	}
+	if m.lastError != "retrying..." {
+		t.Errorf("status %q while retrying", m.lastError)
+	}
+	if _, ok := cmd().(errMsg); !ok {
+		t.Fatal("the retry didn't re-run the failed fetch")
+	}
+
+	// Its detail outlives the status bar, which the retry cleared
+	m, _ = update(t, m, keyPress("E"))
+	if m.logView != errorDetailTitle || !strings.Contains(m.logContent, "private") {
+		t.Fatalf("E showed %q:\n%s", m.logView, m.logContent)
+	}
+}
+
+func TestRetriedPollClearsItsError(t *testing.T) {
+	m := fixtureModel(t)
+	m, _ = update(t, m, errMsg{err: os.ErrDeadlineExceeded, retry: m.fetchProcesses, source: pollProcesses})
+	m, _ = update(t, m, keyPress("R"))
+	m, _ = update(t, m, processesMsg{})
+	if m.lastError != "" {
+		t.Errorf("status %q after the retried poll succeeded", m.lastError)
+	}
+}
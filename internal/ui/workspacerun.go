@@ -0,0 +1,175 @@
+package ui
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/jaigner-hub/openclaw-commander/internal/data"
+)
+
+// execPollInterval is how often a backgrounded workspace command is polled
+// for new output.
+const execPollInterval = time.Second
+
+// execOutputMsg carries output from a workspace command; run tells stale
+// output from an earlier command apart.
+type execOutputMsg struct {
+	run    int
+	result data.ExecResult
+	err    error
+}
+
+// execPollMsg asks for the next output of a backgrounded command.
+type execPollMsg struct {
+	run       int
+	sessionID string
+}
+
+// workspaceTarget returns the session whose workspace commands run in: the
+// one under the cursor in the Sessions tab, or else the one whose log is
+// open.
+func (m Model) workspaceTarget() (data.Session, bool) {
+	if m.activeTab == tabSessions {
+		if ss := m.filteredSessions(); m.sessionCursor < len(ss) {
+			return ss[m.sessionCursor], true
+		}
+	}
+	if m.selectedLogTab == tabSessions && m.selectedLogID != "" {
+		return m.sessionByKey(m.selectedLogID)
+	}
+	return data.Session{}, false
+}
+
+// openRunPrompt asks for a command to run in the selected session's
+// workspace, starting from the first configured command.
+func (m *Model) openRunPrompt() tea.Cmd {
+	s, ok := m.workspaceTarget()
+	if !ok {
+		m.setStatus("run: select a session")
+		return nil
+	}
+	if s.Workspace == "" {
+		m.setStatus("run: " + sessionDisplayName(s) + " has no workspace")
+		return nil
+	}
+	m.runTarget = s
+	m.runPrompting = true
+	m.runChoice = 0
+	m.runInput.SetValue("")
+	if len(m.cfg.WorkspaceCommands) > 0 {
+		m.runInput.SetValue(m.cfg.WorkspaceCommands[0])
+	}
+	m.runInput.CursorEnd()
+	m.runInput.Focus()
+	return textinput.Blink
+}
+
+// handleRunPrompt handles keys while the run prompt is open. Tab cycles
+// through the configured commands.
+func (m *Model) handleRunPrompt(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, keys.Escape):
+		m.runPrompting = false
+		return nil
+	case key.Matches(msg, keys.Enter):
+		m.runPrompting = false
+		command := strings.TrimSpace(m.runInput.Value())
+		if command == "" {
+			return nil
+		}
+		return m.startRun(command)
+	case key.Matches(msg, keys.Tab):
+		if n := len(m.cfg.WorkspaceCommands); n > 0 {
+			m.runChoice = (m.runChoice + 1) % n
+			m.runInput.SetValue(m.cfg.WorkspaceCommands[m.runChoice])
+			m.runInput.CursorEnd()
+		}
+		return nil
+	}
+	var cmd tea.Cmd
+	m.runInput, cmd = m.runInput.Update(msg)
+	return cmd
+}
+
+// startRun runs command in the target session's workspace through the
+// gateway and shows its output in the log panel as it arrives.
+func (m *Model) startRun(command string) tea.Cmd {
+	m.runSeq++
+	m.runTitle = "$ " + command + " — " + sessionDisplayName(m.runTarget)
+	m.runOutput = "$ " + command + "\n" + "(in " + m.runTarget.Workspace + ")\n\n"
+	m.showLogView(m.runTitle, m.runOutput)
+	m.setStatus(m.deco("⏳", "running "+command+"..."))
+	run, workdir := m.runSeq, m.runTarget.Workspace
+	client := m.client
+	return func() tea.Msg {
+		res, err := client.ExecCommand(command, workdir)
+		return execOutputMsg{run: run, result: res, err: err}
+	}
+}
+
+// handleExecOutput appends a command's output to the log panel, polling for
+// more while the command runs.
+func (m *Model) handleExecOutput(msg execOutputMsg) tea.Cmd {
+	if msg.run != m.runSeq {
+		return nil
+	}
+	if msg.err != nil {
+		m.appendRunOutput("\n✗ " + msg.err.Error() + "\n")
+		m.setStatus("run failed: " + msg.err.Error())
+		return nil
+	}
+	res := msg.result
+	m.appendRunOutput(res.Output)
+	if res.Running && res.SessionID != "" {
+		run, sessionID := msg.run, res.SessionID
+		return tea.Tick(execPollInterval, func(time.Time) tea.Msg {
+			return execPollMsg{run: run, sessionID: sessionID}
+		})
+	}
+	if res.Failed {
+		m.appendRunOutput(fmt.Sprintf("\n✗ exited with status %d\n", res.ExitCode))
+		m.setStatus(fmt.Sprintf("command failed (exit %d)", res.ExitCode))
+	} else {
+		m.appendRunOutput("\n✓ done\n")
+		m.setStatus("command finished")
+	}
+	return nil
+}
+
+// pollRun fetches the next output of a backgrounded command.
+func (m *Model) pollRun(msg execPollMsg) tea.Cmd {
+	if msg.run != m.runSeq {
+		return nil
+	}
+	client := m.client
+	return func() tea.Msg {
+		res, err := client.PollCommand(msg.sessionID)
+		return execOutputMsg{run: msg.run, result: res, err: err}
+	}
+}
+
+// appendRunOutput adds text to the running command's output, updating the
+// log panel if it still shows the command and keeping it scrolled to the
+// bottom when it was there.
+func (m *Model) appendRunOutput(text string) {
+	if text == "" {
+		return
+	}
+	m.runOutput += text
+	if m.logView != m.runTitle {
+		return
+	}
+	w := m.logWidth()
+	atBottom := m.isAtBottom(w)
+	m.logContent = m.runOutput
+	m.logContentHash = fmt.Sprintf("%x", sha256.Sum256([]byte(m.logContent)))
+	if atBottom {
+		m.logScrollPos = m.maxLogScroll(w)
+	}
+}
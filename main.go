@@ -3,25 +3,121 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net/http"
+	neturl "net/url"
 	"os"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/jaigner-hub/openclaw-commander/internal/config"
+	"github.com/jaigner-hub/openclaw-commander/internal/fakegateway"
+	"github.com/jaigner-hub/openclaw-commander/internal/serve"
 	"github.com/jaigner-hub/openclaw-commander/internal/ui"
 )
 
 func main() {
 	token := flag.String("token", "", "Gateway auth token (overrides env/config file)")
 	url := flag.String("url", "", "Gateway URL (default: http://127.0.0.1:18789)")
+	proxy := flag.String("proxy", "", "Proxy URL for gateway requests, e.g. http://host:3128 or socks5://host:1080 (default: HTTP_PROXY/HTTPS_PROXY env)")
+	a11y := flag.Bool("a11y", false, "Screen-reader friendly output: status words instead of emoji, panels stacked in reading order (env: OPENCLAW_COMMANDER_A11Y)")
+	noColor := flag.Bool("no-color", false, "Disable color output (env: NO_COLOR)")
+	noEmoji := flag.Bool("no-emoji", false, "Replace emoji and other non-ASCII glyphs with ASCII (env: OPENCLAW_COMMANDER_NO_EMOJI)")
+	glyphs := flag.String("glyphs", "", "Status indicators: emoji, ascii (colored ASCII for terminals that render emoji poorly), or auto to detect (env: OPENCLAW_COMMANDER_GLYPHS)")
+	record := flag.String("record", "", "Record gateway responses to this fixture file (JSONL) while running, for replay with --replay")
+	replay := flag.String("replay", "", "Run against a fake gateway that replays this fixture file instead of the real one")
+	session := flag.String("session", "", "Open this session (key, ID, or label) at startup and follow its logs")
+	tab := flag.String("tab", "", "Tab to show at startup: sessions, processes, history, or activity (or 1-4), or auto to pick History when the gateway is down and Processes when one has failed (the default)")
+	filter := flag.String("filter", "", "List filter to apply at startup, as typed after /")
+	serveAddr := flag.String("serve", "", "Serve the aggregated sessions/processes/history/health as JSON on this address (e.g. :8787) instead of starting the TUI")
 	flag.Parse()
 
-	cfg := config.Load(*url, *token)
+	cfg := config.Load(*url, *token, *proxy)
+	if *a11y {
+		cfg.A11y = true
+	}
+	if *noColor {
+		cfg.NoColor = true
+	}
+	if *noEmoji {
+		cfg.NoEmoji = true
+	}
+	if *glyphs != "" {
+		cfg.Glyphs = *glyphs
+	}
+	cfg.StartSession, cfg.StartFilter = *session, *filter
+	if *tab != "" {
+		cfg.StartTab = *tab
+	}
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	cfg.ResolveGlyphs()
+
+	if err := startFakeGateway(&cfg, *record, *replay); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *serveAddr != "" {
+		if err := serve.Run(cfg, *serveAddr); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	m := ui.NewModel(cfg)
 	p := tea.NewProgram(m, tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
+	final, err := p.Run()
+	if fm, ok := final.(ui.Model); ok {
+		fm.Close()
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// startFakeGateway puts a recording proxy or a replaying fake gateway in
+// front of the Commander and points cfg at it.
+func startFakeGateway(cfg *config.Config, record, replay string) error {
+	if record == "" && replay == "" {
+		return nil
+	}
+	switch {
+	case record != "" && replay != "":
+		return fmt.Errorf("--record and --replay cannot be combined")
+	case cfg.Transport == config.TransportMCP:
+		return fmt.Errorf("--record and --replay need the http transport")
+	}
+
+	var h http.Handler
+	if replay != "" {
+		exchanges, err := fakegateway.LoadFixtures(replay)
+		if err != nil {
+			return fmt.Errorf("load fixtures: %w", err)
+		}
+		h = fakegateway.NewServer(exchanges)
+	} else {
+		f, err := os.OpenFile(record, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return fmt.Errorf("open fixture file: %w", err)
+		}
+		// The recorder reaches the real gateway, so it takes over the proxy
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if cfg.Proxy != "" {
+			if u, err := neturl.Parse(cfg.Proxy); err == nil {
+				transport.Proxy = http.ProxyURL(u)
+			}
+		}
+		h = fakegateway.NewRecorder(cfg.GatewayURL, &http.Client{Timeout: 30 * time.Second, Transport: transport}, f)
+	}
+	u, err := fakegateway.Start(h)
+	if err != nil {
+		return err
+	}
+	cfg.GatewayURL, cfg.Proxy = u, ""
+	return nil
+}